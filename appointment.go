@@ -31,6 +31,9 @@ type AppointmentServicer interface {
 	Get(ctx context.Context, id int64) (*Appointment, *http.Response, error)
 	Update(ctx context.Context, id int64, update *AppointmentUpdate) (*Appointment, *http.Response, error)
 	Delete(ctx context.Context, id int64) (*http.Response, error)
+	CheckIn(ctx context.Context, id int64, room string) (*Appointment, *http.Response, error)
+	Cancel(ctx context.Context, id int64, reason string) (*Appointment, *http.Response, error)
+	MarkNoShow(ctx context.Context, id int64) (*Appointment, *http.Response, error)
 }
 
 var _ AppointmentServicer = (*AppointmentService)(nil)
@@ -94,9 +97,10 @@ type Appointment struct {
 }
 
 type AppointmentStatus struct {
-	Status     string `json:"status"`
-	Room       string `json:"room"`
-	StatusDate string `json:"status_date"`
+	Status       AppointmentStatusType `json:"status"`
+	Room         string                `json:"room"`
+	StatusDate   string                `json:"status_date"`
+	StatusDetail string                `json:"status_detail"`
 }
 
 type AppointmentServiceLocation struct {
@@ -181,8 +185,9 @@ type AppointmentUpdate struct {
 }
 
 type AppointmentUpdateStatus struct {
-	Status string `json:"status"`         // Required
-	Room   string `json:"room,omitempty"` // Optional
+	Status       AppointmentStatusType `json:"status"`                  // Required
+	Room         string                `json:"room,omitempty"`          // Optional
+	StatusDetail string                `json:"status_detail,omitempty"` // Optional
 }
 
 func (s *AppointmentService) Update(ctx context.Context, id int64, update *AppointmentUpdate) (*Appointment, *http.Response, error) {
@@ -214,3 +219,87 @@ func (s *AppointmentService) Delete(ctx context.Context, id int64) (*http.Respon
 
 	return res, nil
 }
+
+// CheckIn changes the status of an appointment to Checked In, in room if it is not empty.
+//
+// The current status is read and checked before the status is updated, in separate requests. A status changed by
+// someone else in between is overwritten, so the check does not guard against concurrent changes.
+func (s *AppointmentService) CheckIn(ctx context.Context, id int64, room string) (*Appointment, *http.Response, error) {
+	return s.transition(ctx, "check in appointment", id, &AppointmentUpdateStatus{
+		Status: AppointmentStatusCheckedIn,
+		Room:   room,
+	})
+}
+
+// Cancel changes the status of an appointment to Cancelled, with reason as the status detail. Like CheckIn, it does
+// not guard against the status changing between the check and the update.
+func (s *AppointmentService) Cancel(ctx context.Context, id int64, reason string) (*Appointment, *http.Response, error) {
+	return s.transition(ctx, "cancel appointment", id, &AppointmentUpdateStatus{
+		Status:       AppointmentStatusCancelled,
+		StatusDetail: reason,
+	})
+}
+
+// MarkNoShow changes the status of an appointment to Not Seen. Like CheckIn, it does not guard against the status
+// changing between the check and the update.
+func (s *AppointmentService) MarkNoShow(ctx context.Context, id int64) (*Appointment, *http.Response, error) {
+	return s.transition(ctx, "mark appointment no-show", id, &AppointmentUpdateStatus{
+		Status: AppointmentStatusNotSeen,
+	})
+}
+
+// transition gets an appointment and updates its status if the current status can change to status. Invalid
+// transitions are not sent; they return a 409 Conflict response and an *Error that matches ErrConflict and wraps
+// ErrInvalidStatusTransition. The API has no
+// conditional update, so a status changed between the Get and the Update is overwritten.
+func (s *AppointmentService) transition(ctx context.Context, name string, id int64, status *AppointmentUpdateStatus) (*Appointment, *http.Response, error) {
+	ctx, span := s.client.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attribute.Int64("elation.appointment_id", id)))
+	defer span.End()
+
+	appointment, res, err := s.Get(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error getting appointment")
+		return nil, res, fmt.Errorf("getting appointment: %w", err)
+	}
+
+	from := appointment.CurrentStatus()
+	span.SetAttributes(
+		attribute.String("elation.appointment_status_from", string(from)),
+		attribute.String("elation.appointment_status_to", string(status.Status)),
+	)
+
+	err = from.ValidateTransition(status.Status)
+	if err != nil {
+		// Report the refused update like the API reports a conflict, so callers handle both the same way.
+		err = &Error{
+			StatusCode: http.StatusConflict,
+			Method:     http.MethodPatch,
+			Path:       "/appointments/" + strconv.FormatInt(id, 10),
+			Err:        err,
+		}
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid status transition")
+		return nil, conflictResponse(), err
+	}
+
+	out, res, err := s.Update(ctx, id, &AppointmentUpdate{Status: status})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error updating appointment")
+		return nil, res, fmt.Errorf("updating appointment: %w", err)
+	}
+
+	return out, res, nil
+}
+
+// conflictResponse is the response for an update that the client refuses to send.
+func conflictResponse() *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusConflict,
+		Status:     strconv.Itoa(http.StatusConflict) + " " + http.StatusText(http.StatusConflict),
+		Header:     http.Header{},
+		Body:       http.NoBody,
+	}
+}
@@ -0,0 +1,122 @@
+package elation
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type AppointmentStatusType string
+
+const (
+	AppointmentStatusScheduled         AppointmentStatusType = "Scheduled"
+	AppointmentStatusConfirmed         AppointmentStatusType = "Confirmed"
+	AppointmentStatusCheckedIn         AppointmentStatusType = "Checked In"
+	AppointmentStatusInRoom            AppointmentStatusType = "In Room"
+	AppointmentStatusInRoomVitalsTaken AppointmentStatusType = "In Room - Vitals Taken"
+	AppointmentStatusWithDoctor        AppointmentStatusType = "With Doctor"
+	AppointmentStatusCheckedOut        AppointmentStatusType = "Checked Out"
+	AppointmentStatusBilled            AppointmentStatusType = "Billed"
+	AppointmentStatusCancelled         AppointmentStatusType = "Cancelled"
+	AppointmentStatusNotSeen           AppointmentStatusType = "Not Seen" // The patient did not show up.
+)
+
+var ErrInvalidStatusTransition = errors.New("invalid appointment status transition")
+
+// appointmentStatusTransitions are the statuses each status can change to. Billed, Cancelled and Not Seen are final.
+var appointmentStatusTransitions = map[AppointmentStatusType][]AppointmentStatusType{
+	AppointmentStatusScheduled: {
+		AppointmentStatusConfirmed,
+		AppointmentStatusCheckedIn,
+		AppointmentStatusCancelled,
+		AppointmentStatusNotSeen,
+	},
+	AppointmentStatusConfirmed: {
+		AppointmentStatusCheckedIn,
+		AppointmentStatusCancelled,
+		AppointmentStatusNotSeen,
+	},
+	AppointmentStatusCheckedIn: {
+		AppointmentStatusInRoom,
+		AppointmentStatusInRoomVitalsTaken,
+		AppointmentStatusWithDoctor,
+		AppointmentStatusCheckedOut,
+		AppointmentStatusCancelled,
+	},
+	AppointmentStatusInRoom: {
+		AppointmentStatusInRoomVitalsTaken,
+		AppointmentStatusWithDoctor,
+		AppointmentStatusCheckedOut,
+	},
+	AppointmentStatusInRoomVitalsTaken: {
+		AppointmentStatusWithDoctor,
+		AppointmentStatusCheckedOut,
+	},
+	AppointmentStatusWithDoctor: {
+		AppointmentStatusCheckedOut,
+	},
+	AppointmentStatusCheckedOut: {
+		AppointmentStatusBilled,
+	},
+}
+
+// AppointmentStatuses returns every appointment status.
+func AppointmentStatuses() []AppointmentStatusType {
+	return []AppointmentStatusType{
+		AppointmentStatusScheduled,
+		AppointmentStatusConfirmed,
+		AppointmentStatusCheckedIn,
+		AppointmentStatusInRoom,
+		AppointmentStatusInRoomVitalsTaken,
+		AppointmentStatusWithDoctor,
+		AppointmentStatusCheckedOut,
+		AppointmentStatusBilled,
+		AppointmentStatusCancelled,
+		AppointmentStatusNotSeen,
+	}
+}
+
+// normalize returns the status that matches s regardless of case, or s if there is none.
+func (s AppointmentStatusType) normalize() AppointmentStatusType {
+	for _, status := range AppointmentStatuses() {
+		if s.Is(status) {
+			return status
+		}
+	}
+
+	return s
+}
+
+// Is reports whether s is status, ignoring case.
+func (s AppointmentStatusType) Is(status AppointmentStatusType) bool {
+	return strings.EqualFold(string(s), string(status))
+}
+
+// CanTransitionTo reports whether an appointment with status s can be changed to next. An empty status is Scheduled.
+func (s AppointmentStatusType) CanTransitionTo(next AppointmentStatusType) bool {
+	from := s.normalize()
+	if from == "" {
+		from = AppointmentStatusScheduled
+	}
+
+	return slices.Contains(appointmentStatusTransitions[from], next.normalize())
+}
+
+// ValidateTransition returns an error that wraps ErrInvalidStatusTransition if s cannot be changed to next.
+func (s AppointmentStatusType) ValidateTransition(next AppointmentStatusType) error {
+	if !s.CanTransitionTo(next) {
+		return fmt.Errorf("%w from %q to %q", ErrInvalidStatusTransition, s, next)
+	}
+
+	return nil
+}
+
+// CurrentStatus returns the status of a, which is Scheduled if it has none.
+func (a *Appointment) CurrentStatus() AppointmentStatusType {
+	if a.Status == nil || a.Status.Status == "" {
+		return AppointmentStatusScheduled
+	}
+
+	return a.Status.Status.normalize()
+}
@@ -0,0 +1,89 @@
+package elation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppointmentStatusType_CanTransitionTo(t *testing.T) {
+	testCases := map[string]struct {
+		from     AppointmentStatusType
+		to       AppointmentStatusType
+		expected bool
+	}{
+		"scheduled to confirmed": {
+			from:     AppointmentStatusScheduled,
+			to:       AppointmentStatusConfirmed,
+			expected: true,
+		},
+		"empty to checked in": {
+			from:     "",
+			to:       AppointmentStatusCheckedIn,
+			expected: true,
+		},
+		"checked in to with doctor": {
+			from:     AppointmentStatusCheckedIn,
+			to:       AppointmentStatusWithDoctor,
+			expected: true,
+		},
+		"checked out to billed": {
+			from:     AppointmentStatusCheckedOut,
+			to:       AppointmentStatusBilled,
+			expected: true,
+		},
+		"case insensitive": {
+			from:     "checked in",
+			to:       "IN ROOM",
+			expected: true,
+		},
+		"scheduled to checked out": {
+			from:     AppointmentStatusScheduled,
+			to:       AppointmentStatusCheckedOut,
+			expected: false,
+		},
+		"with doctor to checked in": {
+			from:     AppointmentStatusWithDoctor,
+			to:       AppointmentStatusCheckedIn,
+			expected: false,
+		},
+		"cancelled to scheduled": {
+			from:     AppointmentStatusCancelled,
+			to:       AppointmentStatusScheduled,
+			expected: false,
+		},
+		"not seen to checked in": {
+			from:     AppointmentStatusNotSeen,
+			to:       AppointmentStatusCheckedIn,
+			expected: false,
+		},
+		"unknown": {
+			from:     AppointmentStatusScheduled,
+			to:       "Rescheduled",
+			expected: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			assert.Equal(testCase.expected, testCase.from.CanTransitionTo(testCase.to))
+
+			err := testCase.from.ValidateTransition(testCase.to)
+			if testCase.expected {
+				assert.NoError(err)
+			} else {
+				assert.ErrorIs(err, ErrInvalidStatusTransition)
+			}
+		})
+	}
+}
+
+func TestAppointment_CurrentStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(AppointmentStatusScheduled, (&Appointment{}).CurrentStatus())
+	assert.Equal(AppointmentStatusScheduled, (&Appointment{Status: &AppointmentStatus{}}).CurrentStatus())
+	assert.Equal(AppointmentStatusCheckedIn, (&Appointment{Status: &AppointmentStatus{Status: "checked in"}}).CurrentStatus())
+}
@@ -251,3 +251,113 @@ func TestAppointmentService_Delete(t *testing.T) {
 	assert.NotNil(res)
 	assert.NoError(err)
 }
+
+func TestAppointmentService_transitions(t *testing.T) {
+	var id int64 = 1
+
+	testCases := map[string]struct {
+		current        AppointmentStatusType
+		transition     func(svc *AppointmentService) (*Appointment, *http.Response, error)
+		expectedUpdate *AppointmentUpdateStatus
+		expectedErr    error
+	}{
+		"check in": {
+			current: AppointmentStatusConfirmed,
+			transition: func(svc *AppointmentService) (*Appointment, *http.Response, error) {
+				return svc.CheckIn(context.Background(), id, "Room 1")
+			},
+			expectedUpdate: &AppointmentUpdateStatus{Status: AppointmentStatusCheckedIn, Room: "Room 1"},
+		},
+		"cancel": {
+			current: AppointmentStatusScheduled,
+			transition: func(svc *AppointmentService) (*Appointment, *http.Response, error) {
+				return svc.Cancel(context.Background(), id, "Patient request")
+			},
+			expectedUpdate: &AppointmentUpdateStatus{Status: AppointmentStatusCancelled, StatusDetail: "Patient request"},
+		},
+		"mark no-show": {
+			current: "",
+			transition: func(svc *AppointmentService) (*Appointment, *http.Response, error) {
+				return svc.MarkNoShow(context.Background(), id)
+			},
+			expectedUpdate: &AppointmentUpdateStatus{Status: AppointmentStatusNotSeen},
+		},
+		"check in checked out": {
+			current: AppointmentStatusCheckedOut,
+			transition: func(svc *AppointmentService) (*Appointment, *http.Response, error) {
+				return svc.CheckIn(context.Background(), id, "")
+			},
+			expectedErr: ErrInvalidStatusTransition,
+		},
+		"cancel with doctor": {
+			current: AppointmentStatusWithDoctor,
+			transition: func(svc *AppointmentService) (*Appointment, *http.Response, error) {
+				return svc.Cancel(context.Background(), id, "")
+			},
+			expectedErr: ErrInvalidStatusTransition,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			updated := false
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tokenRequest(w, r) {
+					return
+				}
+
+				assert.Equal("/appointments/"+strconv.FormatInt(id, 10), r.URL.Path)
+
+				appointment := &Appointment{ID: id, Status: &AppointmentStatus{Status: testCase.current}}
+
+				switch r.Method {
+				case http.MethodGet:
+				case http.MethodPatch:
+					updated = true
+
+					actual := &AppointmentUpdate{}
+					assert.NoError(json.NewDecoder(r.Body).Decode(actual))
+					assert.Equal(&AppointmentUpdate{Status: testCase.expectedUpdate}, actual)
+
+					appointment.Status = &AppointmentStatus{Status: actual.Status.Status, Room: actual.Status.Room}
+				default:
+					t.Errorf("unexpected method %s", r.Method)
+				}
+
+				b, err := json.Marshal(appointment)
+				assert.NoError(err)
+
+				w.Header().Set("Content-Type", "application/json")
+				//nolint
+				w.Write(b)
+			}))
+			defer srv.Close()
+
+			client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+			svc := &AppointmentService{client}
+
+			appointment, res, err := testCase.transition(svc)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(err, testCase.expectedErr)
+				assert.True(IsConflict(err))
+				assert.Nil(appointment)
+				assert.False(updated)
+				if assert.NotNil(res) {
+					assert.Equal(http.StatusConflict, res.StatusCode)
+				}
+
+				return
+			}
+
+			assert.NoError(err)
+			assert.True(updated)
+			if assert.NotNil(appointment) {
+				assert.Equal(testCase.expectedUpdate.Status, appointment.CurrentStatus())
+			}
+		})
+	}
+}
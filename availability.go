@@ -0,0 +1,250 @@
+package elation
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// AvailabilityOptions selects the open slots found by FindAvailability.
+type AvailabilityOptions struct {
+	// Practice is the practice whose schedules are searched. Its timezone is the timezone of the schedules.
+	Practice int64
+
+	// Physicians are the physicians to search. Defaults to the physicians of the practice.
+	Physicians []int64
+
+	// From and To are the window to search.
+	From time.Time
+	To   time.Time
+
+	// Duration is the length of the slots.
+	Duration time.Duration
+
+	// Interval is the time between the starts of consecutive slots. Defaults to Duration.
+	Interval time.Duration
+}
+
+// OpenSlot is a bookable slot of a physician.
+type OpenSlot struct {
+	Physician int64
+	Start     time.Time
+	End       time.Time
+}
+
+type interval struct {
+	start time.Time
+	end   time.Time
+}
+
+// FindAvailability returns the open slots of opts.Duration in the window of opts, sorted by start and then physician.
+//
+// The appointment slot recurring event groups of the practice are expanded into the times physicians are available.
+// Occurrences of event recurring event groups, and the appointments and events returned by Appointments().Find that
+// are not cancelled, are subtracted. Slots start every opts.Interval from the start of each appointment slot occurrence,
+// so they stay on the schedule's grid however the window is chosen and whatever is booked before them.
+func FindAvailability(ctx context.Context, client Client, opts AvailabilityOptions) ([]*OpenSlot, error) {
+	if opts.Duration <= 0 {
+		return nil, errors.New("duration must be positive")
+	}
+
+	if !opts.From.Before(opts.To) {
+		return nil, errors.New("from must be before to")
+	}
+
+	step := opts.Interval
+	if step <= 0 {
+		step = opts.Duration
+	}
+
+	practice, _, err := client.Practices().Get(ctx, opts.Practice)
+	if err != nil {
+		return nil, fmt.Errorf("getting practice: %w", err)
+	}
+
+	loc, err := practice.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	physicians := opts.Physicians
+	if len(physicians) == 0 {
+		physicians = practice.Physicians
+	}
+
+	available := map[int64][]interval{}
+	busy := map[int64][]interval{}
+
+	groups := All(ctx, client.RecurringEventGroups().Find, &FindRecurringEventGroupsOptions{
+		Practice:  []int64{opts.Practice},
+		Physician: physicians,
+		StartDate: opts.From.In(loc).Format(time.DateOnly),
+		EndDate:   opts.To.In(loc).Format(time.DateOnly),
+	})
+	for group, err := range groups {
+		if err != nil {
+			return nil, fmt.Errorf("finding recurring event groups: %w", err)
+		}
+
+		if group.DeletedDate != nil {
+			continue
+		}
+
+		for _, schedule := range group.Schedules {
+			if !slices.Contains(physicians, schedule.Physician) {
+				continue
+			}
+
+			length := time.Duration(schedule.Duration) * time.Minute
+
+			// Include occurrences that start before the window but overlap it.
+			starts, err := schedule.Occurrences(opts.From.Add(-length), opts.To, loc)
+			if err != nil {
+				return nil, fmt.Errorf("expanding schedule %d of recurring event group %d: %w", schedule.ID, group.ID, err)
+			}
+
+			for _, start := range starts {
+				i := interval{start, start.Add(length)}
+
+				switch group.TimeSlotType {
+				case AppointmentTimeSlotTypeAppointmentSlot:
+					available[schedule.Physician] = append(available[schedule.Physician], i)
+				case AppointmentTimeSlotTypeEvent:
+					busy[schedule.Physician] = append(busy[schedule.Physician], i)
+				}
+			}
+		}
+	}
+
+	// Start a day early to find appointments that start before the window but overlap it.
+	appointments := All(ctx, client.Appointments().Find, &FindAppointmentsOptions{
+		Practice:  []int64{opts.Practice},
+		Physician: physicians,
+		FromDate:  opts.From.Add(-24 * time.Hour),
+		ToDate:    opts.To,
+	})
+	for appointment, err := range appointments {
+		if err != nil {
+			return nil, fmt.Errorf("finding appointments: %w", err)
+		}
+
+		if !blocksTime(appointment) {
+			continue
+		}
+
+		busy[appointment.Physician] = append(busy[appointment.Physician], interval{
+			appointment.ScheduledDate,
+			appointment.ScheduledDate.Add(time.Duration(appointment.Duration) * time.Minute),
+		})
+	}
+
+	var slots []*OpenSlot
+	for _, physician := range physicians {
+		free := subtractIntervals(mergeIntervals(slices.Clone(available[physician])), mergeIntervals(busy[physician]))
+		seen := map[int64]bool{}
+
+		for _, occurrence := range available[physician] {
+			for start := occurrence.start; start.Before(occurrence.end); start = start.Add(step) {
+				end := start.Add(opts.Duration)
+				if end.After(opts.To) {
+					break
+				}
+
+				if start.Before(opts.From) || seen[start.UnixNano()] || !containsInterval(free, interval{start, end}) {
+					continue
+				}
+
+				seen[start.UnixNano()] = true
+				slots = append(slots, &OpenSlot{
+					Physician: physician,
+					Start:     start.In(loc),
+					End:       end.In(loc),
+				})
+			}
+		}
+	}
+
+	slices.SortStableFunc(slots, func(a, b *OpenSlot) int {
+		return cmp.Or(a.Start.Compare(b.Start), cmp.Compare(a.Physician, b.Physician))
+	})
+
+	return slots, nil
+}
+
+// blocksTime reports whether a takes up time in its physician's schedule. Appointment slots are open time, and
+// cancelled or deleted appointments free their time.
+func blocksTime(a *Appointment) bool {
+	if a.DeletedDate != nil || a.TimeSlotType == string(AppointmentTimeSlotTypeAppointmentSlot) {
+		return false
+	}
+
+	return a.CurrentStatus() != AppointmentStatusCancelled
+}
+
+// mergeIntervals sorts intervals and merges those that overlap or touch.
+func mergeIntervals(intervals []interval) []interval {
+	slices.SortFunc(intervals, func(a, b interval) int {
+		return a.start.Compare(b.start)
+	})
+
+	var merged []interval
+	for _, i := range intervals {
+		if !i.start.Before(i.end) {
+			continue
+		}
+
+		if n := len(merged); n > 0 && !i.start.After(merged[n-1].end) {
+			merged[n-1].end = maxTime(merged[n-1].end, i.end)
+			continue
+		}
+
+		merged = append(merged, i)
+	}
+
+	return merged
+}
+
+// subtractIntervals returns the parts of intervals that are not in remove. Both must be merged.
+func subtractIntervals(intervals []interval, remove []interval) []interval {
+	var out []interval
+	for _, i := range intervals {
+		for _, r := range remove {
+			if !r.end.After(i.start) || !r.start.Before(i.end) {
+				continue
+			}
+
+			if r.start.After(i.start) {
+				out = append(out, interval{i.start, r.start})
+			}
+
+			i.start = r.end
+			if !i.start.Before(i.end) {
+				break
+			}
+		}
+
+		if i.start.Before(i.end) {
+			out = append(out, i)
+		}
+	}
+
+	return out
+}
+
+// containsInterval reports whether i is entirely within one of intervals, which must be merged.
+func containsInterval(intervals []interval, i interval) bool {
+	return slices.ContainsFunc(intervals, func(j interval) bool {
+		return !i.start.Before(j.start) && !i.end.After(j.end)
+	})
+}
+
+func maxTime(a time.Time, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,250 @@
+package elation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindAvailability(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		var body string
+
+		switch r.URL.Path {
+		case "/practices/1":
+			body = `{"id":1,"timezone":"America/New_York","physicians":[2,3]}`
+		case "/recurring_event_groups":
+			assert.Equal("1", r.URL.Query().Get("practice"))
+			assert.Equal("2", r.URL.Query().Get("physician"))
+			assert.Equal("2024-03-11", r.URL.Query().Get("start_date"))
+			assert.Equal("2024-03-11", r.URL.Query().Get("end_date"))
+
+			body = `{"results":[
+				{"id":1,"time_slot_type":"appointment_slot","schedules":[
+					{"id":1,"physician":2,"series_start":"2024-01-01","event_time":"09:00:00","duration":240,"repeats":"Weekly","dow_monday":true},
+					{"id":2,"physician":3,"series_start":"2024-01-01","event_time":"09:00:00","duration":240,"repeats":"Weekly","dow_monday":true}
+				]},
+				{"id":2,"time_slot_type":"event","schedules":[
+					{"id":3,"physician":2,"series_start":"2024-01-01","event_time":"12:00:00","duration":60,"repeats":"Daily"}
+				]},
+				{"id":3,"time_slot_type":"appointment_slot","deleted_date":"2024-01-01T00:00:00Z","schedules":[
+					{"id":4,"physician":2,"series_start":"2024-01-01","event_time":"14:00:00","duration":60,"repeats":"Daily"}
+				]}
+			]}`
+		case "/appointments":
+			assert.Equal("1", r.URL.Query().Get("practice"))
+			assert.Equal("2", r.URL.Query().Get("physician"))
+
+			body = `{"results":[
+				{"id":1,"physician":2,"scheduled_date":"2024-03-11T14:00:00Z","duration":30,"time_slot_type":"appointment"},
+				{"id":2,"physician":2,"scheduled_date":"2024-03-11T15:00:00Z","duration":30,"time_slot_type":"appointment","status":{"status":"Cancelled"}},
+				{"id":3,"physician":2,"scheduled_date":"2024-03-11T15:30:00Z","duration":30,"time_slot_type":"appointment","deleted_date":"2024-03-01T00:00:00Z"}
+			]}`
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		//nolint
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+	loc, err := time.LoadLocation("America/New_York")
+	if !assert.NoError(err) {
+		return
+	}
+
+	slots, err := FindAvailability(context.Background(), client, AvailabilityOptions{
+		Practice:   1,
+		Physicians: []int64{2},
+		From:       time.Date(2024, time.March, 11, 9, 20, 0, 0, loc),
+		To:         time.Date(2024, time.March, 11, 18, 0, 0, 0, loc),
+		Duration:   30 * time.Minute,
+	})
+	assert.NoError(err)
+
+	var starts []string
+	for _, slot := range slots {
+		assert.Equal(int64(2), slot.Physician)
+		assert.Equal(30*time.Minute, slot.End.Sub(slot.Start))
+		starts = append(starts, slot.Start.Format("15:04"))
+	}
+
+	assert.Equal([]string{"09:30", "10:30", "11:00", "11:30"}, starts)
+}
+
+func TestFindAvailability_interval(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		var body string
+
+		switch r.URL.Path {
+		case "/practices/1":
+			body = `{"id":1,"timezone":"UTC","physicians":[2,3]}`
+		case "/recurring_event_groups":
+			body = `{"results":[
+				{"id":1,"time_slot_type":"appointment_slot","schedules":[
+					{"id":1,"physician":2,"series_start":"2024-03-11","event_time":"09:00:00","duration":60},
+					{"id":2,"physician":3,"series_start":"2024-03-11","event_time":"09:30:00","duration":60}
+				]}
+			]}`
+		case "/appointments":
+			body = `{"results":[]}`
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		//nolint
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+	slots, err := FindAvailability(context.Background(), client, AvailabilityOptions{
+		Practice: 1,
+		From:     time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2024, time.March, 12, 0, 0, 0, 0, time.UTC),
+		Duration: 45 * time.Minute,
+		Interval: 15 * time.Minute,
+	})
+	assert.NoError(err)
+
+	start := func(hour, minute int) time.Time {
+		return time.Date(2024, time.March, 11, hour, minute, 0, 0, time.UTC)
+	}
+
+	assert.Equal([]*OpenSlot{
+		{Physician: 2, Start: start(9, 0), End: start(9, 45)},
+		{Physician: 2, Start: start(9, 15), End: start(10, 0)},
+		{Physician: 3, Start: start(9, 30), End: start(10, 15)},
+		{Physician: 3, Start: start(9, 45), End: start(10, 30)},
+	}, slots)
+}
+
+func TestFindAvailability_options(t *testing.T) {
+	testCases := map[string]struct {
+		opts        AvailabilityOptions
+		expectedErr string
+	}{
+		"no duration": {
+			opts:        AvailabilityOptions{From: time.Unix(0, 0), To: time.Unix(60, 0)},
+			expectedErr: "duration must be positive",
+		},
+		"empty window": {
+			opts:        AvailabilityOptions{From: time.Unix(60, 0), To: time.Unix(60, 0), Duration: time.Minute},
+			expectedErr: "from must be before to",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			_, err := FindAvailability(context.Background(), nil, testCase.opts)
+			assert.EqualError(err, testCase.expectedErr)
+		})
+	}
+}
+
+func TestFindAvailability_grid(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		var body string
+
+		switch r.URL.Path {
+		case "/practices/1":
+			body = `{"id":1,"timezone":"UTC","physicians":[2]}`
+		case "/recurring_event_groups":
+			body = `{"results":[
+				{"id":1,"time_slot_type":"appointment_slot","schedules":[
+					{"id":1,"physician":2,"series_start":"2024-03-11","event_time":"09:00:00","duration":120}
+				]}
+			]}`
+		case "/appointments":
+			body = `{"results":[
+				{"id":1,"physician":2,"scheduled_date":"2024-03-11T09:00:00Z","duration":17,"time_slot_type":"appointment"}
+			]}`
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		//nolint
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+	slots, err := FindAvailability(context.Background(), client, AvailabilityOptions{
+		Practice: 1,
+		From:     time.Date(2024, time.March, 11, 9, 10, 0, 0, time.UTC),
+		To:       time.Date(2024, time.March, 12, 0, 0, 0, 0, time.UTC),
+		Duration: 30 * time.Minute,
+	})
+	assert.NoError(err)
+
+	var starts []string
+	for _, slot := range slots {
+		starts = append(starts, slot.Start.Format("15:04"))
+	}
+
+	assert.Equal([]string{"09:30", "10:00", "10:30"}, starts)
+}
+
+func TestFindAvailability_no_timezone(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		if r.URL.Path != "/practices/1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		//nolint
+		w.Write([]byte(`{"id":1,"physicians":[2]}`))
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+	_, err := FindAvailability(context.Background(), client, AvailabilityOptions{
+		Practice: 1,
+		From:     time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2024, time.March, 12, 0, 0, 0, 0, time.UTC),
+		Duration: 30 * time.Minute,
+	})
+	assert.EqualError(err, "practice 1 has no timezone")
+}
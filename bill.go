@@ -2,13 +2,13 @@ package elation
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
-	"slices"
+	"strconv"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -21,6 +21,10 @@ type BillServicer interface {
 	Create(ctx context.Context, create *BillCreate) (*CreatedBill, *http.Response, error)
 	Find(ctx context.Context, opts *FindBillOptions) (*Response[[]*Bill], *http.Response, error)
 	Get(ctx context.Context, id int64) (*Bill, *http.Response, error)
+	Update(ctx context.Context, id int64, update *BillUpdate) (*Bill, *http.Response, error)
+	MarkProcessed(ctx context.Context, id int64, refNumber string, billingDate time.Time) (*Bill, *http.Response, error)
+	MarkFailed(ctx context.Context, id int64, billingError string, billingRawError string) (*Bill, *http.Response, error)
+	Delete(ctx context.Context, id int64) (*http.Response, error)
 }
 
 var _ BillServicer = (*BillService)(nil)
@@ -125,17 +129,9 @@ func (b *BillService) Create(ctx context.Context, create *BillCreate) (*CreatedB
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "error making request")
 
-		var clientErr *Error
-		if errors.As(err, &clientErr) && clientErr.StatusCode == http.StatusBadRequest {
-			errorRes := map[string][]string{}
-			err := json.Unmarshal([]byte(clientErr.Body), &errorRes)
-			if err != nil {
-				return nil, res, fmt.Errorf("unmarshaling response body to error response: %w", err)
-			}
-
-			if len(errorRes["visit_note"]) > 0 && slices.Contains(errorRes["visit_note"], billExistError) {
-				return nil, res, ErrBillExist
-			}
+		var validationErr *ValidationError
+		if IsBadRequest(err) && errors.As(err, &validationErr) && validationErr.HasFieldError("visit_note", billExistError) {
+			return nil, res, fmt.Errorf("%w: %w", ErrBillExist, err)
 		}
 
 		return nil, res, fmt.Errorf("making request: %w", err)
@@ -188,12 +184,12 @@ type Bill struct {
 }
 
 func (b *BillService) Get(ctx context.Context, id int64) (*Bill, *http.Response, error) {
-	ctx, span := b.client.tracer.Start(ctx, "get bill", trace.WithSpanKind(trace.SpanKindClient))
+	ctx, span := b.client.tracer.Start(ctx, "get bill", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attribute.Int64("elation.bill_id", id)))
 	defer span.End()
 
 	bill := &Bill{}
 
-	res, err := b.client.request(ctx, http.MethodGet, fmt.Sprintf("/bills/%d", id), nil, nil, &bill)
+	res, err := b.client.request(ctx, http.MethodGet, "/bills/"+strconv.FormatInt(id, 10), nil, nil, &bill)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "error making request")
@@ -232,3 +228,75 @@ func (b *BillService) Find(ctx context.Context, opts *FindBillOptions) (*Respons
 
 	return out, res, nil
 }
+
+type BillUpdate struct {
+	RefNumber       *string    `json:"ref_number,omitempty"`        //: "REF-1234",             // required to mark the bill as processed.
+	BillingDate     *time.Time `json:"billing_date,omitempty"`      //: "2016-10-13T12:00:00Z", // required to mark the bill as processed.
+	BillingError    *string    `json:"billing_error,omitempty"`     //: "Invalid CPT",          // required to mark the bill as failed.
+	BillingRawError *string    `json:"billing_raw_error,omitempty"` //: "...",
+	Notes           *string    `json:"notes,omitempty"`             //: "patient has not paid yet",
+}
+
+func (b *BillService) Update(ctx context.Context, id int64, update *BillUpdate) (*Bill, *http.Response, error) {
+	ctx, span := b.client.tracer.Start(ctx, "update bill", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attribute.Int64("elation.bill_id", id)))
+	defer span.End()
+
+	out := &Bill{}
+
+	res, err := b.client.request(ctx, http.MethodPatch, "/bills/"+strconv.FormatInt(id, 10), nil, update, &out)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error making request")
+		return nil, res, fmt.Errorf("making request: %w", err)
+	}
+
+	return out, res, nil
+}
+
+// MarkProcessed marks a bill as processed by the billing system, with the reference number and date it was billed.
+func (b *BillService) MarkProcessed(ctx context.Context, id int64, refNumber string, billingDate time.Time) (*Bill, *http.Response, error) {
+	if refNumber == "" {
+		return nil, nil, errors.New("reference number is required")
+	}
+
+	if billingDate.IsZero() {
+		return nil, nil, errors.New("billing date is required")
+	}
+
+	return b.Update(ctx, id, &BillUpdate{
+		RefNumber:   &refNumber,
+		BillingDate: &billingDate,
+	})
+}
+
+// MarkFailed marks a bill as failed with an error message of up to 200 characters. billingRawError is optional and
+// can hold the full response of the billing system.
+func (b *BillService) MarkFailed(ctx context.Context, id int64, billingError string, billingRawError string) (*Bill, *http.Response, error) {
+	if billingError == "" {
+		return nil, nil, errors.New("billing error is required")
+	}
+
+	update := &BillUpdate{
+		BillingError: &billingError,
+	}
+
+	if billingRawError != "" {
+		update.BillingRawError = &billingRawError
+	}
+
+	return b.Update(ctx, id, update)
+}
+
+func (b *BillService) Delete(ctx context.Context, id int64) (*http.Response, error) {
+	ctx, span := b.client.tracer.Start(ctx, "delete bill", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attribute.Int64("elation.bill_id", id)))
+	defer span.End()
+
+	res, err := b.client.request(ctx, http.MethodDelete, "/bills/"+strconv.FormatInt(id, 10), nil, nil, nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error making request")
+		return res, fmt.Errorf("making request: %w", err)
+	}
+
+	return res, nil
+}
@@ -249,3 +249,200 @@ func TestBillService_Find(t *testing.T) {
 	assert.Len(billsRes.Results, 2)
 	assert.Equal(bills, billsRes.Results)
 }
+
+func TestBillService_Update(t *testing.T) {
+	assert := assert.New(t)
+
+	var id int64 = 65099661468
+
+	expected := &BillUpdate{
+		Notes: new("patient has paid"),
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		assert.Equal(http.MethodPatch, r.Method)
+		assert.Equal("/bills/"+strconv.FormatInt(id, 10), r.URL.Path)
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(err)
+
+		actual := &BillUpdate{}
+		err = json.Unmarshal(body, actual)
+		assert.NoError(err)
+
+		assert.Equal(expected, actual)
+
+		b, err := json.Marshal(&Bill{ID: id})
+		assert.NoError(err)
+
+		w.Header().Set("Content-Type", "application/json")
+		//nolint
+		w.Write(b)
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+	svc := BillService{client}
+
+	updated, res, err := svc.Update(context.Background(), id, expected)
+	assert.NotNil(updated)
+	assert.NotNil(res)
+	assert.NoError(err)
+	assert.Equal(id, updated.ID)
+}
+
+func TestBillService_MarkProcessed(t *testing.T) {
+	billingDate := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		refNumber    string
+		billingDate  time.Time
+		expectedBody string
+		expectErr    bool
+	}{
+		"processed": {
+			refNumber:    "REF-1234",
+			billingDate:  billingDate,
+			expectedBody: `{"ref_number":"REF-1234","billing_date":"2026-01-02T12:00:00Z"}`,
+		},
+		"missing reference number": {
+			billingDate: billingDate,
+			expectErr:   true,
+		},
+		"missing billing date": {
+			refNumber: "REF-1234",
+			expectErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var requested bool
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tokenRequest(w, r) {
+					return
+				}
+
+				requested = true
+
+				assert.Equal(http.MethodPatch, r.Method)
+				assert.Equal("/bills/1", r.URL.Path)
+
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(err)
+				assert.JSONEq(testCase.expectedBody, string(body))
+
+				w.Header().Set("Content-Type", "application/json")
+				//nolint
+				w.Write([]byte(`{"id":1,"billing_status":"Billed"}`))
+			}))
+			defer srv.Close()
+
+			client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+			svc := BillService{client}
+
+			bill, _, err := svc.MarkProcessed(context.Background(), 1, testCase.refNumber, testCase.billingDate)
+			if testCase.expectErr {
+				assert.Error(err)
+				assert.False(requested)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal("Billed", bill.BillingStatus)
+		})
+	}
+}
+
+func TestBillService_MarkFailed(t *testing.T) {
+	testCases := map[string]struct {
+		billingError    string
+		billingRawError string
+		expectedBody    string
+		expectErr       bool
+	}{
+		"error only": {
+			billingError: "Invalid CPT",
+			expectedBody: `{"billing_error":"Invalid CPT"}`,
+		},
+		"raw error": {
+			billingError:    "Invalid CPT",
+			billingRawError: `{"code":"E42"}`,
+			expectedBody:    `{"billing_error":"Invalid CPT","billing_raw_error":"{\"code\":\"E42\"}"}`,
+		},
+		"missing error": {
+			expectErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var requested bool
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tokenRequest(w, r) {
+					return
+				}
+
+				requested = true
+
+				assert.Equal(http.MethodPatch, r.Method)
+				assert.Equal("/bills/1", r.URL.Path)
+
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(err)
+				assert.JSONEq(testCase.expectedBody, string(body))
+
+				w.Header().Set("Content-Type", "application/json")
+				//nolint
+				w.Write([]byte(`{"id":1,"billing_status":"Failed"}`))
+			}))
+			defer srv.Close()
+
+			client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+			svc := BillService{client}
+
+			bill, _, err := svc.MarkFailed(context.Background(), 1, testCase.billingError, testCase.billingRawError)
+			if testCase.expectErr {
+				assert.Error(err)
+				assert.False(requested)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal("Failed", bill.BillingStatus)
+		})
+	}
+}
+
+func TestBillService_Delete(t *testing.T) {
+	assert := assert.New(t)
+
+	var id int64 = 65099661468
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		assert.Equal(http.MethodDelete, r.Method)
+		assert.Equal("/bills/"+strconv.FormatInt(id, 10), r.URL.Path)
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+	svc := BillService{client}
+
+	res, err := svc.Delete(context.Background(), id)
+	assert.NotNil(res)
+	assert.NoError(err)
+}
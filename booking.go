@@ -0,0 +1,235 @@
+package elation
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type BookingOptions struct {
+	// AllowConflicts books the appointment even if it overlaps other appointments or events.
+	AllowConflicts bool
+}
+
+// Conflict is an appointment, event or occurrence of a recurring event that overlaps a booking.
+type Conflict struct {
+	// Appointment is the conflicting appointment or event. It is nil for occurrences of recurring events.
+	Appointment *Appointment
+
+	// RecurringEventGroup and RecurringEventSchedule identify a conflicting occurrence of a recurring event.
+	RecurringEventGroup    int64
+	RecurringEventSchedule int64
+
+	Start time.Time
+	End   time.Time
+
+	// Physician and ServiceLocation report whether the conflict is with the physician or the service location of the
+	// booking, or both.
+	Physician       bool
+	ServiceLocation bool
+}
+
+func (c *Conflict) String() string {
+	var on []string
+	if c.Physician {
+		on = append(on, "physician")
+	}
+
+	if c.ServiceLocation {
+		on = append(on, "service location")
+	}
+
+	what := "recurring event schedule " + strconv.FormatInt(c.RecurringEventSchedule, 10)
+	if c.Appointment != nil {
+		what = "appointment " + strconv.FormatInt(c.Appointment.ID, 10)
+	}
+
+	return fmt.Sprintf("%s from %s to %s (%s)", what, c.Start.Format(time.RFC3339), c.End.Format(time.RFC3339), strings.Join(on, ", "))
+}
+
+// BookingConflictError is returned by BookAppointment when a booking overlaps other appointments or events. It
+// matches ErrConflict with errors.Is.
+type BookingConflictError struct {
+	Conflicts []*Conflict
+
+	// Appointment is the appointment that was created and then deleted because a conflicting booking was made at the
+	// same time. It is nil if the conflicts were found before creating it.
+	Appointment *Appointment
+}
+
+func (e *BookingConflictError) Error() string {
+	conflicts := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		conflicts[i] = c.String()
+	}
+
+	return "booking conflicts with " + strings.Join(conflicts, "; ")
+}
+
+func (e *BookingConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// BookAppointment creates an appointment if it does not overlap the appointments and events of its physician or
+// service location, or the event recurring event groups of its physician. Cancelled and deleted appointments and
+// appointment slots do not conflict. Overlaps are returned as a *BookingConflictError unless opts.AllowConflicts is
+// set.
+//
+// Conflicts are checked again after the appointment is created. If an appointment or event that was created earlier
+// now overlaps it, the appointment is deleted and a *BookingConflictError with the deleted appointment is returned.
+// Appointments created later are left to their own booking, so only one of two concurrent bookings is kept. If the
+// appointment cannot be deleted, it is returned along with the error, since it still exists.
+func BookAppointment(ctx context.Context, client Client, create *AppointmentCreate, opts BookingOptions) (*Appointment, error) {
+	if create.Duration <= 0 {
+		return nil, errors.New("duration must be positive")
+	}
+
+	if opts.AllowConflicts {
+		appointment, _, err := client.Appointments().Create(ctx, create)
+		if err != nil {
+			return nil, fmt.Errorf("creating appointment: %w", err)
+		}
+
+		return appointment, nil
+	}
+
+	practice, _, err := client.Practices().Get(ctx, create.Practice)
+	if err != nil {
+		return nil, fmt.Errorf("getting practice: %w", err)
+	}
+
+	loc, err := practice.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts, err := findConflicts(ctx, client, create, loc, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(conflicts) > 0 {
+		return nil, &BookingConflictError{Conflicts: conflicts}
+	}
+
+	appointment, _, err := client.Appointments().Create(ctx, create)
+	if err != nil {
+		return nil, fmt.Errorf("creating appointment: %w", err)
+	}
+
+	conflicts, err = findConflicts(ctx, client, create, loc, appointment.ID)
+	if err != nil {
+		return appointment, fmt.Errorf("checking conflicts after creating appointment %d: %w", appointment.ID, err)
+	}
+
+	// The other booking keeps its appointment if it was created first.
+	var earlier []*Conflict
+	for _, c := range conflicts {
+		if c.Appointment == nil || createdBefore(c.Appointment, appointment) {
+			earlier = append(earlier, c)
+		}
+	}
+
+	if len(earlier) == 0 {
+		return appointment, nil
+	}
+
+	conflictErr := &BookingConflictError{Conflicts: earlier, Appointment: appointment}
+
+	_, err = client.Appointments().Delete(ctx, appointment.ID)
+	if err != nil {
+		return appointment, errors.Join(conflictErr, fmt.Errorf("deleting appointment %d: %w", appointment.ID, err))
+	}
+
+	return nil, conflictErr
+}
+
+// createdBefore reports whether a was created before b, comparing IDs only when they were created at the same time.
+func createdBefore(a *Appointment, b *Appointment) bool {
+	return cmp.Or(a.CreatedDate.Compare(b.CreatedDate), cmp.Compare(a.ID, b.ID)) < 0
+}
+
+// findConflicts returns the appointments, events and recurring events that overlap create, except the appointment
+// with the ID exclude.
+func findConflicts(ctx context.Context, client Client, create *AppointmentCreate, loc *time.Location, exclude int64) ([]*Conflict, error) {
+	start := create.ScheduledDate
+	end := start.Add(time.Duration(create.Duration) * time.Minute)
+
+	var conflicts []*Conflict
+
+	// Start a day early to find appointments that start before the booking but overlap it.
+	appointments := All(ctx, client.Appointments().Find, &FindAppointmentsOptions{
+		Practice: []int64{create.Practice},
+		FromDate: start.Add(-24 * time.Hour),
+		ToDate:   end,
+	})
+	for appointment, err := range appointments {
+		if err != nil {
+			return nil, fmt.Errorf("finding appointments: %w", err)
+		}
+
+		if appointment.ID == exclude || !blocksTime(appointment) {
+			continue
+		}
+
+		c := &Conflict{
+			Appointment:     appointment,
+			Start:           appointment.ScheduledDate,
+			End:             appointment.ScheduledDate.Add(time.Duration(appointment.Duration) * time.Minute),
+			Physician:       appointment.Physician == create.Physician,
+			ServiceLocation: create.ServiceLocation != nil && appointment.ServiceLocation != nil && int64(appointment.ServiceLocation.ID) == *create.ServiceLocation,
+		}
+
+		if (c.Physician || c.ServiceLocation) && c.Start.Before(end) && c.End.After(start) {
+			conflicts = append(conflicts, c)
+		}
+	}
+
+	groups := All(ctx, client.RecurringEventGroups().Find, &FindRecurringEventGroupsOptions{
+		Practice:     []int64{create.Practice},
+		Physician:    []int64{create.Physician},
+		StartDate:    start.In(loc).Format(time.DateOnly),
+		EndDate:      end.In(loc).Format(time.DateOnly),
+		TimeSlotType: AppointmentTimeSlotTypeEvent,
+	})
+	for group, err := range groups {
+		if err != nil {
+			return nil, fmt.Errorf("finding recurring event groups: %w", err)
+		}
+
+		if group.DeletedDate != nil || group.TimeSlotType != AppointmentTimeSlotTypeEvent {
+			continue
+		}
+
+		for _, schedule := range group.Schedules {
+			if schedule.Physician != create.Physician {
+				continue
+			}
+
+			length := time.Duration(schedule.Duration) * time.Minute
+
+			occurrences, err := schedule.Occurrences(start.Add(-length), end, loc)
+			if err != nil {
+				return nil, fmt.Errorf("expanding schedule %d of recurring event group %d: %w", schedule.ID, group.ID, err)
+			}
+
+			for _, occurrence := range occurrences {
+				if occurrence.Before(end) && occurrence.Add(length).After(start) {
+					conflicts = append(conflicts, &Conflict{
+						RecurringEventGroup:    group.ID,
+						RecurringEventSchedule: schedule.ID,
+						Start:                  occurrence,
+						End:                    occurrence.Add(length),
+						Physician:              true,
+					})
+				}
+			}
+		}
+	}
+
+	return conflicts, nil
+}
@@ -0,0 +1,370 @@
+package elation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// bookingServer serves a practice, its event recurring event groups and an in-memory list of appointments.
+type bookingServer struct {
+	mu           sync.Mutex
+	appointments []*Appointment
+	nextID       int64
+	deleted      []int64
+
+	// noTimezone serves the practice without a timezone.
+	noTimezone bool
+
+	// failDelete makes deleting appointments fail.
+	failDelete bool
+
+	// onCreate is called with the new appointment before it is returned, to simulate concurrent bookings.
+	onCreate func(s *bookingServer, created *Appointment)
+}
+
+func (s *bookingServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if tokenRequest(w, r) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var body any
+
+	switch {
+	case r.URL.Path == "/practices/1":
+		practice := &Practice{ID: 1, Timezone: "America/New_York"}
+		if s.noTimezone {
+			practice.Timezone = ""
+		}
+
+		body = practice
+	case r.URL.Path == "/recurring_event_groups":
+		body = &Response[[]*RecurringEventGroup]{Results: []*RecurringEventGroup{
+			{ID: 1, TimeSlotType: AppointmentTimeSlotTypeEvent, Schedules: []*RecurringEventGroupSchedule{
+				{ID: 2, Physician: 2, SeriesStart: "2024-01-01", EventTime: "12:00:00", Duration: 60, Repeats: "Daily"},
+			}},
+		}}
+	case r.URL.Path == "/appointments" && r.Method == http.MethodGet:
+		body = &Response[[]*Appointment]{Results: s.appointments}
+	case r.URL.Path == "/appointments" && r.Method == http.MethodPost:
+		create := &AppointmentCreate{}
+		if err := json.NewDecoder(r.Body).Decode(create); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		created := s.add(create.Physician, create.ScheduledDate, int(create.Duration), nil)
+		if s.onCreate != nil {
+			s.onCreate(s, created)
+		}
+
+		body = created
+	case strings.HasPrefix(r.URL.Path, "/appointments/") && r.Method == http.MethodDelete:
+		if s.failDelete {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		id, _ := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/appointments/"), 10, 64)
+		s.deleted = append(s.deleted, id)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	//nolint
+	json.NewEncoder(w).Encode(body)
+}
+
+func (s *bookingServer) add(physician int64, scheduled time.Time, duration int, serviceLocation *AppointmentServiceLocation) *Appointment {
+	s.nextID++
+	a := &Appointment{
+		ID:              s.nextID,
+		Physician:       physician,
+		Practice:        1,
+		ScheduledDate:   scheduled,
+		Duration:        duration,
+		TimeSlotType:    string(AppointmentTimeSlotTypeAppointment),
+		ServiceLocation: serviceLocation,
+	}
+	s.appointments = append(s.appointments, a)
+
+	return a
+}
+
+func TestBookAppointment(t *testing.T) {
+	nineAM := time.Date(2024, time.March, 11, 13, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		existing          func(s *bookingServer)
+		scheduledDate     time.Time
+		serviceLocation   *int64
+		opts              BookingOptions
+		expectedConflicts []*Conflict
+	}{
+		"no conflicts": {
+			existing: func(s *bookingServer) {
+				s.add(2, nineAM.Add(-30*time.Minute), 30, nil)
+				s.add(3, nineAM, 30, nil)
+			},
+			scheduledDate: nineAM,
+		},
+		"cancelled appointment": {
+			existing: func(s *bookingServer) {
+				s.add(2, nineAM, 30, nil).Status = &AppointmentStatus{Status: "Cancelled"}
+			},
+			scheduledDate: nineAM,
+		},
+		"physician": {
+			existing: func(s *bookingServer) {
+				s.add(2, nineAM.Add(-15*time.Minute), 30, nil)
+			},
+			scheduledDate: nineAM,
+			expectedConflicts: []*Conflict{
+				{
+					Start:     nineAM.Add(-15 * time.Minute),
+					End:       nineAM.Add(15 * time.Minute),
+					Physician: true,
+				},
+			},
+		},
+		"service location": {
+			existing: func(s *bookingServer) {
+				s.add(3, nineAM.Add(15*time.Minute), 30, &AppointmentServiceLocation{ID: 5})
+			},
+			scheduledDate:   nineAM,
+			serviceLocation: new(int64(5)),
+			expectedConflicts: []*Conflict{
+				{
+					Start:           nineAM.Add(15 * time.Minute),
+					End:             nineAM.Add(45 * time.Minute),
+					ServiceLocation: true,
+				},
+			},
+		},
+		"recurring event": {
+			existing:      func(s *bookingServer) {},
+			scheduledDate: time.Date(2024, time.March, 11, 15, 45, 0, 0, time.UTC),
+			expectedConflicts: []*Conflict{
+				{
+					RecurringEventGroup:    1,
+					RecurringEventSchedule: 2,
+					Start:                  time.Date(2024, time.March, 11, 16, 0, 0, 0, time.UTC),
+					End:                    time.Date(2024, time.March, 11, 17, 0, 0, 0, time.UTC),
+					Physician:              true,
+				},
+			},
+		},
+		"allow conflicts": {
+			existing: func(s *bookingServer) {
+				s.add(2, nineAM, 30, nil)
+			},
+			scheduledDate: nineAM,
+			opts:          BookingOptions{AllowConflicts: true},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			server := &bookingServer{}
+			testCase.existing(server)
+			existing := len(server.appointments)
+
+			srv := httptest.NewServer(server)
+			defer srv.Close()
+
+			client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+			appointment, err := BookAppointment(context.Background(), client, &AppointmentCreate{
+				Duration:        30,
+				Patient:         4,
+				Physician:       2,
+				Practice:        1,
+				ScheduledDate:   testCase.scheduledDate,
+				ServiceLocation: testCase.serviceLocation,
+			}, testCase.opts)
+
+			if testCase.expectedConflicts == nil {
+				assert.NoError(err)
+				if assert.NotNil(appointment) {
+					assert.True(testCase.scheduledDate.Equal(appointment.ScheduledDate))
+				}
+
+				return
+			}
+
+			assert.Nil(appointment)
+			assert.ErrorIs(err, ErrConflict)
+
+			conflictErr := &BookingConflictError{}
+			if !assert.ErrorAs(err, &conflictErr) || !assert.Len(conflictErr.Conflicts, len(testCase.expectedConflicts)) {
+				return
+			}
+
+			assert.Nil(conflictErr.Appointment)
+
+			for i, expected := range testCase.expectedConflicts {
+				actual := conflictErr.Conflicts[i]
+				assert.Equal(expected.RecurringEventGroup, actual.RecurringEventGroup)
+				assert.Equal(expected.RecurringEventSchedule, actual.RecurringEventSchedule)
+				assert.True(expected.Start.Equal(actual.Start), "start %s", actual.Start)
+				assert.True(expected.End.Equal(actual.End), "end %s", actual.End)
+				assert.Equal(expected.Physician, actual.Physician)
+				assert.Equal(expected.ServiceLocation, actual.ServiceLocation)
+			}
+
+			server.mu.Lock()
+			defer server.mu.Unlock()
+
+			assert.Len(server.appointments, existing, "no appointment is created")
+		})
+	}
+}
+
+func TestBookAppointment_race(t *testing.T) {
+	nineAM := time.Date(2024, time.March, 11, 13, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		onCreate            func(s *bookingServer, created *Appointment)
+		expectedDeleted     []int64
+		expectedAppointment int64
+		expectedErr         bool
+	}{
+		"earlier booking": {
+			onCreate: func(s *bookingServer, created *Appointment) {
+				// Another booking created its appointment first.
+				created.ID = 10
+				s.add(2, nineAM, 30, nil)
+			},
+			expectedDeleted:     []int64{10},
+			expectedAppointment: 10,
+			expectedErr:         true,
+		},
+		"later booking": {
+			onCreate: func(s *bookingServer, created *Appointment) {
+				s.add(2, nineAM, 30, nil)
+			},
+			expectedAppointment: 1,
+		},
+		"earlier booking with a later ID": {
+			onCreate: func(s *bookingServer, created *Appointment) {
+				created.CreatedDate = nineAM
+				s.add(2, nineAM, 30, nil).CreatedDate = nineAM.Add(-time.Second)
+			},
+			expectedDeleted:     []int64{1},
+			expectedAppointment: 1,
+			expectedErr:         true,
+		},
+		"later booking with an earlier ID": {
+			onCreate: func(s *bookingServer, created *Appointment) {
+				created.ID = 10
+				created.CreatedDate = nineAM
+				s.add(2, nineAM, 30, nil).CreatedDate = nineAM.Add(time.Second)
+			},
+			expectedAppointment: 10,
+		},
+		"delete fails": {
+			onCreate: func(s *bookingServer, created *Appointment) {
+				created.ID = 10
+				s.add(2, nineAM, 30, nil)
+				s.failDelete = true
+			},
+			expectedAppointment: 10,
+			expectedErr:         true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			server := &bookingServer{onCreate: testCase.onCreate}
+
+			srv := httptest.NewServer(server)
+			defer srv.Close()
+
+			client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+			appointment, err := BookAppointment(context.Background(), client, &AppointmentCreate{
+				Duration:      30,
+				Patient:       4,
+				Physician:     2,
+				Practice:      1,
+				ScheduledDate: nineAM,
+			}, BookingOptions{})
+
+			server.mu.Lock()
+			defer server.mu.Unlock()
+
+			assert.Equal(testCase.expectedDeleted, server.deleted)
+
+			if !testCase.expectedErr {
+				assert.NoError(err)
+				if assert.NotNil(appointment) {
+					assert.Equal(testCase.expectedAppointment, appointment.ID)
+				}
+
+				return
+			}
+
+			conflictErr := &BookingConflictError{}
+			if assert.ErrorAs(err, &conflictErr) && assert.NotNil(conflictErr.Appointment) {
+				assert.Equal(testCase.expectedAppointment, conflictErr.Appointment.ID)
+				assert.Len(conflictErr.Conflicts, 1)
+			}
+
+			if testCase.expectedDeleted != nil {
+				assert.Nil(appointment)
+				return
+			}
+
+			// The appointment could not be deleted, so it is returned with the error.
+			assert.ErrorContains(err, "deleting appointment 10")
+			if assert.NotNil(appointment) {
+				assert.Equal(testCase.expectedAppointment, appointment.ID)
+			}
+		})
+	}
+}
+
+func TestBookAppointment_no_timezone(t *testing.T) {
+	assert := assert.New(t)
+
+	server := &bookingServer{noTimezone: true}
+
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+	appointment, err := BookAppointment(context.Background(), client, &AppointmentCreate{
+		Duration:      30,
+		Patient:       4,
+		Physician:     2,
+		Practice:      1,
+		ScheduledDate: time.Date(2024, time.March, 11, 13, 0, 0, 0, time.UTC),
+	}, BookingOptions{})
+	assert.Nil(appointment)
+	assert.EqualError(err, "practice 1 has no timezone")
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	assert.Empty(server.appointments, "no appointment is created")
+}
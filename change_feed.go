@@ -0,0 +1,393 @@
+package elation
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Resources that cannot be subscribed to but can be polled with a ChangeFeed.
+const (
+	ResourceHistoryDownloadFills Resource = "medication_history_download_fills"
+	ResourcePrescriptionFills    Resource = "prescription_fills"
+)
+
+// Checkpoint is the position of a ChangeFeed in a resource.
+type Checkpoint struct {
+	// Time is the greatest timestamp emitted.
+	Time time.Time `json:"time"`
+
+	// IDs are the IDs emitted whose timestamp equals Time. The filters are inclusive, so these are returned again by
+	// the next poll and must be skipped.
+	IDs []int64 `json:"ids,omitempty"`
+
+	// EventID is the EventID of the last event emitted.
+	EventID int64 `json:"event_id"`
+}
+
+// CheckpointStore persists the checkpoint of each resource of a ChangeFeed.
+type CheckpointStore interface {
+	// LoadCheckpoint returns the checkpoint of resource, or nil if it has none.
+	LoadCheckpoint(ctx context.Context, resource Resource) (*Checkpoint, error)
+
+	// SaveCheckpoint replaces the checkpoint of resource.
+	SaveCheckpoint(ctx context.Context, resource Resource, checkpoint *Checkpoint) error
+}
+
+// MemoryCheckpointStore is a CheckpointStore that keeps checkpoints in memory.
+type MemoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[Resource]Checkpoint
+}
+
+var _ CheckpointStore = (*MemoryCheckpointStore)(nil)
+
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{
+		checkpoints: map[Resource]Checkpoint{},
+	}
+}
+
+func (s *MemoryCheckpointStore) LoadCheckpoint(ctx context.Context, resource Resource) (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoint, ok := s.checkpoints[resource]
+	if !ok {
+		return nil, nil
+	}
+
+	checkpoint.IDs = slices.Clone(checkpoint.IDs)
+
+	return &checkpoint, nil
+}
+
+func (s *MemoryCheckpointStore) SaveCheckpoint(ctx context.Context, resource Resource, checkpoint *Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := *checkpoint
+	c.IDs = slices.Clone(c.IDs)
+	s.checkpoints[resource] = c
+
+	return nil
+}
+
+// FileCheckpointStore is a CheckpointStore that keeps every checkpoint in a JSON file, so that a ChangeFeed resumes
+// where it stopped across restarts. The file is replaced atomically on every save.
+type FileCheckpointStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+var _ CheckpointStore = (*FileCheckpointStore)(nil)
+
+// NewFileCheckpointStore returns a store backed by the file at path, which is created on the first save.
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{
+		path: path,
+	}
+}
+
+func (s *FileCheckpointStore) LoadCheckpoint(ctx context.Context, resource Resource) (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint, ok := checkpoints[resource]
+	if !ok {
+		return nil, nil
+	}
+
+	return checkpoint, nil
+}
+
+func (s *FileCheckpointStore) SaveCheckpoint(ctx context.Context, resource Resource, checkpoint *Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	checkpoints[resource] = checkpoint
+
+	b, err := json.Marshal(checkpoints)
+	if err != nil {
+		return fmt.Errorf("marshaling checkpoints: %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("creating checkpoint file: %w", err)
+	}
+
+	_, err = file.Write(b)
+	if err == nil {
+		err = file.Sync()
+	}
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(file.Name(), s.path)
+	}
+
+	if err != nil {
+		//nolint
+		os.Remove(file.Name())
+		return fmt.Errorf("writing checkpoint file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FileCheckpointStore) read() (map[Resource]*Checkpoint, error) {
+	checkpoints := map[Resource]*Checkpoint{}
+
+	b, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return checkpoints, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading checkpoint file: %w", err)
+	}
+
+	err = json.Unmarshal(b, &checkpoints)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling checkpoint file: %w", err)
+	}
+
+	return checkpoints, nil
+}
+
+// ChangeSource is a resource polled by a ChangeFeed.
+type ChangeSource struct {
+	Resource Resource
+
+	// find returns every row whose timestamp is at or after since.
+	find func(ctx context.Context, since time.Time) ([]change, error)
+}
+
+type change struct {
+	id   int64
+	time time.Time
+	data any
+}
+
+func changeSource[T any, O any](resource Resource, find FindFunc[T, O], opts func(since time.Time) O, key func(T) (int64, time.Time)) ChangeSource {
+	return ChangeSource{
+		Resource: resource,
+		find: func(ctx context.Context, since time.Time) ([]change, error) {
+			var changes []change
+
+			for row, err := range All(ctx, find, opts(since)) {
+				if err != nil {
+					return nil, err
+				}
+
+				id, t := key(row)
+				changes = append(changes, change{id, t, row})
+			}
+
+			return changes, nil
+		},
+	}
+}
+
+// VisitNoteChanges polls visit notes by last_modified.
+func VisitNoteChanges(visitNotes VisitNoteServicer) ChangeSource {
+	return changeSource(ResourceVisitNotes, visitNotes.Find,
+		func(since time.Time) *FindVisitNotesOptions {
+			return &FindVisitNotesOptions{LastModifiedGTE: since}
+		},
+		func(visitNote *VisitNote) (int64, time.Time) {
+			return visitNote.ID, visitNote.LastModified
+		},
+	)
+}
+
+// LetterChanges polls letters by document_date. Letters have no modification time, so edits to a letter are not
+// emitted.
+func LetterChanges(letters LetterServicer) ChangeSource {
+	return changeSource(ResourceLetters, letters.Find,
+		func(since time.Time) *FindLettersOptions {
+			return &FindLettersOptions{DocumentDateGTE: since}
+		},
+		func(letter *Letter) (int64, time.Time) {
+			return letter.ID, letter.DocumentDate
+		},
+	)
+}
+
+// DiscontinuedMedicationChanges polls discontinued medications by document_date.
+func DiscontinuedMedicationChanges(discontinuedMedications DiscontinuedMedicationServicer) ChangeSource {
+	return changeSource(ResourceDiscontinuedMedications, discontinuedMedications.Find,
+		func(since time.Time) *FindDiscontinuedMedicationsOptions {
+			return &FindDiscontinuedMedicationsOptions{DocumentDateGTE: since}
+		},
+		func(discontinuedMedication *DiscontinuedMedication) (int64, time.Time) {
+			return discontinuedMedication.ID, discontinuedMedication.DocumentDate
+		},
+	)
+}
+
+// PrescriptionFillChanges polls prescription fills by fill_date. Fill dates have no time of day, so every fill of the
+// checkpoint's day is fetched again by each poll and skipped.
+func PrescriptionFillChanges(prescriptionFills PrescriptionFillServicer) ChangeSource {
+	return changeSource(ResourcePrescriptionFills, prescriptionFills.Find,
+		func(since time.Time) *FindPrescriptionFillsOptions {
+			return &FindPrescriptionFillsOptions{FillDateGTE: since}
+		},
+		func(fill *PrescriptionFill) (int64, time.Time) {
+			if fill.FillDate == nil {
+				return fill.ID, time.Time{}
+			}
+
+			return fill.ID, fill.FillDate.In(time.UTC)
+		},
+	)
+}
+
+// HistoryDownloadFillChanges polls medication history download fills by last_fill_date.
+func HistoryDownloadFillChanges(historyDownloadFills HistoryDownloadFillServicer) ChangeSource {
+	return changeSource(ResourceHistoryDownloadFills, historyDownloadFills.Find,
+		func(since time.Time) *FindHistoryDownloadFillsOptions {
+			return &FindHistoryDownloadFillsOptions{LastFillDateGTE: since}
+		},
+		func(fill *HistoryDownloadFill) (int64, time.Time) {
+			return fill.ID, fill.LastFillDate
+		},
+	)
+}
+
+// ChangeFeed polls Find endpoints for rows that changed since the last poll and emits them as saved events, for
+// resources that are not webhook-enabled or environments that cannot receive webhooks. Deletions are not emitted.
+//
+// Events have the same shape as the ones returned by VerifyWebhook, so a WebhookHandler can process them with Handle.
+// Their EventID is a sequence of the feed, not an Elation event ID, so an EventStore should not be shared with
+// webhooks.
+type ChangeFeed struct {
+	// Start is the time from which a resource without a checkpoint is polled. It defaults to the time the feed was
+	// created.
+	Start time.Time
+
+	checkpoints CheckpointStore
+	sources     []ChangeSource
+}
+
+func NewChangeFeed(checkpoints CheckpointStore, sources ...ChangeSource) *ChangeFeed {
+	return &ChangeFeed{
+		Start:       time.Now(),
+		checkpoints: checkpoints,
+		sources:     sources,
+	}
+}
+
+// Poll emits every change since the last poll to fn, in order of timestamp and ID within each resource. The checkpoint
+// of a resource is saved after each event that fn accepts, so if fn returns an error, Poll stops and the event is
+// emitted again by the next poll.
+func (f *ChangeFeed) Poll(ctx context.Context, fn func(ctx context.Context, event *Event) error) error {
+	checkpoints := make([]*Checkpoint, len(f.sources))
+
+	var eventID int64
+
+	for i, source := range f.sources {
+		checkpoint, err := f.checkpoints.LoadCheckpoint(ctx, source.Resource)
+		if err != nil {
+			return fmt.Errorf("loading %s checkpoint: %w", source.Resource, err)
+		}
+
+		if checkpoint == nil {
+			checkpoint = &Checkpoint{Time: f.Start}
+		}
+
+		checkpoints[i] = checkpoint
+		eventID = max(eventID, checkpoint.EventID)
+	}
+
+	for i, source := range f.sources {
+		checkpoint := checkpoints[i]
+
+		changes, err := source.find(ctx, checkpoint.Time)
+		if err != nil {
+			return fmt.Errorf("polling %s: %w", source.Resource, err)
+		}
+
+		slices.SortStableFunc(changes, func(a, b change) int {
+			return cmp.Or(a.time.Compare(b.time), cmp.Compare(a.id, b.id))
+		})
+
+		for _, c := range changes {
+			if c.time.Before(checkpoint.Time) || (c.time.Equal(checkpoint.Time) && slices.Contains(checkpoint.IDs, c.id)) {
+				continue
+			}
+
+			data, err := json.Marshal(c.data)
+			if err != nil {
+				return fmt.Errorf("marshaling %s %d: %w", source.Resource, c.id, err)
+			}
+
+			eventID++
+
+			event := &Event{
+				Data:     data,
+				Action:   WebhookEventActionSaved,
+				EventID:  eventID,
+				Resource: source.Resource,
+			}
+
+			err = fn(ctx, event)
+			if err != nil {
+				return fmt.Errorf("handling %s %d: %w", source.Resource, c.id, err)
+			}
+
+			if c.time.Equal(checkpoint.Time) {
+				checkpoint.IDs = append(checkpoint.IDs, c.id)
+			} else {
+				checkpoint.Time = c.time
+				checkpoint.IDs = []int64{c.id}
+			}
+
+			checkpoint.EventID = eventID
+
+			err = f.checkpoints.SaveCheckpoint(ctx, source.Resource, checkpoint)
+			if err != nil {
+				return fmt.Errorf("saving %s checkpoint: %w", source.Resource, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Run polls every interval until ctx is done or a poll fails.
+func (f *ChangeFeed) Run(ctx context.Context, interval time.Duration, fn func(ctx context.Context, event *Event) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := f.Poll(ctx, fn)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package elation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeFeed(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	visitNotes := []*VisitNote{
+		{ID: 3, LastModified: start.Add(2 * time.Hour)},
+		{ID: 2, LastModified: start.Add(time.Hour)},
+		{ID: 1, LastModified: start.Add(time.Hour)},
+	}
+	letters := []*Letter{
+		{ID: 10, DocumentDate: start.Add(time.Hour)},
+	}
+
+	var queries []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		queries = append(queries, r.URL.Path+"?"+r.URL.RawQuery)
+
+		var b []byte
+		var err error
+
+		switch r.URL.Path {
+		case "/visit_notes":
+			since, err := time.Parse(time.RFC3339, r.URL.Query().Get("last_modified__gte"))
+			assert.NoError(err)
+
+			var results []*VisitNote
+			for _, visitNote := range visitNotes {
+				if !visitNote.LastModified.Before(since) {
+					results = append(results, visitNote)
+				}
+			}
+
+			b, err = json.Marshal(Response[[]*VisitNote]{Results: results})
+			assert.NoError(err)
+
+		case "/letters":
+			b, err = json.Marshal(Response[[]*Letter]{Results: letters})
+			assert.NoError(err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		//nolint
+		w.Write(b)
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+	ctx := context.Background()
+
+	checkpoints := NewMemoryCheckpointStore()
+
+	feed := NewChangeFeed(checkpoints, VisitNoteChanges(client.VisitNote()), LetterChanges(client.Letters()))
+	feed.Start = start
+
+	var events []*Event
+	collect := func(ctx context.Context, event *Event) error {
+		events = append(events, event)
+		return nil
+	}
+
+	assert.NoError(feed.Poll(ctx, collect))
+
+	if assert.Len(events, 4) {
+		assert.Equal(ResourceVisitNotes, events[0].Resource)
+		assert.Equal(WebhookEventActionSaved, events[0].Action)
+		assert.Equal([]int64{1, 2, 3, 10}, []int64{events[0].ResourceID(), events[1].ResourceID(), events[2].ResourceID(), events[3].ResourceID()})
+		assert.Equal([]int64{1, 2, 3, 4}, []int64{events[0].EventID, events[1].EventID, events[2].EventID, events[3].EventID})
+
+		visitNote := &VisitNote{}
+		assert.NoError(json.Unmarshal(events[2].Data, visitNote))
+		assert.Equal(visitNotes[0], visitNote)
+	}
+
+	checkpoint, err := checkpoints.LoadCheckpoint(ctx, ResourceVisitNotes)
+	assert.NoError(err)
+	assert.Equal(&Checkpoint{Time: start.Add(2 * time.Hour), IDs: []int64{3}, EventID: 3}, checkpoint)
+
+	// Rows at the checkpoint time are returned again but not emitted.
+	events = nil
+	visitNotes = append(visitNotes, &VisitNote{ID: 4, LastModified: start.Add(2 * time.Hour)})
+
+	assert.NoError(feed.Poll(ctx, collect))
+
+	if assert.Len(events, 1) {
+		assert.Equal(int64(4), events[0].ResourceID())
+		assert.Equal(int64(5), events[0].EventID)
+	}
+
+	assert.Contains(queries[len(queries)-2], "last_modified__gte=2024-01-01T02%3A00%3A00Z")
+}
+
+func TestChangeFeed_error(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		b, err := json.Marshal(Response[[]*Letter]{Results: []*Letter{
+			{ID: 1, DocumentDate: start.Add(time.Hour)},
+			{ID: 2, DocumentDate: start.Add(2 * time.Hour)},
+		}})
+		assert.NoError(err)
+
+		w.Header().Set("Content-Type", "application/json")
+		//nolint
+		w.Write(b)
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+	ctx := context.Background()
+
+	feed := NewChangeFeed(NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoints.json")), LetterChanges(client.Letters()))
+	feed.Start = start
+
+	var handled []int64
+	fail := true
+
+	handler := NewWebhookHandler(nil)
+	handler.OnLetterSaved(func(ctx context.Context, letter *Letter) error {
+		if letter.ID == 2 && fail {
+			fail = false
+			return errors.New("foo")
+		}
+
+		handled = append(handled, letter.ID)
+
+		return nil
+	})
+
+	assert.Error(feed.Poll(ctx, handler.Handle))
+	assert.Equal([]int64{1}, handled)
+
+	// The failed event is emitted again.
+	assert.NoError(feed.Poll(ctx, handler.Handle))
+	assert.Equal([]int64{1, 2}, handled)
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "checkpoints.json")
+	ctx := context.Background()
+
+	store := NewFileCheckpointStore(path)
+
+	checkpoint, err := store.LoadCheckpoint(ctx, ResourceLetters)
+	assert.NoError(err)
+	assert.Nil(checkpoint)
+
+	expected := &Checkpoint{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), IDs: []int64{1, 2}, EventID: 3}
+	assert.NoError(store.SaveCheckpoint(ctx, ResourceLetters, expected))
+	assert.NoError(store.SaveCheckpoint(ctx, ResourceVisitNotes, &Checkpoint{EventID: 4}))
+
+	checkpoint, err = NewFileCheckpointStore(path).LoadCheckpoint(ctx, ResourceLetters)
+	assert.NoError(err)
+	assert.Equal(expected, checkpoint)
+}
@@ -0,0 +1,215 @@
+package elation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+const defaultChartConcurrency = 4
+
+type ChartSection string
+
+const (
+	ChartSectionPatient                 ChartSection = "patient"
+	ChartSectionAllergies               ChartSection = "allergies"
+	ChartSectionProblems                ChartSection = "problems"
+	ChartSectionMedications             ChartSection = "medications"
+	ChartSectionDiscontinuedMedications ChartSection = "discontinued_medications"
+	ChartSectionInsurancePolicies       ChartSection = "insurance_policies"
+	ChartSectionVisitNotes              ChartSection = "visit_notes"
+	ChartSectionNonVisitNotes           ChartSection = "non_visit_notes"
+)
+
+// ChartSections returns every section of a PatientChart.
+func ChartSections() []ChartSection {
+	return []ChartSection{
+		ChartSectionPatient,
+		ChartSectionAllergies,
+		ChartSectionProblems,
+		ChartSectionMedications,
+		ChartSectionDiscontinuedMedications,
+		ChartSectionInsurancePolicies,
+		ChartSectionVisitNotes,
+		ChartSectionNonVisitNotes,
+	}
+}
+
+type ChartOptions struct {
+	// Sections are the sections to fetch. Every section is fetched if it is empty.
+	Sections []ChartSection
+
+	// Concurrency is the maximum number of sections fetched at once. It defaults to 4.
+	Concurrency int
+
+	// MaxItems limits the number of items fetched for each section. There is no limit if it is zero.
+	MaxItems int
+}
+
+// PatientChart is a patient with their clinical and insurance records. Sections that were not requested or failed to
+// load are empty.
+type PatientChart struct {
+	Patient                 *Patient
+	Allergies               []*Allergy
+	Problems                []*PatientProblem
+	Medications             []*PatientMedication
+	DiscontinuedMedications []*DiscontinuedMedication
+	InsurancePolicies       []*InsurancePolicy
+	VisitNotes              []*VisitNote
+	NonVisitNotes           []*NonVisitNote
+
+	// Errors are the errors of the sections that failed to load.
+	Errors map[ChartSection]error
+}
+
+// ChartSectionError is the error of a section of a PatientChart.
+type ChartSectionError struct {
+	Section ChartSection
+	Err     error
+}
+
+func (e *ChartSectionError) Error() string {
+	return fmt.Sprintf("fetching chart %s: %v", e.Section, e.Err)
+}
+
+func (e *ChartSectionError) Unwrap() error {
+	return e.Err
+}
+
+// GetChart fetches the sections of the chart of a patient concurrently, following pagination. A section that fails
+// does not stop the others: the chart is always returned, along with a *ChartSectionError for each failed section.
+func GetChart(ctx context.Context, client Client, patientID int64, opts ChartOptions) (*PatientChart, error) {
+	sections := opts.Sections
+	if len(sections) == 0 {
+		sections = ChartSections()
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultChartConcurrency
+	}
+
+	var pageOpts []PageOption
+	if opts.MaxItems > 0 {
+		pageOpts = append(pageOpts, WithMaxItems(opts.MaxItems))
+	}
+
+	chart := &PatientChart{
+		Errors: map[ChartSection]error{},
+	}
+
+	fetchers := map[ChartSection]func(ctx context.Context) error{
+		ChartSectionPatient: func(ctx context.Context) error {
+			patient, _, err := client.Patients().Get(ctx, patientID)
+			chart.Patient = patient
+			return err
+		},
+		ChartSectionAllergies: func(ctx context.Context) error {
+			var err error
+			chart.Allergies, err = collect(All(ctx, client.Allergies().Find, &FindAllergiesOptions{Patient: []int64{patientID}}, pageOpts...))
+			return err
+		},
+		ChartSectionProblems: func(ctx context.Context) error {
+			var err error
+			chart.Problems, err = collect(All(ctx, client.Problems().Find, &FindPatientProblemsOptions{Patient: patientID}, pageOpts...))
+			return err
+		},
+		ChartSectionMedications: func(ctx context.Context) error {
+			var err error
+			chart.Medications, err = collect(All(ctx, client.Medications().Find, &FindPatientMedicationsOptions{Patient: patientID}, pageOpts...))
+			return err
+		},
+		ChartSectionDiscontinuedMedications: func(ctx context.Context) error {
+			var err error
+			chart.DiscontinuedMedications, err = collect(All(ctx, client.DiscontinuedMedications().Find, &FindDiscontinuedMedicationsOptions{Patient: []int64{patientID}}, pageOpts...))
+			return err
+		},
+		ChartSectionInsurancePolicies: func(ctx context.Context) error {
+			find := func(ctx context.Context, opts *FindInsurancePoliciesOptions) (*FindInsurancePoliciesResponse, *http.Response, error) {
+				return client.InsurancePolicies().Find(ctx, patientID, opts)
+			}
+
+			var err error
+			chart.InsurancePolicies, err = collect(All(ctx, find, &FindInsurancePoliciesOptions{}, pageOpts...))
+			return err
+		},
+		ChartSectionVisitNotes: func(ctx context.Context) error {
+			var err error
+			chart.VisitNotes, err = collect(All(ctx, client.VisitNote().Find, &FindVisitNotesOptions{Patient: patientID}, pageOpts...))
+			return err
+		},
+		ChartSectionNonVisitNotes: func(ctx context.Context) error {
+			var err error
+			chart.NonVisitNotes, err = collect(All(ctx, client.NonVisitNotes().Find, &FindNonVisitNotesOptions{Patient: patientID}, pageOpts...))
+			return err
+		},
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	var unique []ChartSection
+	for _, section := range sections {
+		if _, ok := fetchers[section]; !ok {
+			return nil, fmt.Errorf("unknown chart section %q", section)
+		}
+
+		if !slices.Contains(unique, section) {
+			unique = append(unique, section)
+		}
+	}
+
+	sections = unique
+	sem := make(chan struct{}, concurrency)
+
+	for _, section := range sections {
+		fetch := fetchers[section]
+
+		wg.Go(func() {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				mu.Lock()
+				chart.Errors[section] = ctx.Err()
+				mu.Unlock()
+				return
+			}
+			defer func() { <-sem }()
+
+			if err := fetch(ctx); err != nil {
+				mu.Lock()
+				chart.Errors[section] = err
+				mu.Unlock()
+			}
+		})
+	}
+
+	wg.Wait()
+
+	var errs []error
+	for _, section := range sections {
+		if err, ok := chart.Errors[section]; ok {
+			errs = append(errs, &ChartSectionError{section, err})
+		}
+	}
+
+	return chart, errors.Join(errs...)
+}
+
+// collect returns the items of seq, stopping at the first error.
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
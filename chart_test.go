@@ -0,0 +1,131 @@
+package elation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetChart(t *testing.T) {
+	assert := assert.New(t)
+
+	var mu sync.Mutex
+	inFlight := 0
+	maxInFlight := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+
+		var body string
+
+		switch r.URL.Path {
+		case "/patients/1":
+			body = `{"id":1,"first_name":"Jane"}`
+		case "/allergies":
+			assert.Equal("1", r.URL.Query().Get("patient"))
+
+			if r.URL.Query().Get("offset") == "" {
+				body = `{"count":2,"next":"http://` + r.Host + `/allergies?patient=1&offset=1","results":[{"id":1}]}`
+			} else {
+				body = `{"count":2,"results":[{"id":2}]}`
+			}
+		case "/problems":
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		case "/medications", "/discontinued_medications", "/visit_notes", "/non_visit_notes":
+			assert.Equal("1", r.URL.Query().Get("patient"))
+			body = `{"results":[{"id":3}]}`
+		case "/patients/1/policies":
+			body = `{"results":[{"id":4,"patient_id":1}]}`
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		//nolint
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	client := NewClient(
+		WithHTTPClient(srv.Client()),
+		WithClientCredentials(srv.URL+"/token", "", ""),
+		WithBaseURL(srv.URL),
+		WithRetryPolicy(nil))
+
+	chart, err := GetChart(context.Background(), client, 1, ChartOptions{Concurrency: 2})
+	assert.ErrorIs(err, ErrServer)
+
+	sectionErr := &ChartSectionError{}
+	if assert.ErrorAs(err, &sectionErr) {
+		assert.Equal(ChartSectionProblems, sectionErr.Section)
+	}
+
+	assert.Len(chart.Errors, 1)
+	assert.Contains(chart.Errors, ChartSectionProblems)
+	assert.LessOrEqual(maxInFlight, 2)
+
+	assert.Equal(&Patient{ID: 1, FirstName: "Jane"}, chart.Patient)
+	assert.Equal([]*Allergy{{ID: 1}, {ID: 2}}, chart.Allergies)
+	assert.Nil(chart.Problems)
+	assert.Len(chart.Medications, 1)
+	assert.Len(chart.DiscontinuedMedications, 1)
+	assert.Equal([]*InsurancePolicy{{ID: 4, PatientID: 1}}, chart.InsurancePolicies)
+	assert.Len(chart.VisitNotes, 1)
+	assert.Len(chart.NonVisitNotes, 1)
+}
+
+func TestGetChart_sections(t *testing.T) {
+	assert := assert.New(t)
+
+	var paths []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		paths = append(paths, r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		//nolint
+		w.Write([]byte(`{"results":[{"id":1},{"id":2}]}`))
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+	chart, err := GetChart(context.Background(), client, 1, ChartOptions{
+		Sections: []ChartSection{ChartSectionAllergies, ChartSectionAllergies},
+		MaxItems: 1,
+	})
+	assert.NoError(err)
+	assert.Empty(chart.Errors)
+	assert.Equal([]*Allergy{{ID: 1}}, chart.Allergies)
+	assert.Nil(chart.Patient)
+	assert.Equal([]string{"/allergies"}, paths)
+
+	_, err = GetChart(context.Background(), client, 1, ChartOptions{Sections: []ChartSection{"foo"}})
+	assert.EqualError(err, `unknown chart section "foo"`)
+}
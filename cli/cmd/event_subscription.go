@@ -2,10 +2,18 @@ package cmd
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/authorhealth/go-elation"
 	"github.com/davecgh/go-spew/spew"
@@ -65,8 +73,148 @@ var subscribe = &cobra.Command{
 	}),
 }
 
+var (
+	syncSubscriptionsFile   string
+	syncSubscriptionsDryRun bool
+)
+
+var syncSubscriptions = &cobra.Command{
+	Use: "sync-subscriptions",
+	Run: wrapRunFunc(func(ctx context.Context, client elation.Client, args []string) error {
+		requestBytes, err := os.ReadFile(syncSubscriptionsFile)
+		if err != nil {
+			return err
+		}
+
+		var desired []elation.Subscribe
+		err = json.Unmarshal(requestBytes, &desired)
+		if err != nil {
+			return err
+		}
+
+		plan, err := elation.ReconcileSubscriptions(ctx, client.Subscriptions(), desired, &elation.ReconcileSubscriptionsOptions{
+			DryRun: syncSubscriptionsDryRun,
+		})
+		if plan != nil {
+			spew.Dump(plan)
+		}
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}),
+}
+
+var (
+	sendTestWebhookTarget     string
+	sendTestWebhookResource   string
+	sendTestWebhookAction     string
+	sendTestWebhookPrivateKey string
+)
+
+var sendTestWebhook = &cobra.Command{
+	Use: "send-test-webhook",
+	Run: wrapRunFunc(func(ctx context.Context, client elation.Client, args []string) error {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		if !json.Valid(data) {
+			return errors.New("event data is not valid JSON")
+		}
+
+		var privateKey ed25519.PrivateKey
+		if sendTestWebhookPrivateKey != "" {
+			privateKey, err = readPrivateKey(sendTestWebhookPrivateKey)
+			if err != nil {
+				return err
+			}
+		} else {
+			var publicKey ed25519.PublicKey
+			publicKey, privateKey, err = ed25519.GenerateKey(nil)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Signing public key: %s\n", base64.StdEncoding.EncodeToString(publicKey))
+		}
+
+		sender := elation.NewEventSender(sendTestWebhookTarget, privateKey)
+
+		res, err := sender.Send(ctx, &elation.Event{
+			Data:     data,
+			Action:   elation.WebhookEventAction(sendTestWebhookAction),
+			Resource: elation.Resource(sendTestWebhookResource),
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(res.Status)
+
+		return nil
+	}),
+}
+
+// readPrivateKey reads an Ed25519 private key from a PKCS #8 PEM file, or a file with the hex or base64 encoding of its
+// seed.
+func readPrivateKey(path string) (ed25519.PrivateKey, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if block, _ := pem.Decode(b); block != nil {
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		privateKey, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("unexpected private key type %T", key)
+		}
+
+		return privateKey, nil
+	}
+
+	s := strings.TrimSpace(string(b))
+
+	seed, err := hex.DecodeString(s)
+	if err != nil {
+		seed, err = base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, errors.New("private key is not PEM, hex or base64")
+		}
+	}
+
+	if len(seed) != ed25519.SeedSize {
+		return nil, elation.ErrPrivateKeyLength
+	}
+
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
 func init() {
 	rootCmd.AddCommand(deleteSubscription)
 	rootCmd.AddCommand(findSubscriptions)
 	rootCmd.AddCommand(subscribe)
+
+	syncSubscriptions.Flags().StringVar(&syncSubscriptionsFile, "file", "", "JSON file with the desired subscriptions")
+	syncSubscriptions.Flags().BoolVar(&syncSubscriptionsDryRun, "dry-run", false, "Print the plan without applying it")
+	//nolint
+	syncSubscriptions.MarkFlagRequired("file")
+	rootCmd.AddCommand(syncSubscriptions)
+
+	sendTestWebhook.Flags().StringVar(&sendTestWebhookTarget, "target", "", "URL to send the webhook to")
+	sendTestWebhook.Flags().StringVar(&sendTestWebhookResource, "resource", "", "Resource of the event, such as patients")
+	sendTestWebhook.Flags().StringVar(&sendTestWebhookAction, "action", elation.WebhookEventActionSaved.String(), "Action of the event, saved or deleted")
+	sendTestWebhook.Flags().StringVar(&sendTestWebhookPrivateKey, "private-key", "", "Ed25519 private key file; a new key is generated if empty")
+	//nolint
+	sendTestWebhook.MarkFlagRequired("target")
+	//nolint
+	sendTestWebhook.MarkFlagRequired("resource")
+	rootCmd.AddCommand(sendTestWebhook)
 }
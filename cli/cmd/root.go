@@ -64,6 +64,9 @@ func wrapRunFunc(runFunc runFunc) func(cmd *cobra.Command, args []string) {
 				slog.ErrorContext(ctx, "API error running command",
 					slog.Any("error", err),
 					slog.Int("statusCode", apiError.StatusCode),
+					slog.String("method", apiError.Method),
+					slog.String("path", apiError.Path),
+					slog.String("requestID", apiError.RequestID),
 					slog.String("body", apiError.Body))
 			} else {
 				slog.ErrorContext(ctx, "error running command", slog.Any("error", err))
@@ -7,8 +7,10 @@ import (
 	"io"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/authorhealth/go-elation"
+	"github.com/authorhealth/go-elation/ical"
 	"github.com/spf13/cobra"
 )
 
@@ -65,7 +67,95 @@ var updateAppointment = &cobra.Command{
 	}),
 }
 
+var (
+	exportCalendarPhysician int64
+	exportCalendarFrom      string
+	exportCalendarDays      int
+)
+
+var exportCalendar = &cobra.Command{
+	Use:  "export-calendar",
+	Args: cobra.NoArgs,
+	Run: wrapRunFunc(func(ctx context.Context, client elation.Client, args []string) error {
+		physician, _, err := client.Physicians().Get(ctx, exportCalendarPhysician)
+		if err != nil {
+			return err
+		}
+
+		practice, _, err := client.Practices().Get(ctx, int64(physician.Practice))
+		if err != nil {
+			return err
+		}
+
+		loc, err := practice.Location()
+		if err != nil {
+			return err
+		}
+
+		from := time.Now().In(loc)
+		if exportCalendarFrom != "" {
+			from, err = time.ParseInLocation(time.DateOnly, exportCalendarFrom, loc)
+			if err != nil {
+				return err
+			}
+		}
+
+		from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+		to := from.AddDate(0, 0, exportCalendarDays)
+
+		calendar := &ical.Calendar{Location: loc}
+
+		appointments := elation.All(ctx, client.Appointments().Find, &elation.FindAppointmentsOptions{
+			Physician: []int64{physician.ID},
+			FromDate:  from,
+			ToDate:    to,
+		})
+		for appointment, err := range appointments {
+			if err != nil {
+				return err
+			}
+
+			calendar.Events = append(calendar.Events, ical.FromElationAppointment(appointment))
+		}
+
+		groups := elation.All(ctx, client.RecurringEventGroups().Find, &elation.FindRecurringEventGroupsOptions{
+			Physician: []int64{physician.ID},
+			StartDate: from.Format(time.DateOnly),
+			EndDate:   to.Format(time.DateOnly),
+		})
+		for group, err := range groups {
+			if err != nil {
+				return err
+			}
+
+			if group.DeletedDate != nil {
+				continue
+			}
+
+			events, err := ical.FromElationRecurringEventGroup(group, loc)
+			if err != nil {
+				return err
+			}
+
+			for _, event := range events {
+				if event.Physician == physician.ID {
+					calendar.Events = append(calendar.Events, event)
+				}
+			}
+		}
+
+		return ical.Encode(os.Stdout, calendar)
+	}),
+}
+
 func init() {
 	rootCmd.AddCommand(getAppointment)
 	rootCmd.AddCommand(updateAppointment)
+
+	exportCalendar.Flags().Int64Var(&exportCalendarPhysician, "physician", 0, "ID of the physician")
+	exportCalendar.Flags().StringVar(&exportCalendarFrom, "from", "", "First date to export, such as 2024-01-01; defaults to today")
+	exportCalendar.Flags().IntVar(&exportCalendarDays, "days", 28, "Number of days to export")
+	//nolint
+	exportCalendar.MarkFlagRequired("physician")
+	rootCmd.AddCommand(exportCalendar)
 }
@@ -9,10 +9,12 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	querystring "github.com/google/go-querystring/query"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/oauth2"
@@ -54,9 +56,11 @@ type Client interface {
 }
 
 type HTTPClient struct {
-	httpClient *http.Client
-	baseURL    string
-	tracer     trace.Tracer
+	httpClient  *http.Client
+	baseURL     string
+	tracer      trace.Tracer
+	retryPolicy *RetryPolicy
+	rateLimiter RateLimiter
 
 	AllergySvc                 *AllergyService
 	AllergyDocumentationSvc    *AllergyDocumentationService
@@ -90,22 +94,75 @@ type HTTPClient struct {
 var _ Client = (*HTTPClient)(nil)
 
 func NewHTTPClient(httpClient *http.Client, tokenURL, clientID, clientSecret, baseURL string) *HTTPClient {
-	config := clientcredentials.Config{
-		ClientID:     clientID,
-		ClientSecret: clientSecret,
-		TokenURL:     tokenURL,
+	return NewClient(
+		WithHTTPClient(httpClient),
+		WithClientCredentials(tokenURL, clientID, clientSecret),
+		WithBaseURL(baseURL))
+}
+
+func NewClient(opts ...Option) *HTTPClient {
+	o := &clientOptions{
+		httpClient:     &http.Client{},
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		retryPolicy:    DefaultRetryPolicy(),
 	}
 
-	httpClient.Transport = otelhttp.NewTransport(httpClient.Transport,
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	transport := o.baseTransport
+	if transport == nil {
+		transport = o.httpClient.Transport
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	if o.userAgent != "" || len(o.headers) > 0 {
+		transport = &headerTransport{
+			base:      transport,
+			userAgent: o.userAgent,
+			headers:   o.headers,
+		}
+	}
+
+	transport = otelhttp.NewTransport(transport,
 		// Ensure that the trace context is not propagated by using an empty composite propagator.
-		otelhttp.WithPropagators(propagation.NewCompositeTextMapPropagator()))
+		otelhttp.WithPropagators(propagation.NewCompositeTextMapPropagator()),
+		otelhttp.WithTracerProvider(o.tracerProvider),
+		otelhttp.WithMeterProvider(o.meterProvider))
+
+	// Copy the caller's client so that its transport is left untouched.
+	baseHTTPClient := *o.httpClient
+	baseHTTPClient.Transport = transport
+
+	tokenSource := o.tokenSource
+	if tokenSource == nil {
+		config := clientcredentials.Config{
+			ClientID:     o.clientID,
+			ClientSecret: o.clientSecret,
+			TokenURL:     o.tokenURL,
+			Scopes:       o.scopes,
+		}
+
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &baseHTTPClient)
+		tokenSource = config.TokenSource(ctx)
+	}
 
-	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+	httpClient := baseHTTPClient
+	httpClient.Transport = &oauth2.Transport{
+		Base:   transport,
+		Source: oauth2.ReuseTokenSource(nil, tokenSource),
+	}
 
 	client := &HTTPClient{
-		httpClient: config.Client(ctx),
-		baseURL:    baseURL,
-		tracer:     otel.GetTracerProvider().Tracer("github.com/authorhealth/go-elation"),
+		httpClient:  &httpClient,
+		baseURL:     o.baseURL,
+		tracer:      o.tracerProvider.Tracer("github.com/authorhealth/go-elation"),
+		retryPolicy: o.retryPolicy,
+		rateLimiter: o.rateLimiter,
 	}
 
 	client.AllergySvc = &AllergyService{client}
@@ -139,6 +196,16 @@ func NewHTTPClient(httpClient *http.Client, tokenURL, clientID, clientSecret, ba
 	return client
 }
 
+// SetRetryPolicy replaces the policy used to retry failed requests. A nil policy disables retries.
+func (c *HTTPClient) SetRetryPolicy(policy *RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+// SetRateLimiter sets the limiter consulted before every request attempt. A nil limiter disables rate limiting.
+func (c *HTTPClient) SetRateLimiter(limiter RateLimiter) {
+	c.rateLimiter = limiter
+}
+
 func (c *HTTPClient) Allergies() AllergyServicer {
 	return c.AllergySvc
 }
@@ -277,21 +344,12 @@ func (r *Response[ResultsT]) PaginationNextWithLimit(limit int) *Pagination {
 	return p
 }
 
-type Error struct {
-	StatusCode int
-	Body       string
-}
-
 type Pagination struct {
 	Cursor string `url:"cursor,omitempty"`
 	Limit  int    `url:"limit,omitempty"`
 	Offset int    `url:"offset,omitempty"`
 }
 
-func (e Error) Error() string {
-	return fmt.Sprintf("API error (status code %d)", e.StatusCode)
-}
-
 func (c *HTTPClient) request(ctx context.Context, method string, path string, query any, body any, out any) (*http.Response, error) {
 	q, err := querystring.Values(query)
 	if err != nil {
@@ -303,42 +361,66 @@ func (c *HTTPClient) request(ctx context.Context, method string, path string, qu
 		u = u + "?" + q.Encode()
 	}
 
-	reader := bytes.NewReader(nil)
+	var b []byte
 	if body != nil {
-		b, err := json.Marshal(body)
+		b, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("marshaling request body: %w", err)
 		}
-
-		reader = bytes.NewReader(b)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, u, reader)
-	if err != nil {
-		return nil, fmt.Errorf("making new HTTP request: %w", err)
-	}
+	span := trace.SpanFromContext(ctx)
+	maxAttempts := c.retryPolicy.maxAttempts(method)
 
-	req.Header.Set("Content-Type", "application/json")
+	var res *http.Response
+	var resBody []byte
 
-	res, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("doing HTTP request: %w", err)
+	for attempt := 1; ; attempt++ {
+		span.SetAttributes(attribute.Int("elation.request.attempt", attempt))
+
+		err = c.waitRateLimiter(ctx, method)
+		if err != nil {
+			return nil, err
+		}
+
+		res, resBody, err = c.do(ctx, method, u, b)
+		if attempt >= maxAttempts || !c.retryPolicy.shouldRetry(res, err) {
+			break
+		}
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+
+		delay, ok := c.retryPolicy.backoff(attempt, res)
+		if !ok {
+			break
+		}
+
+		attrs := []attribute.KeyValue{
+			attribute.Int("elation.request.attempt", attempt),
+			attribute.Int64("elation.request.retry_delay_ms", delay.Milliseconds()),
+		}
+		if res != nil {
+			attrs = append(attrs, attribute.Int("http.response.status_code", res.StatusCode))
+		}
+		if err != nil {
+			attrs = append(attrs, attribute.String("error", err.Error()))
+		}
+		span.AddEvent("retrying request", trace.WithAttributes(attrs...))
+
+		err = sleep(ctx, delay)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	resBody, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, fmt.Errorf("reading response body: %w", err)
+		return nil, err
 	}
-	//nolint
-	_ = res.Body.Close()
-
-	res.Body = io.NopCloser(bytes.NewBuffer(resBody))
 
 	if res.StatusCode >= http.StatusBadRequest {
-		return res, &Error{
-			StatusCode: res.StatusCode,
-			Body:       string(resBody),
-		}
+		return res, newError(res, resBody)
 	}
 
 	if out != nil {
@@ -351,6 +433,57 @@ func (c *HTTPClient) request(ctx context.Context, method string, path string, qu
 	return res, nil
 }
 
+func (c *HTTPClient) waitRateLimiter(ctx context.Context, method string) error {
+	if c.rateLimiter == nil {
+		return nil
+	}
+
+	start := time.Now()
+
+	err := c.rateLimiter.Wait(ctx, method)
+
+	waited := time.Since(start)
+	if waited >= time.Millisecond {
+		trace.SpanFromContext(ctx).AddEvent("waited on rate limiter", trace.WithAttributes(
+			attribute.Int64("elation.rate_limiter.wait_ms", waited.Milliseconds())))
+	}
+
+	if err != nil {
+		return fmt.Errorf("waiting on rate limiter: %w", err)
+	}
+
+	return nil
+}
+
+// do makes a single attempt of a request. The request body is rebuilt from b on every call so that it can be retried.
+func (c *HTTPClient) do(ctx context.Context, method string, u string, b []byte) (*http.Response, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(b))
+	if err != nil {
+		return nil, nil, fmt.Errorf("making new HTTP request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("doing HTTP request: %w", err)
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		//nolint
+		_ = res.Body.Close()
+
+		return nil, nil, fmt.Errorf("reading response body: %w", err)
+	}
+	//nolint
+	_ = res.Body.Close()
+
+	res.Body = io.NopCloser(bytes.NewBuffer(resBody))
+
+	return res, resBody, nil
+}
+
 func parsePagination(v string) *Pagination {
 	p := &Pagination{
 		Limit: defaultPaginationLimit,
@@ -0,0 +1,268 @@
+package elationtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+)
+
+const (
+	billingStatusUnbilled = "Unbilled"
+	billingStatusBilled   = "Billed"
+	billingStatusFailed   = "Failed"
+
+	// billExistError mirrors the message the API returns when a visit note is billed twice.
+	billExistError = "The visit note provided already has a bill associated with it."
+)
+
+var _ elation.BillServicer = (*BillService)(nil)
+
+type BillService struct {
+	client *Client
+}
+
+func (s *BillService) Create(ctx context.Context, billCreate *elation.BillCreate) (*elation.CreatedBill, *http.Response, error) {
+	if err := s.client.begin(ctx, "Bill.Create"); err != nil {
+		return nil, nil, err
+	}
+	defer s.client.end()
+
+	existing := s.client.bills.list(func(bill *elation.Bill, _ time.Time) bool {
+		return bill.VisitNoteID == billCreate.VisitNote
+	})
+	if len(existing) > 0 {
+		err := badRequest(http.MethodPost, "/bills", "visit_note", billExistError)
+		return nil, errorResponse(err), fmt.Errorf("%w: %w", elation.ErrBillExist, err)
+	}
+
+	visitNote, ok := s.client.visitNotes.get(billCreate.VisitNote)
+	if !ok {
+		err := badRequest(http.MethodPost, "/bills", "visit_note", "Invalid pk - object does not exist.")
+		return nil, errorResponse(err), err
+	}
+
+	now := s.client.now()
+
+	bill := &elation.Bill{
+		ServiceDate:         visitNote.DocumentDate,
+		BillingStatus:       billingStatusUnbilled,
+		Notes:               billCreate.Notes,
+		VisitNoteID:         visitNote.ID,
+		VisitNoteSignedDate: visitNote.SignedDate,
+		ReferringProvider:   billCreate.ReferringProvider,
+		OrderingProvider:    billCreate.OrderingProvider,
+		Physician:           billCreate.Physician,
+		Practice:            billCreate.Practice,
+		Patient:             billCreate.Patient,
+		CreatedDate:         now,
+		LastModifiedDate:    now,
+	}
+
+	if billCreate.BillingProvider != 0 {
+		bill.BillingProvider = new(billCreate.BillingProvider)
+	}
+
+	if billCreate.RenderingProvider != 0 {
+		bill.RenderingProvider = new(billCreate.RenderingProvider)
+	}
+
+	if billCreate.SupervisingProvider != 0 {
+		bill.SupervisingProvider = new(billCreate.SupervisingProvider)
+	}
+
+	if billCreate.PriorAuthorization != "" {
+		bill.PriorAuthorization = new(billCreate.PriorAuthorization)
+	}
+
+	if billCreate.PaymentAmount != 0 {
+		bill.Payment = elation.BillPayment{
+			Amount:        strconv.FormatFloat(billCreate.PaymentAmount, 'f', 2, 64),
+			WhenCollected: now,
+		}
+	}
+
+	if serviceLocation, ok := s.client.serviceLocations.get(billCreate.ServiceLocation); ok {
+		bill.ServiceLocation = *serviceLocation
+	} else {
+		bill.ServiceLocation = elation.ServiceLocation{ID: billCreate.ServiceLocation}
+	}
+
+	for _, cpt := range billCreate.CPTs {
+		billCPT := &elation.BillCPT{
+			CPT:        cpt.CPT,
+			Modifiers:  cpt.Modifiers,
+			AltDXs:     cpt.AltDXs,
+			UnitCharge: cpt.UnitCharge,
+			Units:      cpt.Units,
+		}
+
+		for _, dx := range cpt.DXs {
+			billCPT.DXs = append(billCPT.DXs, dx.ICD10Code)
+		}
+
+		bill.CPTs = append(bill.CPTs, billCPT)
+	}
+
+	bill = s.client.bills.insert(s.client, bill)
+
+	return &elation.CreatedBill{
+		ID:                   bill.ID,
+		ServiceDate:          bill.ServiceDate,
+		BillingStatus:        bill.BillingStatus,
+		Notes:                bill.Notes,
+		CPTs:                 billCreate.CPTs,
+		VisitNote:            bill.VisitNoteID,
+		VisitNoteSignedDate:  bill.VisitNoteSignedDate,
+		VisitNoteDeletedDate: bill.VisitNoteDeletedDate,
+		ReferringProvider:    bill.ReferringProvider,
+		BillingProvider:      bill.BillingProvider,
+		RenderingProvider:    bill.RenderingProvider,
+		SupervisingProvider:  bill.SupervisingProvider,
+		OrderingProvider:     bill.OrderingProvider,
+		ServiceLocation:      bill.ServiceLocation.ID,
+		Physician:            bill.Physician,
+		Practice:             bill.Practice,
+		Patient:              bill.Patient,
+		PriorAuthorization:   bill.PriorAuthorization,
+		CreatedDate:          bill.CreatedDate,
+		LastModifiedDate:     bill.LastModifiedDate,
+	}, response(http.StatusCreated), nil
+}
+
+func (s *BillService) Find(ctx context.Context, opts *elation.FindBillOptions) (*elation.Response[[]*elation.Bill], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindBillOptions{}
+	}
+
+	return find(ctx, s.client, "Bill.Find", "/bills", s.client.bills, opts.Pagination, func(bill *elation.Bill, _ time.Time) bool {
+		return matchAny(opts.AssignedPhysician, bill.Physician) &&
+			matchAny(opts.BillID, bill.ID) &&
+			matchAny(opts.Patient, bill.Patient) &&
+			matchAny(opts.VisitNoteID, bill.VisitNoteID) &&
+			(opts.SigningPhysician == 0 || s.client.visitNoteSignedBy(bill.VisitNoteID) == opts.SigningPhysician) &&
+			matchTime(bill.ServiceDate, time.Time{}, opts.FromServiceDate, time.Time{}, opts.ToServiceDate)
+	})
+}
+
+func (s *BillService) Get(ctx context.Context, id int64) (*elation.Bill, *http.Response, error) {
+	return get(ctx, s.client, "Bill.Get", "/bills", s.client.bills, id)
+}
+
+func (s *BillService) Update(ctx context.Context, id int64, billUpdate *elation.BillUpdate) (*elation.Bill, *http.Response, error) {
+	if err := s.client.begin(ctx, "Bill.Update"); err != nil {
+		return nil, nil, err
+	}
+	defer s.client.end()
+
+	path := idPath("/bills", id)
+
+	if _, ok := s.client.bills.get(id); !ok {
+		err := notFound(http.MethodPatch, path)
+		return nil, errorResponse(err), err
+	}
+
+	// Marking a bill as processed requires both its reference number and billing date.
+	processed := billUpdate.RefNumber != nil || billUpdate.BillingDate != nil
+	if processed && billUpdate.RefNumber == nil {
+		err := badRequest(http.MethodPatch, path, "ref_number", "This field is required.")
+		return nil, errorResponse(err), err
+	}
+
+	if processed && billUpdate.BillingDate == nil {
+		err := badRequest(http.MethodPatch, path, "billing_date", "This field is required.")
+		return nil, errorResponse(err), err
+	}
+
+	if billUpdate.RefNumber != nil && *billUpdate.RefNumber == "" {
+		err := badRequest(http.MethodPatch, path, "ref_number", "This field may not be blank.")
+		return nil, errorResponse(err), err
+	}
+
+	if billUpdate.BillingError != nil && *billUpdate.BillingError == "" {
+		err := badRequest(http.MethodPatch, path, "billing_error", "This field may not be blank.")
+		return nil, errorResponse(err), err
+	}
+
+	if billUpdate.RefNumber != nil && len(*billUpdate.RefNumber) > 50 {
+		err := badRequest(http.MethodPatch, path, "ref_number", "Ensure this field has no more than 50 characters.")
+		return nil, errorResponse(err), err
+	}
+
+	if billUpdate.BillingError != nil && len(*billUpdate.BillingError) > 200 {
+		err := badRequest(http.MethodPatch, path, "billing_error", "Ensure this field has no more than 200 characters.")
+		return nil, errorResponse(err), err
+	}
+
+	bill, _ := s.client.bills.update(s.client, id, func(bill *elation.Bill) {
+		setPtr(&bill.Notes, billUpdate.Notes)
+
+		switch {
+		case processed:
+			bill.RefNumber = new(*billUpdate.RefNumber)
+			bill.BillingDate = new(*billUpdate.BillingDate)
+			bill.BillingError = nil
+			bill.BillingRawError = nil
+			bill.BillingStatus = billingStatusBilled
+		case billUpdate.BillingError != nil:
+			bill.BillingError = new(*billUpdate.BillingError)
+			bill.BillingRawError = nil
+			if billUpdate.BillingRawError != nil {
+				bill.BillingRawError = new(*billUpdate.BillingRawError)
+			}
+			bill.BillingStatus = billingStatusFailed
+		}
+
+		bill.LastModifiedDate = s.client.now()
+	})
+
+	return bill, response(http.StatusOK), nil
+}
+
+func (s *BillService) MarkProcessed(ctx context.Context, id int64, refNumber string, billingDate time.Time) (*elation.Bill, *http.Response, error) {
+	if refNumber == "" {
+		return nil, nil, errors.New("reference number is required")
+	}
+
+	if billingDate.IsZero() {
+		return nil, nil, errors.New("billing date is required")
+	}
+
+	return s.Update(ctx, id, &elation.BillUpdate{
+		RefNumber:   &refNumber,
+		BillingDate: &billingDate,
+	})
+}
+
+func (s *BillService) MarkFailed(ctx context.Context, id int64, billingError string, billingRawError string) (*elation.Bill, *http.Response, error) {
+	if billingError == "" {
+		return nil, nil, errors.New("billing error is required")
+	}
+
+	billUpdate := &elation.BillUpdate{
+		BillingError: &billingError,
+	}
+
+	if billingRawError != "" {
+		billUpdate.BillingRawError = &billingRawError
+	}
+
+	return s.Update(ctx, id, billUpdate)
+}
+
+func (s *BillService) Delete(ctx context.Context, id int64) (*http.Response, error) {
+	return remove(ctx, s.client, "Bill.Delete", "/bills", s.client.bills, id)
+}
+
+func (c *Client) visitNoteSignedBy(visitNoteID int64) int64 {
+	visitNote, ok := c.visitNotes.get(visitNoteID)
+	if !ok {
+		return 0
+	}
+
+	return visitNote.SignedBy
+}
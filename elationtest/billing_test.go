@@ -0,0 +1,102 @@
+package elationtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBillService_Create(t *testing.T) {
+	assert := assert.New(t)
+
+	client := NewClient()
+	ctx := context.Background()
+
+	documentDate := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	Seed(client, &elation.VisitNote{ID: 10, DocumentDate: documentDate, SignedBy: 5})
+
+	billCreate := &elation.BillCreate{
+		VisitNote: 10,
+		Patient:   1,
+		Practice:  2,
+		Physician: 3,
+		CPTs: []*elation.CreatedBillCPT{
+			{CPT: "99213", DXs: []elation.CreatedBillDX{{ICD10Code: "D23.4"}}},
+		},
+		PaymentAmount: 10,
+	}
+
+	created, _, err := client.Bill().Create(ctx, billCreate)
+	assert.NoError(err)
+	assert.Equal(documentDate, created.ServiceDate)
+	assert.Equal("Unbilled", created.BillingStatus)
+
+	bill, _, err := client.Bill().Get(ctx, created.ID)
+	assert.NoError(err)
+	assert.Equal([]string{"D23.4"}, bill.CPTs[0].DXs)
+	assert.Equal("10.00", bill.Payment.Amount)
+
+	_, _, err = client.Bill().Create(ctx, billCreate)
+	assert.ErrorIs(err, elation.ErrBillExist)
+	assert.True(elation.IsBadRequest(err))
+
+	res, _, err := client.Bill().Find(ctx, &elation.FindBillOptions{SigningPhysician: 5})
+	assert.NoError(err)
+	assert.Len(res.Results, 1)
+
+	res, _, err = client.Bill().Find(ctx, &elation.FindBillOptions{SigningPhysician: 6})
+	assert.NoError(err)
+	assert.Empty(res.Results)
+}
+
+func TestBillService_MarkProcessed(t *testing.T) {
+	assert := assert.New(t)
+
+	client := NewClient()
+	ctx := context.Background()
+
+	Seed(client, &elation.Bill{ID: 1, BillingStatus: "Unbilled"})
+
+	_, _, err := client.Bill().Update(ctx, 1, &elation.BillUpdate{RefNumber: new("REF-1234")})
+	assert.True(elation.IsBadRequest(err), "billing date is required with the reference number")
+
+	failed, _, err := client.Bill().MarkFailed(ctx, 1, "Invalid CPT", "E42")
+	assert.NoError(err)
+	assert.Equal("Failed", failed.BillingStatus)
+	assert.Equal(new("Invalid CPT"), failed.BillingError)
+	assert.Equal(new("E42"), failed.BillingRawError)
+
+	billingDate := time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC)
+
+	processed, _, err := client.Bill().MarkProcessed(ctx, 1, "REF-1234", billingDate)
+	assert.NoError(err)
+	assert.Equal("Billed", processed.BillingStatus)
+	assert.Equal(new("REF-1234"), processed.RefNumber)
+	assert.Equal(&billingDate, processed.BillingDate)
+	assert.Nil(processed.BillingError)
+	assert.Nil(processed.BillingRawError)
+
+	_, _, err = client.Bill().MarkProcessed(ctx, 2, "REF-1234", billingDate)
+	assert.True(elation.IsNotFound(err))
+
+	_, res, err := client.Bill().MarkProcessed(ctx, 1, "", billingDate)
+	assert.EqualError(err, "reference number is required")
+	assert.Nil(res)
+
+	_, res, err = client.Bill().MarkProcessed(ctx, 1, "REF-1234", time.Time{})
+	assert.EqualError(err, "billing date is required")
+	assert.Nil(res)
+
+	_, res, err = client.Bill().MarkFailed(ctx, 1, "", "E42")
+	assert.EqualError(err, "billing error is required")
+	assert.Nil(res)
+
+	_, err = client.Bill().Delete(ctx, 1)
+	assert.NoError(err)
+
+	_, _, err = client.Bill().Get(ctx, 1)
+	assert.True(elation.IsNotFound(err))
+}
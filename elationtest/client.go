@@ -0,0 +1,735 @@
+// Package elationtest provides a stateful, in-memory implementation of elation.Client for use in tests.
+package elationtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+)
+
+const (
+	defaultPaginationLimit = 25
+
+	// baseURL is only used to build the next and previous links of paginated responses.
+	baseURL = "https://elationtest.invalid/api/2.0"
+)
+
+// Hook is called before every operation with the operation name, such as "Patients.Find" or "Appointments.Create". A
+// non-nil error fails the operation and is returned to the caller.
+type Hook func(ctx context.Context, op string) error
+
+type Client struct {
+	// Now returns the time used for created, modified and deleted dates. It defaults to time.Now.
+	Now func() time.Time
+
+	mu       sync.Mutex
+	nextID   int64
+	hook     Hook
+	failures map[string][]error
+	tables   []any
+
+	listeners []*func(Change)
+	changes   []Change
+
+	// signingPubKey is returned with new subscriptions. It is set by a Server that sends webhooks for the client.
+	signingPubKey string
+
+	allergies                *table[elation.Allergy]
+	allergyDocumentation     *table[elation.AllergyDocumentation]
+	appointments             *table[elation.Appointment]
+	bills                    *table[elation.Bill]
+	clinicalDocuments        *table[elation.ClinicalDocument]
+	contacts                 *table[elation.Contact]
+	discontinuedMedications  *table[elation.DiscontinuedMedication]
+	historyDownloadFills     *table[elation.HistoryDownloadFill]
+	insuranceCompanies       *table[elation.InsuranceCompany]
+	insuranceEligibility     *table[elation.InsuranceEligibility]
+	insuranceEligibilityFull *table[elation.InsuranceEligibilityFullReport]
+	insurancePlans           *table[elation.InsurancePlan]
+	insurancePolicies        *table[elation.InsurancePolicy]
+	letters                  *table[elation.Letter]
+	medications              *table[elation.PatientMedication]
+	messageThreads           *table[elation.MessageThread]
+	nonVisitNotes            *table[elation.NonVisitNote]
+	patients                 *table[elation.Patient]
+	pharmacies               *table[elation.Pharmacy]
+	physicians               *table[elation.Physician]
+	practices                *table[elation.Practice]
+	prescriptionFills        *table[elation.PrescriptionFill]
+	problems                 *table[elation.PatientProblem]
+	recurringEventGroups     *table[elation.RecurringEventGroup]
+	serviceLocations         *table[elation.ServiceLocation]
+	subscriptions            *table[elation.Subscription]
+	threadMembers            *table[elation.ThreadMember]
+	visitNotes               *table[elation.VisitNote]
+}
+
+var _ elation.Client = (*Client)(nil)
+
+func NewClient() *Client {
+	c := &Client{
+		Now:      time.Now,
+		nextID:   1,
+		failures: map[string][]error{},
+	}
+
+	c.allergies = addTable(c, elation.ResourceAllergies, func(v *elation.Allergy) *int64 { return &v.ID })
+	c.allergyDocumentation = addTable(c, elation.ResourceAllergyDocumentation, func(v *elation.AllergyDocumentation) *int64 { return &v.ID })
+	c.appointments = addTable(c, elation.ResourceAppointments, func(v *elation.Appointment) *int64 { return &v.ID })
+	c.bills = addTable(c, elation.ResourceBills, func(v *elation.Bill) *int64 { return &v.ID })
+	c.clinicalDocuments = addTable(c, elation.ResourceClinicalDocuments, func(v *elation.ClinicalDocument) *int64 { return &v.ID })
+	c.contacts = addTable(c, "", func(v *elation.Contact) *int64 { return &v.ID })
+	c.discontinuedMedications = addTable(c, elation.ResourceDiscontinuedMedications, func(v *elation.DiscontinuedMedication) *int64 { return &v.ID })
+	c.historyDownloadFills = addTable(c, "", func(v *elation.HistoryDownloadFill) *int64 { return &v.ID })
+	c.insuranceCompanies = addTable(c, elation.ResourceInsuranceCompanies, func(v *elation.InsuranceCompany) *int64 { return &v.ID })
+	c.insuranceEligibility = addTable(c, "", func(v *elation.InsuranceEligibility) *int64 { return &v.PatientInsuranceID })
+	c.insuranceEligibilityFull = addTable(c, "", func(v *elation.InsuranceEligibilityFullReport) *int64 { return &v.PatientInsuranceID })
+	c.insurancePlans = addTable(c, elation.ResourceInsurancePlans, func(v *elation.InsurancePlan) *int64 { return &v.ID })
+	c.insurancePolicies = addTable(c, elation.ResourceInsurancePolicies, func(v *elation.InsurancePolicy) *int64 { return &v.ID })
+	c.letters = addTable(c, elation.ResourceLetters, func(v *elation.Letter) *int64 { return &v.ID })
+	c.medications = addTable(c, elation.ResourceMedications, func(v *elation.PatientMedication) *int64 { return &v.ID })
+	c.messageThreads = addTable(c, elation.ResourceMessageThreads, func(v *elation.MessageThread) *int64 { return &v.ID })
+	c.nonVisitNotes = addTable(c, elation.ResourceNonVisitNotes, func(v *elation.NonVisitNote) *int64 { return &v.ID })
+	c.patients = addTable(c, elation.ResourcePatients, func(v *elation.Patient) *int64 { return &v.ID })
+	c.pharmacies = addTable(c, "", func(v *elation.Pharmacy) *int64 { return &v.ID })
+	c.physicians = addTable(c, elation.ResourcePhysicians, func(v *elation.Physician) *int64 { return &v.ID })
+	c.practices = addTable(c, "", func(v *elation.Practice) *int64 { return &v.ID })
+	c.prescriptionFills = addTable(c, "", func(v *elation.PrescriptionFill) *int64 { return &v.ID })
+	c.problems = addTable(c, elation.ResourceProblems, func(v *elation.PatientProblem) *int64 { return &v.ID })
+	c.recurringEventGroups = addTable(c, "", func(v *elation.RecurringEventGroup) *int64 { return &v.ID })
+	c.serviceLocations = addTable(c, elation.ResourceServiceLocations, func(v *elation.ServiceLocation) *int64 { return &v.ID })
+	c.subscriptions = addTable(c, "", func(v *elation.Subscription) *int64 { return &v.ID })
+	c.threadMembers = addTable(c, "", func(v *elation.ThreadMember) *int64 { return &v.ID })
+	c.visitNotes = addTable(c, elation.ResourceVisitNotes, func(v *elation.VisitNote) *int64 { return &v.ID })
+
+	return c
+}
+
+// SetHook sets a hook that is called before every operation. A nil hook removes it.
+func (c *Client) SetHook(hook Hook) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.hook = hook
+}
+
+// FailNext makes the next call of op, such as "Patients.Get", return err. Calling it several times for the same op
+// queues the errors in order.
+func (c *Client) FailNext(op string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.failures[op] = append(c.failures[op], err)
+}
+
+// Change describes a stored row that was created, updated or deleted through the client.
+type Change struct {
+	Resource elation.Resource
+	Action   elation.WebhookEventAction
+	ID       int64
+
+	// Data is a copy of the stored row. It is nil for deleted rows.
+	Data any
+}
+
+// OnChange registers fn to be called after every operation that creates, updates or deletes a row of a resource that
+// supports webhooks. Rows added with Seed are not reported. Fn is called without the client locked. The returned
+// function unregisters fn.
+func (c *Client) OnChange(fn func(Change)) func() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	listener := &fn
+	c.listeners = append(c.listeners, listener)
+
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		c.listeners = slices.DeleteFunc(slices.Clone(c.listeners), func(l *func(Change)) bool { return l == listener })
+	}
+}
+
+// Seed adds items to the store, assigning IDs to items without one. It panics if T is not a resource stored by the
+// client.
+func Seed[T any](c *Client, items ...*T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := tableFor[T](c)
+	for _, item := range items {
+		t.put(c, item)
+	}
+}
+
+// Items returns copies of every stored item of type T, in insertion order. It panics if T is not a resource stored by
+// the client.
+func Items[T any](c *Client) []*T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return tableFor[T](c).list(nil)
+}
+
+func (c *Client) Allergies() elation.AllergyServicer {
+	return &AllergyService{c}
+}
+
+func (c *Client) AllergyDocumentation() elation.AllergyDocumentationServicer {
+	return &AllergyDocumentationService{c}
+}
+
+func (c *Client) Appointments() elation.AppointmentServicer {
+	return &AppointmentService{c}
+}
+
+func (c *Client) Bill() elation.BillServicer {
+	return &BillService{c}
+}
+
+func (c *Client) ClinicalDocuments() elation.ClinicalDocumentServicer {
+	return &ClinicalDocumentService{c}
+}
+
+func (c *Client) Contacts() elation.ContactServicer {
+	return &ContactService{c}
+}
+
+func (c *Client) DiscontinuedMedications() elation.DiscontinuedMedicationServicer {
+	return &DiscontinuedMedicationService{c}
+}
+
+func (c *Client) HistoryDownloadFills() elation.HistoryDownloadFillServicer {
+	return &HistoryDownloadFillService{c}
+}
+
+func (c *Client) InsuranceCompanies() elation.InsuranceCompanyServicer {
+	return &InsuranceCompanyService{c}
+}
+
+func (c *Client) InsuranceEligibility() elation.InsuranceEligibilityServicer {
+	return &InsuranceEligibilityService{c}
+}
+
+func (c *Client) InsurancePlans() elation.InsurancePlanServicer {
+	return &InsurancePlanService{c}
+}
+
+func (c *Client) InsurancePolicies() elation.InsurancePolicyServicer {
+	return &InsurancePolicyService{c}
+}
+
+func (c *Client) Letters() elation.LetterServicer {
+	return &LetterService{c}
+}
+
+func (c *Client) Medications() elation.MedicationServicer {
+	return &MedicationService{c}
+}
+
+func (c *Client) MessageThreads() elation.MessageThreadServicer {
+	return &MessageThreadService{c}
+}
+
+func (c *Client) NonVisitNotes() elation.NonVisitNoteServicer {
+	return &NonVisitNoteService{c}
+}
+
+func (c *Client) Patients() elation.PatientServicer {
+	return &PatientService{c}
+}
+
+func (c *Client) Pharmacies() elation.PharmacyServicer {
+	return &PharmacyService{c}
+}
+
+func (c *Client) Physicians() elation.PhysicianServicer {
+	return &PhysicianService{c}
+}
+
+func (c *Client) Practices() elation.PracticeServicer {
+	return &PracticeService{c}
+}
+
+func (c *Client) PrescriptionFills() elation.PrescriptionFillServicer {
+	return &PrescriptionFillService{c}
+}
+
+func (c *Client) Problems() elation.ProblemServicer {
+	return &ProblemService{c}
+}
+
+func (c *Client) RecurringEventGroups() elation.RecurringEventGroupServicer {
+	return &RecurringEventGroupService{c}
+}
+
+func (c *Client) ServiceLocations() elation.ServiceLocationServicer {
+	return &ServiceLocationService{c}
+}
+
+func (c *Client) Subscriptions() elation.SubscriptionServicer {
+	return &SubscriptionService{c}
+}
+
+func (c *Client) ThreadMembers() elation.ThreadMemberServicer {
+	return &ThreadMemberService{c}
+}
+
+func (c *Client) VisitNote() elation.VisitNoteServicer {
+	return &VisitNoteService{c}
+}
+
+// begin runs the error injection hooks for op and, if they pass, locks the client. The caller must call c.end.
+func (c *Client) begin(ctx context.Context, op string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+
+	hook := c.hook
+
+	var err error
+	if failures := c.failures[op]; len(failures) > 0 {
+		err = failures[0]
+		c.failures[op] = failures[1:]
+	}
+
+	c.mu.Unlock()
+
+	// The hook may call back into the client, so it runs unlocked.
+	if err == nil && hook != nil {
+		err = hook(ctx, op)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+
+	return nil
+}
+
+// end unlocks the client and reports the changes made since begin.
+func (c *Client) end() {
+	changes := c.changes
+	listeners := c.listeners
+	c.changes = nil
+
+	c.mu.Unlock()
+
+	for _, change := range changes {
+		for _, fn := range listeners {
+			(*fn)(change)
+		}
+	}
+}
+
+func (c *Client) now() time.Time {
+	return c.Now().UTC()
+}
+
+func (c *Client) newID() int64 {
+	id := c.nextID
+	c.nextID++
+
+	return id
+}
+
+type table[T any] struct {
+	// resource is the webhook resource of the rows. Changes to tables without one are not reported.
+	resource elation.Resource
+	key      func(*T) *int64
+	rows     map[int64]*T
+	order    []int64
+	modified map[int64]time.Time
+}
+
+func addTable[T any](c *Client, resource elation.Resource, key func(*T) *int64) *table[T] {
+	t := &table[T]{
+		resource: resource,
+		key:      key,
+		rows:     map[int64]*T{},
+		modified: map[int64]time.Time{},
+	}
+
+	c.tables = append(c.tables, t)
+
+	return t
+}
+
+func tableFor[T any](c *Client) *table[T] {
+	for _, t := range c.tables {
+		if t, ok := t.(*table[T]); ok {
+			return t
+		}
+	}
+
+	var zero T
+	panic(fmt.Sprintf("elationtest: %T is not a stored resource", zero))
+}
+
+// insert stores a copy of v like put and records the change.
+func (t *table[T]) insert(c *Client, v *T) *T {
+	row := t.put(c, v)
+	t.record(c, elation.WebhookEventActionSaved, *t.key(row), row)
+
+	return row
+}
+
+// put stores a copy of v, assigning it an ID if it does not have one, and returns a copy of the stored row.
+func (t *table[T]) put(c *Client, v *T) *T {
+	row := *v
+
+	key := t.key(&row)
+	if *key == 0 {
+		*key = c.newID()
+	} else {
+		c.nextID = max(c.nextID, *key+1)
+	}
+
+	if _, ok := t.rows[*key]; !ok {
+		t.order = append(t.order, *key)
+	}
+
+	t.rows[*key] = &row
+	t.modified[*key] = c.now()
+
+	out := row
+
+	return &out
+}
+
+func (t *table[T]) get(id int64) (*T, bool) {
+	row, ok := t.rows[id]
+	if !ok {
+		return nil, false
+	}
+
+	c := *row
+
+	return &c, true
+}
+
+func (t *table[T]) update(c *Client, id int64, fn func(*T)) (*T, bool) {
+	row, ok := t.rows[id]
+	if !ok {
+		return nil, false
+	}
+
+	fn(row)
+	t.modified[id] = c.now()
+
+	out := *row
+	t.record(c, elation.WebhookEventActionSaved, id, &out)
+
+	return &out, true
+}
+
+func (t *table[T]) delete(c *Client, id int64) bool {
+	if _, ok := t.rows[id]; !ok {
+		return false
+	}
+
+	delete(t.rows, id)
+	delete(t.modified, id)
+	t.order = slices.DeleteFunc(t.order, func(v int64) bool { return v == id })
+	t.record(c, elation.WebhookEventActionDeleted, id, nil)
+
+	return true
+}
+
+// record queues a change to be reported when the client is unlocked. Data is copied so that listeners cannot modify
+// rows returned to the caller.
+func (t *table[T]) record(c *Client, action elation.WebhookEventAction, id int64, data *T) {
+	if t.resource == "" {
+		return
+	}
+
+	change := Change{
+		Resource: t.resource,
+		Action:   action,
+		ID:       id,
+	}
+
+	if data != nil {
+		row := *data
+		change.Data = &row
+	}
+
+	c.changes = append(c.changes, change)
+}
+
+func (t *table[T]) list(match func(*T, time.Time) bool) []*T {
+	var out []*T
+
+	for _, id := range t.order {
+		row := t.rows[id]
+		if match != nil && !match(row, t.modified[id]) {
+			continue
+		}
+
+		c := *row
+		out = append(out, &c)
+	}
+
+	return out
+}
+
+func get[T any](ctx context.Context, c *Client, op, path string, t *table[T], id int64) (*T, *http.Response, error) {
+	if err := c.begin(ctx, op); err != nil {
+		return nil, nil, err
+	}
+	defer c.end()
+
+	row, ok := t.get(id)
+	if !ok {
+		err := notFound(http.MethodGet, idPath(path, id))
+		return nil, errorResponse(err), err
+	}
+
+	return row, response(http.StatusOK), nil
+}
+
+// find lists the rows of t accepted by match, which is also given the time the row was last modified.
+func find[T any](ctx context.Context, c *Client, op, path string, t *table[T], p *elation.Pagination, match func(*T, time.Time) bool) (*elation.Response[[]*T], *http.Response, error) {
+	if err := c.begin(ctx, op); err != nil {
+		return nil, nil, err
+	}
+	defer c.end()
+
+	return page(path, t.list(match), p), response(http.StatusOK), nil
+}
+
+// create stores the row returned by build, which is given the creation time. Build is called with the client locked,
+// so it may look up other rows to validate the request.
+func create[T any](ctx context.Context, c *Client, op string, t *table[T], build func(now time.Time) (*T, error)) (*T, *http.Response, error) {
+	if err := c.begin(ctx, op); err != nil {
+		return nil, nil, err
+	}
+	defer c.end()
+
+	row, err := build(c.now())
+	if err != nil {
+		return nil, errorResponse(err), err
+	}
+
+	return t.insert(c, row), response(http.StatusCreated), nil
+}
+
+func update[T any](ctx context.Context, c *Client, op, path string, t *table[T], id int64, fn func(*T)) (*T, *http.Response, error) {
+	if err := c.begin(ctx, op); err != nil {
+		return nil, nil, err
+	}
+	defer c.end()
+
+	row, ok := t.update(c, id, fn)
+	if !ok {
+		err := notFound(http.MethodPatch, idPath(path, id))
+		return nil, errorResponse(err), err
+	}
+
+	return row, response(http.StatusOK), nil
+}
+
+func remove[T any](ctx context.Context, c *Client, op, path string, t *table[T], id int64) (*http.Response, error) {
+	if err := c.begin(ctx, op); err != nil {
+		return nil, err
+	}
+	defer c.end()
+
+	if !t.delete(c, id) {
+		err := notFound(http.MethodDelete, idPath(path, id))
+		return errorResponse(err), err
+	}
+
+	return response(http.StatusNoContent), nil
+}
+
+// page slices results the way the API does, with next and previous links using limit and offset.
+func page[T any](path string, results []*T, p *elation.Pagination) *elation.Response[[]*T] {
+	limit := defaultPaginationLimit
+	offset := 0
+
+	if p != nil {
+		if p.Limit > 0 {
+			limit = p.Limit
+		}
+
+		offset = max(p.Offset, 0)
+	}
+
+	res := &elation.Response[[]*T]{
+		Count:   len(results),
+		Results: []*T{},
+	}
+
+	if offset < len(results) {
+		res.Results = results[offset:min(offset+limit, len(results))]
+	}
+
+	if offset+limit < len(results) {
+		res.Next = pageURL(path, limit, offset+limit)
+	}
+
+	if offset > 0 {
+		res.Previous = pageURL(path, limit, max(offset-limit, 0))
+	}
+
+	return res
+}
+
+func pageURL(path string, limit, offset int) string {
+	q := url.Values{}
+	q.Set("limit", strconv.Itoa(limit))
+	q.Set("offset", strconv.Itoa(offset))
+
+	return baseURL + path + "?" + q.Encode()
+}
+
+func response(statusCode int) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Status:     strconv.Itoa(statusCode) + " " + http.StatusText(statusCode),
+		Header:     http.Header{},
+		Body:       http.NoBody,
+	}
+}
+
+// errorResponse returns the response matching an API error, or nil for any other error.
+func errorResponse(err error) *http.Response {
+	var apiErr *elation.Error
+	if !errors.As(err, &apiErr) {
+		return nil
+	}
+
+	return response(apiErr.StatusCode)
+}
+
+func notFound(method, path string) error {
+	return &elation.Error{
+		StatusCode: http.StatusNotFound,
+		Body:       `{"detail":"Not found."}`,
+		Method:     method,
+		Path:       path,
+		Validation: &elation.ValidationError{
+			Detail: "Not found.",
+			Fields: map[string][]string{},
+		},
+	}
+}
+
+func badRequest(method, path, field, message string) error {
+	return &elation.Error{
+		StatusCode: http.StatusBadRequest,
+		Body:       fmt.Sprintf(`{%q:[%q]}`, field, message),
+		Method:     method,
+		Path:       path,
+		Validation: &elation.ValidationError{
+			Fields: map[string][]string{
+				field: {message},
+			},
+		},
+	}
+}
+
+// conflict is the error the real client returns for an update it refuses to send because of err.
+func conflict(method, path string, err error) error {
+	return &elation.Error{
+		StatusCode: http.StatusConflict,
+		Method:     method,
+		Path:       path,
+		Err:        err,
+	}
+}
+
+func idPath(collection string, id int64) string {
+	return collection + "/" + strconv.FormatInt(id, 10)
+}
+
+// Filter helpers. A zero-valued filter matches everything, like an omitted query parameter.
+
+func matchAny(filter []int64, v int64) bool {
+	return len(filter) == 0 || slices.Contains(filter, v)
+}
+
+func matchStringPtr(filter string, v *string) bool {
+	return filter == "" || (v != nil && *v == filter)
+}
+
+func matchID(filter int64, v int64) bool {
+	return filter == 0 || filter == v
+}
+
+func matchString(filter string, v string) bool {
+	return filter == "" || filter == v
+}
+
+func matchTime(v time.Time, gt, gte, lt, lte time.Time) bool {
+	if !gt.IsZero() && !v.After(gt) {
+		return false
+	}
+
+	if !gte.IsZero() && v.Before(gte) {
+		return false
+	}
+
+	if !lt.IsZero() && !v.Before(lt) {
+		return false
+	}
+
+	if !lte.IsZero() && v.After(lte) {
+		return false
+	}
+
+	return true
+}
+
+func matchTimePtr(v *time.Time, gt, gte, lt, lte time.Time) bool {
+	if v == nil {
+		return gt.IsZero() && gte.IsZero() && lt.IsZero() && lte.IsZero()
+	}
+
+	return matchTime(*v, gt, gte, lt, lte)
+}
+
+// matchDate compares a YYYY-MM-DD date string against date bounds.
+func matchDate(v string, gte, lte time.Time) bool {
+	if gte.IsZero() && lte.IsZero() {
+		return true
+	}
+
+	t, err := time.Parse(time.DateOnly, v)
+	if err != nil {
+		return false
+	}
+
+	return matchTime(t, time.Time{}, truncateDay(gte), time.Time{}, lte)
+}
+
+func matchIsNull(filter *bool, isNull bool) bool {
+	return filter == nil || *filter == isNull
+}
+
+func truncateDay(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func setPtr[T any](dst *T, src *T) {
+	if src != nil {
+		*dst = *src
+	}
+}
@@ -0,0 +1,230 @@
+package elationtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_pagination(t *testing.T) {
+	testCases := map[string]struct {
+		pagination       *elation.Pagination
+		expectedIDs      []int64
+		expectedNext     *elation.Pagination
+		expectedPrevious *elation.Pagination
+	}{
+		"default limit": {
+			expectedIDs:  []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25},
+			expectedNext: &elation.Pagination{Limit: 25, Offset: 25},
+		},
+		"first page": {
+			pagination:   &elation.Pagination{Limit: 10},
+			expectedIDs:  []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			expectedNext: &elation.Pagination{Limit: 10, Offset: 10},
+		},
+		"middle page": {
+			pagination:       &elation.Pagination{Limit: 10, Offset: 10},
+			expectedIDs:      []int64{11, 12, 13, 14, 15, 16, 17, 18, 19, 20},
+			expectedNext:     &elation.Pagination{Limit: 10, Offset: 20},
+			expectedPrevious: &elation.Pagination{Limit: 10, Offset: 0},
+		},
+		"last page": {
+			pagination:       &elation.Pagination{Limit: 10, Offset: 20},
+			expectedIDs:      []int64{21, 22, 23, 24, 25, 26, 27, 28, 29, 30},
+			expectedPrevious: &elation.Pagination{Limit: 10, Offset: 10},
+		},
+		"past the end": {
+			pagination:       &elation.Pagination{Limit: 10, Offset: 40},
+			expectedPrevious: &elation.Pagination{Limit: 10, Offset: 30},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			client := NewClient()
+			for range 30 {
+				Seed(client, &elation.Patient{})
+			}
+
+			res, httpRes, err := client.Patients().Find(context.Background(), &elation.FindPatientsOptions{Pagination: testCase.pagination})
+			assert.NoError(err)
+			assert.Equal(http.StatusOK, httpRes.StatusCode)
+			assert.Equal(30, res.Count)
+
+			var ids []int64
+			for _, patient := range res.Results {
+				ids = append(ids, patient.ID)
+			}
+			assert.Equal(testCase.expectedIDs, ids)
+
+			if testCase.expectedNext == nil {
+				assert.False(res.HasNext())
+			} else {
+				assert.Equal(testCase.expectedNext, res.PaginationNext())
+			}
+
+			if testCase.expectedPrevious == nil {
+				assert.False(res.HasPrevious())
+			} else {
+				assert.Equal(testCase.expectedPrevious, res.PaginationPrevious())
+			}
+		})
+	}
+}
+
+func TestClient_All(t *testing.T) {
+	assert := assert.New(t)
+
+	client := NewClient()
+	for range 12 {
+		Seed(client, &elation.Allergy{Patient: 1})
+	}
+	Seed(client, &elation.Allergy{Patient: 2})
+
+	count := 0
+	for allergy, err := range elation.All(context.Background(), client.Allergies().Find, &elation.FindAllergiesOptions{Pagination: &elation.Pagination{Limit: 5}, Patient: []int64{1}}) {
+		assert.NoError(err)
+		assert.Equal(int64(1), allergy.Patient)
+		count++
+	}
+
+	assert.Equal(12, count)
+}
+
+func TestClient_not_found(t *testing.T) {
+	assert := assert.New(t)
+
+	client := NewClient()
+
+	patient, res, err := client.Patients().Get(context.Background(), 1)
+	assert.Nil(patient)
+	assert.Equal(http.StatusNotFound, res.StatusCode)
+	assert.True(elation.IsNotFound(err))
+
+	apiErr := &elation.Error{}
+	assert.ErrorAs(err, &apiErr)
+	assert.Equal(http.MethodGet, apiErr.Method)
+	assert.Equal("/patients/1", apiErr.Path)
+
+	res, err = client.Appointments().Delete(context.Background(), 1)
+	assert.Equal(http.StatusNotFound, res.StatusCode)
+	assert.True(elation.IsNotFound(err))
+}
+
+func TestClient_Seed(t *testing.T) {
+	assert := assert.New(t)
+
+	client := NewClient()
+
+	patient := &elation.Patient{ID: 100, FirstName: "Jane"}
+	Seed(client, patient, &elation.Patient{FirstName: "John"})
+
+	// The stored patient is a copy.
+	patient.FirstName = "Foo"
+
+	patients := Items[elation.Patient](client)
+	assert.Len(patients, 2)
+	assert.Equal(int64(100), patients[0].ID)
+	assert.Equal("Jane", patients[0].FirstName)
+	assert.Equal(int64(101), patients[1].ID)
+
+	// Returned patients are copies too.
+	patients[0].FirstName = "Bar"
+
+	actual, _, err := client.Patients().Get(context.Background(), 100)
+	assert.NoError(err)
+	assert.Equal("Jane", actual.FirstName)
+
+	assert.Panics(func() {
+		Seed(client, &struct{}{})
+	})
+}
+
+func TestClient_FailNext(t *testing.T) {
+	assert := assert.New(t)
+
+	client := NewClient()
+	Seed(client, &elation.Patient{ID: 1})
+
+	fooErr := errors.New("foo")
+	client.FailNext("Patients.Get", fooErr)
+	client.FailNext("Patients.Get", &elation.Error{StatusCode: http.StatusServiceUnavailable})
+
+	_, _, err := client.Patients().Get(context.Background(), 1)
+	assert.ErrorIs(err, fooErr)
+
+	_, _, err = client.Patients().Get(context.Background(), 1)
+	assert.True(elation.IsRetryable(err))
+
+	patient, _, err := client.Patients().Get(context.Background(), 1)
+	assert.NoError(err)
+	assert.Equal(int64(1), patient.ID)
+}
+
+func TestClient_SetHook(t *testing.T) {
+	assert := assert.New(t)
+
+	client := NewClient()
+
+	var ops []string
+	client.SetHook(func(ctx context.Context, op string) error {
+		ops = append(ops, op)
+
+		switch op {
+		case "Appointments.Create":
+			return &elation.Error{StatusCode: http.StatusTooManyRequests}
+		case "Patients.Create":
+			// The hook can use the client.
+			_, _, err := client.Practices().Find(ctx, nil)
+			return err
+		default:
+			return nil
+		}
+	})
+
+	_, _, err := client.Patients().Create(context.Background(), &elation.PatientCreate{})
+	assert.NoError(err)
+
+	_, _, err = client.Appointments().Create(context.Background(), &elation.AppointmentCreate{})
+	assert.True(elation.IsRateLimited(err))
+
+	assert.Equal([]string{"Patients.Create", "Practices.Find", "Appointments.Create"}, ops)
+
+	client.SetHook(nil)
+
+	_, _, err = client.Appointments().Create(context.Background(), &elation.AppointmentCreate{})
+	assert.NoError(err)
+}
+
+func TestClient_context_canceled(t *testing.T) {
+	assert := assert.New(t)
+
+	client := NewClient()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := client.Patients().Find(ctx, nil)
+	assert.ErrorIs(err, context.Canceled)
+}
+
+func TestClient_Now(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	client := NewClient()
+	client.Now = func() time.Time { return now }
+
+	patient, res, err := client.Patients().Create(context.Background(), &elation.PatientCreate{FirstName: "Jane"})
+	assert.NoError(err)
+	assert.Equal(http.StatusCreated, res.StatusCode)
+	assert.Equal(int64(1), patient.ID)
+	assert.Equal(now, patient.CreatedDate)
+}
@@ -0,0 +1,49 @@
+package elationtest
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+)
+
+const subscriptionsPath = "/app/subscriptions/"
+
+var _ elation.SubscriptionServicer = (*SubscriptionService)(nil)
+
+type SubscriptionService struct {
+	client *Client
+}
+
+func (s *SubscriptionService) Find(ctx context.Context) ([]*elation.Subscription, *http.Response, error) {
+	if err := s.client.begin(ctx, "Subscriptions.Find"); err != nil {
+		return nil, nil, err
+	}
+	defer s.client.end()
+
+	return s.client.subscriptions.list(nil), response(http.StatusOK), nil
+}
+
+func (s *SubscriptionService) Subscribe(ctx context.Context, subscribe *elation.Subscribe) (*elation.Subscription, *http.Response, error) {
+	return create(ctx, s.client, "Subscriptions.Subscribe", s.client.subscriptions, func(now time.Time) (*elation.Subscription, error) {
+		if subscribe.Resource == "" {
+			return nil, badRequest(http.MethodPost, subscriptionsPath, "resource", "This field is required.")
+		}
+
+		if subscribe.Target == "" {
+			return nil, badRequest(http.MethodPost, subscriptionsPath, "target", "This field is required.")
+		}
+
+		return &elation.Subscription{
+			Resource:      subscribe.Resource,
+			Target:        subscribe.Target,
+			CreatedDate:   elation.SubscriptionJSONDate(now.Truncate(time.Second)),
+			SigningPubKey: s.client.signingPubKey,
+		}, nil
+	})
+}
+
+func (s *SubscriptionService) Delete(ctx context.Context, id int64) (*http.Response, error) {
+	return remove(ctx, s.client, "Subscriptions.Delete", "/app/subscriptions", s.client.subscriptions, id)
+}
@@ -0,0 +1,384 @@
+package elationtest
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+)
+
+const insurancePolicyStatusActive = "active"
+
+var _ elation.InsuranceCompanyServicer = (*InsuranceCompanyService)(nil)
+
+type InsuranceCompanyService struct {
+	client *Client
+}
+
+func (s *InsuranceCompanyService) Create(ctx context.Context, companyCreate *elation.InsuranceCompanyCreate) (*elation.InsuranceCompany, *http.Response, error) {
+	return create(ctx, s.client, "InsuranceCompanies.Create", s.client.insuranceCompanies, func(now time.Time) (*elation.InsuranceCompany, error) {
+		company := &elation.InsuranceCompany{
+			Practice:           companyCreate.Practice,
+			Carrier:            companyCreate.Carrier,
+			Address:            companyCreate.Address,
+			Suite:              companyCreate.Suite,
+			City:               companyCreate.City,
+			State:              companyCreate.State,
+			Zip:                companyCreate.Zip,
+			Phone:              companyCreate.Phone,
+			Extension:          companyCreate.Extension,
+			CreatedDate:        now.Format(time.RFC3339),
+			PayerID:            companyCreate.PayerID,
+			ExternalVendorID:   companyCreate.ExternalVendorID,
+			Aliases:            companyCreate.Aliases,
+			InsuranceType:      companyCreate.InsuranceType,
+			EligibilityPayerID: companyCreate.EligibilityPayerID,
+		}
+
+		setPtr(&company.IsCredentialed, companyCreate.IsCredentialed)
+
+		return company, nil
+	})
+}
+
+func (s *InsuranceCompanyService) Find(ctx context.Context, opts *elation.FindInsuranceCompaniesOptions) (*elation.Response[[]*elation.InsuranceCompany], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindInsuranceCompaniesOptions{}
+	}
+
+	return find(ctx, s.client, "InsuranceCompanies.Find", "/insurance_companies", s.client.insuranceCompanies, opts.Pagination, func(company *elation.InsuranceCompany, _ time.Time) bool {
+		return matchAny(opts.Practice, company.Practice) &&
+			matchString(opts.Carrier, company.Carrier)
+	})
+}
+
+func (s *InsuranceCompanyService) Get(ctx context.Context, id int64) (*elation.InsuranceCompany, *http.Response, error) {
+	return get(ctx, s.client, "InsuranceCompanies.Get", "/insurance_companies", s.client.insuranceCompanies, id)
+}
+
+func (s *InsuranceCompanyService) Update(ctx context.Context, id int64, companyUpdate *elation.InsuranceCompanyUpdate) (*elation.InsuranceCompany, *http.Response, error) {
+	return update(ctx, s.client, "InsuranceCompanies.Update", "/insurance_companies", s.client.insuranceCompanies, id, func(company *elation.InsuranceCompany) {
+		company.Carrier = companyUpdate.Carrier
+		company.Address = companyUpdate.Address
+		company.Suite = companyUpdate.Suite
+		company.City = companyUpdate.City
+		company.State = companyUpdate.State
+		company.Zip = companyUpdate.Zip
+		company.Phone = companyUpdate.Phone
+		company.Extension = companyUpdate.Extension
+		company.PayerID = companyUpdate.PayerID
+		company.ExternalVendorID = companyUpdate.ExternalVendorID
+		company.Aliases = companyUpdate.Aliases
+		company.InsuranceType = companyUpdate.InsuranceType
+		company.EligibilityPayerID = companyUpdate.EligibilityPayerID
+
+		setPtr(&company.IsCredentialed, companyUpdate.IsCredentialed)
+	})
+}
+
+func (s *InsuranceCompanyService) Delete(ctx context.Context, id int64) (*http.Response, error) {
+	return remove(ctx, s.client, "InsuranceCompanies.Delete", "/insurance_companies", s.client.insuranceCompanies, id)
+}
+
+var _ elation.InsurancePlanServicer = (*InsurancePlanService)(nil)
+
+type InsurancePlanService struct {
+	client *Client
+}
+
+func (s *InsurancePlanService) Create(ctx context.Context, planCreate *elation.InsurancePlanCreate) (*elation.InsurancePlan, *http.Response, error) {
+	return create(ctx, s.client, "InsurancePlans.Create", s.client.insurancePlans, func(now time.Time) (*elation.InsurancePlan, error) {
+		if _, ok := s.client.insuranceCompanies.get(planCreate.InsuranceCompany); !ok {
+			return nil, badRequest(http.MethodPost, "/insurance_plans", "insurance_company", "Invalid pk - object does not exist.")
+		}
+
+		return &elation.InsurancePlan{
+			Practice:         planCreate.Practice,
+			InsuranceCompany: planCreate.InsuranceCompany,
+			Name:             planCreate.Name,
+			CreatedDate:      now.Format(time.RFC3339),
+		}, nil
+	})
+}
+
+func (s *InsurancePlanService) Find(ctx context.Context, opts *elation.FindInsurancePlansOptions) (*elation.Response[[]*elation.InsurancePlan], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindInsurancePlansOptions{}
+	}
+
+	return find(ctx, s.client, "InsurancePlans.Find", "/insurance_plans", s.client.insurancePlans, opts.Pagination, func(plan *elation.InsurancePlan, _ time.Time) bool {
+		return matchAny(opts.Practice, plan.Practice) &&
+			matchAny(opts.InsuranceCompany, plan.InsuranceCompany)
+	})
+}
+
+func (s *InsurancePlanService) Get(ctx context.Context, id int64) (*elation.InsurancePlan, *http.Response, error) {
+	return get(ctx, s.client, "InsurancePlans.Get", "/insurance_plans", s.client.insurancePlans, id)
+}
+
+func (s *InsurancePlanService) Update(ctx context.Context, id int64, planUpdate *elation.InsurancePlanUpdate) (*elation.InsurancePlan, *http.Response, error) {
+	return update(ctx, s.client, "InsurancePlans.Update", "/insurance_plans", s.client.insurancePlans, id, func(plan *elation.InsurancePlan) {
+		plan.Name = planUpdate.Name
+	})
+}
+
+func (s *InsurancePlanService) Delete(ctx context.Context, id int64) (*http.Response, error) {
+	return remove(ctx, s.client, "InsurancePlans.Delete", "/insurance_plans", s.client.insurancePlans, id)
+}
+
+var _ elation.InsurancePolicyServicer = (*InsurancePolicyService)(nil)
+
+type InsurancePolicyService struct {
+	client *Client
+}
+
+func policiesPath(patientID int64) string {
+	return idPath("/patients", patientID) + "/policies"
+}
+
+func (s *InsurancePolicyService) Create(ctx context.Context, patientID int64, policyCreate *elation.InsurancePolicyCreate) (*elation.InsurancePolicy, *http.Response, error) {
+	return create(ctx, s.client, "InsurancePolicies.Create", s.client.insurancePolicies, func(_ time.Time) (*elation.InsurancePolicy, error) {
+		if _, ok := s.client.patients.get(patientID); !ok {
+			return nil, notFound(http.MethodPost, policiesPath(patientID))
+		}
+
+		return &elation.InsurancePolicy{
+			PracticeID:              policyCreate.PracticeID,
+			PatientID:               patientID,
+			PatientFirstName:        policyCreate.PatientFirstName,
+			PatientLastName:         policyCreate.PatientLastName,
+			PatientDOB:              policyCreate.PatientDOB,
+			Status:                  policyCreate.Status,
+			Rank:                    policyCreate.Rank,
+			CarrierID:               policyCreate.CarrierID,
+			CarrierName:             policyCreate.CarrierName,
+			PlanID:                  policyCreate.PlanID,
+			PlanName:                policyCreate.PlanName,
+			GroupID:                 policyCreate.GroupID,
+			MemberID:                policyCreate.MemberID,
+			Copay:                   policyCreate.Copay,
+			Deductible:              policyCreate.Deductible,
+			StartDate:               policyCreate.StartDate,
+			EndDate:                 policyCreate.EndDate,
+			Phone:                   policyCreate.Phone,
+			Extension:               policyCreate.Extension,
+			Address:                 policyCreate.Address,
+			Suite:                   policyCreate.Suite,
+			City:                    policyCreate.City,
+			State:                   policyCreate.State,
+			Zip:                     policyCreate.Zip,
+			CountyCode:              policyCreate.CountyCode,
+			InsuredPersonFirstName:  policyCreate.InsuredPersonFirstName,
+			InsuredPersonLastName:   policyCreate.InsuredPersonLastName,
+			InsuredPersonAddress:    policyCreate.InsuredPersonAddress,
+			InsuredPersonCity:       policyCreate.InsuredPersonCity,
+			InsuredPersonState:      policyCreate.InsuredPersonState,
+			InsuredPersonZip:        policyCreate.InsuredPersonZip,
+			InsuredPersonID:         policyCreate.InsuredPersonID,
+			InsuredPersonDOB:        policyCreate.InsuredPersonDOB,
+			InsuredPersonSexAtBirth: policyCreate.InsuredPersonSexAtBirth,
+			InsuredPersonSSN:        policyCreate.InsuredPersonSSN,
+			RelationshipToInsured:   policyCreate.RelationshipToInsured,
+			PaymentProgram:          policyCreate.PaymentProgram,
+			MedicareSecondaryCode:   policyCreate.MedicareSecondaryCode,
+		}, nil
+	})
+}
+
+func (s *InsurancePolicyService) Find(ctx context.Context, patientID int64, opts *elation.FindInsurancePoliciesOptions) (*elation.FindInsurancePoliciesResponse, *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindInsurancePoliciesOptions{}
+	}
+
+	return find(ctx, s.client, "InsurancePolicies.Find", policiesPath(patientID), s.client.insurancePolicies, opts.Pagination, func(policy *elation.InsurancePolicy, _ time.Time) bool {
+		return policy.PatientID == patientID &&
+			(opts.ActiveOnly == nil || !*opts.ActiveOnly || policy.Status == insurancePolicyStatusActive)
+	})
+}
+
+func (s *InsurancePolicyService) Get(ctx context.Context, patientID int64, id int64) (*elation.InsurancePolicy, *http.Response, error) {
+	if err := s.client.begin(ctx, "InsurancePolicies.Get"); err != nil {
+		return nil, nil, err
+	}
+	defer s.client.end()
+
+	policy, ok := s.client.insurancePolicies.get(id)
+	if !ok || policy.PatientID != patientID {
+		err := notFound(http.MethodGet, idPath(policiesPath(patientID), id))
+		return nil, errorResponse(err), err
+	}
+
+	return policy, response(http.StatusOK), nil
+}
+
+func (s *InsurancePolicyService) Update(ctx context.Context, patientID int64, id int64, policyUpdate *elation.InsurancePolicyUpdate) (*elation.InsurancePolicy, *http.Response, error) {
+	if err := s.client.begin(ctx, "InsurancePolicies.Update"); err != nil {
+		return nil, nil, err
+	}
+	defer s.client.end()
+
+	if policy, ok := s.client.insurancePolicies.get(id); !ok || policy.PatientID != patientID {
+		err := notFound(http.MethodPut, idPath(policiesPath(patientID), id))
+		return nil, errorResponse(err), err
+	}
+
+	// Updates are made with PUT, so every field is replaced.
+	policy, _ := s.client.insurancePolicies.update(s.client, id, func(policy *elation.InsurancePolicy) {
+		*policy = elation.InsurancePolicy{
+			ID:                      id,
+			PracticeID:              policyUpdate.PracticeID,
+			PatientID:               patientID,
+			PatientFirstName:        policyUpdate.PatientFirstName,
+			PatientLastName:         policyUpdate.PatientLastName,
+			PatientDOB:              policyUpdate.PatientDOB,
+			Status:                  policyUpdate.Status,
+			Rank:                    policyUpdate.Rank,
+			CarrierID:               policyUpdate.CarrierID,
+			CarrierName:             policyUpdate.CarrierName,
+			PlanID:                  policyUpdate.PlanID,
+			PlanName:                policyUpdate.PlanName,
+			GroupID:                 policyUpdate.GroupID,
+			MemberID:                policyUpdate.MemberID,
+			Copay:                   policyUpdate.Copay,
+			Deductible:              policyUpdate.Deductible,
+			StartDate:               policyUpdate.StartDate,
+			EndDate:                 policyUpdate.EndDate,
+			Phone:                   policyUpdate.Phone,
+			Extension:               policyUpdate.Extension,
+			Address:                 policyUpdate.Address,
+			Suite:                   policyUpdate.Suite,
+			City:                    policyUpdate.City,
+			State:                   policyUpdate.State,
+			Zip:                     policyUpdate.Zip,
+			CountyCode:              policyUpdate.CountyCode,
+			InsuredPersonFirstName:  policyUpdate.InsuredPersonFirstName,
+			InsuredPersonLastName:   policyUpdate.InsuredPersonLastName,
+			InsuredPersonAddress:    policyUpdate.InsuredPersonAddress,
+			InsuredPersonCity:       policyUpdate.InsuredPersonCity,
+			InsuredPersonState:      policyUpdate.InsuredPersonState,
+			InsuredPersonZip:        policyUpdate.InsuredPersonZip,
+			InsuredPersonID:         policyUpdate.InsuredPersonID,
+			InsuredPersonDOB:        policyUpdate.InsuredPersonDOB,
+			InsuredPersonSexAtBirth: policyUpdate.InsuredPersonSexAtBirth,
+			InsuredPersonSSN:        policyUpdate.InsuredPersonSSN,
+			RelationshipToInsured:   policyUpdate.RelationshipToInsured,
+			PaymentProgram:          policyUpdate.PaymentProgram,
+			MedicareSecondaryCode:   policyUpdate.MedicareSecondaryCode,
+			FrontImagePath:          policy.FrontImagePath,
+			BackImagePath:           policy.BackImagePath,
+			CanCheckEligibility:     policy.CanCheckEligibility,
+			IsLegacyInsurance:       policy.IsLegacyInsurance,
+		}
+	})
+
+	return policy, response(http.StatusOK), nil
+}
+
+func (s *InsurancePolicyService) Delete(ctx context.Context, patientID int64, id int64) (*http.Response, error) {
+	if err := s.client.begin(ctx, "InsurancePolicies.Delete"); err != nil {
+		return nil, err
+	}
+	defer s.client.end()
+
+	if policy, ok := s.client.insurancePolicies.get(id); !ok || policy.PatientID != patientID {
+		err := notFound(http.MethodDelete, idPath(policiesPath(patientID), id))
+		return errorResponse(err), err
+	}
+
+	s.client.insurancePolicies.delete(s.client, id)
+
+	return response(http.StatusNoContent), nil
+}
+
+var _ elation.InsuranceEligibilityServicer = (*InsuranceEligibilityService)(nil)
+
+type InsuranceEligibilityService struct {
+	client *Client
+}
+
+func eligibilityPath(patientInsuranceID int64, report string) string {
+	return "/patient_insurances/" + strconv.FormatInt(patientInsuranceID, 10) + "/" + report + "/"
+}
+
+// Create runs an eligibility check. A seeded eligibility for the insurance is returned with a new check timestamp;
+// otherwise the insurance must exist as a patient insurance or an insurance policy and is reported as active.
+func (s *InsuranceEligibilityService) Create(ctx context.Context, patientInsuranceID int64, _ *elation.InsuranceEligibilityCreate) (*elation.InsuranceEligibility, *http.Response, error) {
+	if err := s.client.begin(ctx, "InsuranceEligibility.Create"); err != nil {
+		return nil, nil, err
+	}
+	defer s.client.end()
+
+	now := s.client.now()
+
+	if eligibility, ok := s.client.insuranceEligibility.update(s.client, patientInsuranceID, func(eligibility *elation.InsuranceEligibility) {
+		eligibility.EligibilityCheckTimestamp = now
+	}); ok {
+		return eligibility, response(http.StatusCreated), nil
+	}
+
+	eligibility := &elation.InsuranceEligibility{
+		EligibilityCheckTimestamp: now,
+		EligibilityStatus:         "Active",
+		PatientInsuranceID:        patientInsuranceID,
+	}
+
+	if policy, ok := s.client.insurancePolicies.get(patientInsuranceID); ok {
+		eligibility.PatientID = policy.PatientID
+		eligibility.PracticeID = policy.PracticeID
+	} else if patient := s.client.patientWithInsurance(patientInsuranceID); patient != nil {
+		eligibility.PatientID = patient.ID
+		eligibility.PracticeID = patient.CaregiverPractice
+	} else {
+		err := notFound(http.MethodPost, eligibilityPath(patientInsuranceID, "eligibility"))
+		return nil, errorResponse(err), err
+	}
+
+	return s.client.insuranceEligibility.insert(s.client, eligibility), response(http.StatusCreated), nil
+}
+
+func (c *Client) patientWithInsurance(patientInsuranceID int64) *elation.Patient {
+	patients := c.patients.list(func(patient *elation.Patient, _ time.Time) bool {
+		return slices.ContainsFunc(patient.Insurances, func(insurance *elation.PatientInsurance) bool {
+			return insurance.ID == patientInsuranceID
+		})
+	})
+
+	if len(patients) == 0 {
+		return nil
+	}
+
+	return patients[0]
+}
+
+func (s *InsuranceEligibilityService) Get(ctx context.Context, patientInsuranceID int64) (*elation.InsuranceEligibility, *http.Response, error) {
+	if err := s.client.begin(ctx, "InsuranceEligibility.Get"); err != nil {
+		return nil, nil, err
+	}
+	defer s.client.end()
+
+	eligibility, ok := s.client.insuranceEligibility.get(patientInsuranceID)
+	if !ok {
+		err := notFound(http.MethodGet, eligibilityPath(patientInsuranceID, "eligibility"))
+		return nil, errorResponse(err), err
+	}
+
+	return eligibility, response(http.StatusOK), nil
+}
+
+func (s *InsuranceEligibilityService) GetFullReport(ctx context.Context, patientInsuranceID int64) (*elation.InsuranceEligibilityFullReport, *http.Response, error) {
+	if err := s.client.begin(ctx, "InsuranceEligibility.GetFullReport"); err != nil {
+		return nil, nil, err
+	}
+	defer s.client.end()
+
+	report, ok := s.client.insuranceEligibilityFull.get(patientInsuranceID)
+	if !ok {
+		err := notFound(http.MethodGet, eligibilityPath(patientInsuranceID, "eligibility_full_report"))
+		return nil, errorResponse(err), err
+	}
+
+	return report, response(http.StatusOK), nil
+}
@@ -0,0 +1,62 @@
+package elationtest
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+)
+
+var _ elation.MessageThreadServicer = (*MessageThreadService)(nil)
+
+type MessageThreadService struct {
+	client *Client
+}
+
+func (s *MessageThreadService) Find(ctx context.Context, opts *elation.FindMessageThreadsOptions) (*elation.Response[[]*elation.MessageThread], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindMessageThreadsOptions{}
+	}
+
+	return find(ctx, s.client, "MessageThreads.Find", "/message_threads", s.client.messageThreads, opts.Pagination, func(thread *elation.MessageThread, _ time.Time) bool {
+		return matchAny(opts.Patient, thread.Patient) &&
+			matchAny(opts.Practice, thread.Practice) &&
+			matchTime(thread.DocumentDate, opts.DocumentDateGT, opts.DocumentDateGTE, opts.DocumentDateLT, opts.DocumentDateLTE)
+	})
+}
+
+func (s *MessageThreadService) Get(ctx context.Context, id int64) (*elation.MessageThread, *http.Response, error) {
+	return get(ctx, s.client, "MessageThreads.Get", "/message_threads", s.client.messageThreads, id)
+}
+
+var _ elation.ThreadMemberServicer = (*ThreadMemberService)(nil)
+
+type ThreadMemberService struct {
+	client *Client
+}
+
+// Find filters thread members by patient and practice through the message thread they belong to.
+func (s *ThreadMemberService) Find(ctx context.Context, opts *elation.FindThreadMembersOptions) (*elation.Response[[]*elation.ThreadMember], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindThreadMembersOptions{}
+	}
+
+	return find(ctx, s.client, "ThreadMembers.Find", "/thread_members", s.client.threadMembers, opts.Pagination, func(member *elation.ThreadMember, _ time.Time) bool {
+		thread, ok := s.client.messageThreads.get(member.Thread)
+		if !ok {
+			thread = &elation.MessageThread{}
+		}
+
+		return matchAny(opts.Patient, thread.Patient) &&
+			matchAny(opts.Practice, thread.Practice) &&
+			(len(opts.User) == 0 || member.User != nil && matchAny(opts.User, *member.User)) &&
+			(len(opts.Group) == 0 || member.Group != nil && matchAny(opts.Group, *member.Group)) &&
+			matchString(opts.Status, member.Status) &&
+			(opts.AckTime.IsZero() || member.AckTime != nil && member.AckTime.Equal(opts.AckTime))
+	})
+}
+
+func (s *ThreadMemberService) Get(ctx context.Context, id int64) (*elation.ThreadMember, *http.Response, error) {
+	return get(ctx, s.client, "ThreadMembers.Get", "/thread_members", s.client.threadMembers, id)
+}
@@ -0,0 +1,377 @@
+package elationtest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/authorhealth/go-elation"
+)
+
+var _ elation.MedicationServicer = (*MedicationService)(nil)
+
+type MedicationService struct {
+	client *Client
+}
+
+func (s *MedicationService) Create(ctx context.Context, medicationCreate *elation.PatientMedicationCreate) (*elation.PatientMedication, *http.Response, error) {
+	return create(ctx, s.client, "Medications.Create", s.client.medications, func(now time.Time) (*elation.PatientMedication, error) {
+		medication := &elation.PatientMedication{
+			Patient:              medicationCreate.Patient,
+			Practice:             medicationCreate.Practice,
+			OrderType:            medicationCreate.OrderType,
+			Qty:                  medicationCreate.Qty,
+			QtyUnits:             medicationCreate.QtyUnits,
+			AuthRefills:          medicationCreate.AuthRefills,
+			PrescribingPhysician: medicationCreate.PrescribingPhysician,
+			MedicationType:       medicationCreate.MedicationType,
+			Notes:                medicationCreate.Notes,
+			StartDate:            medicationCreate.StartDate,
+			IsDocMed:             medicationCreate.IsDocMed,
+			DocumentingPersonnel: medicationCreate.DocumentingPersonnel,
+			PharmacyInstructions: medicationCreate.PharmacyInstructions,
+			Directions:           medicationCreate.Directions,
+			DocumentDate:         now,
+			ChartDate:            now,
+			CreatedDate:          now,
+			LastModified:         now.Format(time.RFC3339),
+		}
+
+		if medicationCreate.NumSamples != "" {
+			medication.NumSamples = medicationCreate.NumSamples
+		}
+
+		if medicationCreate.DocumentDate != nil {
+			medication.DocumentDate = *medicationCreate.DocumentDate
+		}
+
+		if medicationCreate.Medication != nil {
+			medication.Medication = &elation.Medication{
+				ID:         medicationCreate.Medication.ID,
+				RxnormCuis: medicationCreate.Medication.RxnormCuis,
+			}
+		}
+
+		if medicationCreate.Thread != nil {
+			medication.Thread = &elation.PatientMedicationThread{
+				ID:          medicationCreate.Thread.ID,
+				IsPermanent: medicationCreate.Thread.IsPermanent,
+			}
+		}
+
+		for _, code := range medicationCreate.Icd10Codes {
+			medication.Icd10Codes = append(medication.Icd10Codes, &elation.PatientMedicationICD10Code{Code: code.Code})
+		}
+
+		return medication, nil
+	})
+}
+
+func (s *MedicationService) Find(ctx context.Context, opts *elation.FindPatientMedicationsOptions) (*elation.Response[[]*elation.PatientMedication], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindPatientMedicationsOptions{}
+	}
+
+	return find(ctx, s.client, "Medications.Find", "/medications", s.client.medications, opts.Pagination, func(medication *elation.PatientMedication, _ time.Time) bool {
+		thread := medication.Thread
+		if thread == nil {
+			thread = &elation.PatientMedicationThread{}
+		}
+
+		return matchID(opts.Patient, medication.Patient) &&
+			matchID(opts.Practice, medication.Practice) &&
+			matchID(opts.Thread, int64(thread.ID)) &&
+			(!opts.Discontinued || thread.DcDate != "") &&
+			(!opts.Permanent || thread.IsPermanent) &&
+			matchTime(medication.DocumentDate, opts.DocumentDateGT, opts.DocumentDateGTE, opts.DocumentDateLT, opts.DocumentDateLTE)
+	})
+}
+
+func (s *MedicationService) Get(ctx context.Context, id int64) (*elation.PatientMedication, *http.Response, error) {
+	return get(ctx, s.client, "Medications.Get", "/medications", s.client.medications, id)
+}
+
+var _ elation.DiscontinuedMedicationServicer = (*DiscontinuedMedicationService)(nil)
+
+type DiscontinuedMedicationService struct {
+	client *Client
+}
+
+func (s *DiscontinuedMedicationService) Create(ctx context.Context, discontinuedCreate *elation.DiscontinuedMedicationCreate) (*elation.DiscontinuedMedication, *http.Response, error) {
+	return create(ctx, s.client, "DiscontinuedMedications.Create", s.client.discontinuedMedications, func(now time.Time) (*elation.DiscontinuedMedication, error) {
+		medication, ok := s.client.medications.get(discontinuedCreate.MedOrder)
+		if !ok {
+			return nil, badRequest(http.MethodPost, "/discontinued_medications", "med_order", "Invalid pk - object does not exist.")
+		}
+
+		discontinued := &elation.DiscontinuedMedication{
+			LastMedicationOrder:  medication.ID,
+			MedOrder:             medication.ID,
+			DiscontinueDate:      discontinuedCreate.DiscontinueDate,
+			Reason:               discontinuedCreate.Reason,
+			PrescribingPhysician: int64(medication.PrescribingPhysician),
+			DocumentingPersonnel: discontinuedCreate.DocumentingPersonnel,
+			DocumentDate:         now,
+			ChartDate:            now,
+			CreatedDate:          now,
+			Patient:              medication.Patient,
+			Practice:             medication.Practice,
+		}
+
+		if discontinued.DiscontinueDate == "" {
+			discontinued.DiscontinueDate = now.Format(time.DateOnly)
+		}
+
+		if discontinuedCreate.IsDocumented != nil {
+			discontinued.IsDocumented = *discontinuedCreate.IsDocumented
+		}
+
+		if medication.Thread != nil {
+			discontinued.Thread = int64(medication.Thread.ID)
+		}
+
+		if medication.Medication != nil {
+			discontinued.Medication = &elation.DiscontinuedMedicationMedication{
+				ID:          medication.Medication.ID,
+				RxnormCuis:  medication.Medication.RxnormCuis,
+				Name:        medication.Medication.Name,
+				BrandName:   medication.Medication.BrandName,
+				GenericName: medication.Medication.GenericName,
+				Route:       medication.Medication.Route,
+				Strength:    medication.Medication.Strength,
+				Form:        medication.Medication.Form,
+			}
+		}
+
+		return discontinued, nil
+	})
+}
+
+func (s *DiscontinuedMedicationService) Find(ctx context.Context, opts *elation.FindDiscontinuedMedicationsOptions) (*elation.Response[[]*elation.DiscontinuedMedication], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindDiscontinuedMedicationsOptions{}
+	}
+
+	return find(ctx, s.client, "DiscontinuedMedications.Find", "/discontinued_medications", s.client.discontinuedMedications, opts.Pagination, func(discontinued *elation.DiscontinuedMedication, _ time.Time) bool {
+		return matchAny(opts.Patient, discontinued.Patient) &&
+			matchAny(opts.Practice, discontinued.Practice) &&
+			matchTime(discontinued.DocumentDate, time.Time{}, opts.DocumentDateGTE, time.Time{}, opts.DocumentDateLTE) &&
+			matchDate(discontinued.DiscontinueDate, opts.DiscontinueDateGTE, opts.DiscontinueDateLTE)
+	})
+}
+
+func (s *DiscontinuedMedicationService) Get(ctx context.Context, id int64) (*elation.DiscontinuedMedication, *http.Response, error) {
+	return get(ctx, s.client, "DiscontinuedMedications.Get", "/discontinued_medications", s.client.discontinuedMedications, id)
+}
+
+var _ elation.PrescriptionFillServicer = (*PrescriptionFillService)(nil)
+
+type PrescriptionFillService struct {
+	client *Client
+}
+
+func (s *PrescriptionFillService) Find(ctx context.Context, opts *elation.FindPrescriptionFillsOptions) (*elation.Response[[]*elation.PrescriptionFill], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindPrescriptionFillsOptions{}
+	}
+
+	return find(ctx, s.client, "PrescriptionFills.Find", "/prescription_fills", s.client.prescriptionFills, opts.Pagination, func(fill *elation.PrescriptionFill, _ time.Time) bool {
+		return matchAny(opts.Practice, fill.Practice) &&
+			matchAny(opts.Patient, fill.Patient) &&
+			matchString(opts.FillStatus, fill.FillStatus) &&
+			matchIsNull(opts.FillDateIsNull, fill.FillDate == nil) &&
+			matchCivilDate(fill.FillDate, opts.FillDateGTE, opts.FillDateLTE)
+	})
+}
+
+func (s *PrescriptionFillService) Get(ctx context.Context, id int64) (*elation.PrescriptionFill, *http.Response, error) {
+	return get(ctx, s.client, "PrescriptionFills.Get", "/prescription_fills", s.client.prescriptionFills, id)
+}
+
+func matchCivilDate(v *civil.Date, gte, lte time.Time) bool {
+	if v == nil {
+		return gte.IsZero() && lte.IsZero()
+	}
+
+	return matchDate(v.String(), gte, lte)
+}
+
+var _ elation.HistoryDownloadFillServicer = (*HistoryDownloadFillService)(nil)
+
+type HistoryDownloadFillService struct {
+	client *Client
+}
+
+func (s *HistoryDownloadFillService) Find(ctx context.Context, opts *elation.FindHistoryDownloadFillsOptions) (*elation.Response[[]*elation.HistoryDownloadFill], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindHistoryDownloadFillsOptions{}
+	}
+
+	return find(ctx, s.client, "HistoryDownloadFills.Find", "/medication_history_download_fills", s.client.historyDownloadFills, opts.Pagination, func(fill *elation.HistoryDownloadFill, _ time.Time) bool {
+		return matchAny(opts.Practice, fill.Practice) &&
+			matchAny(opts.Patient, fill.Patient) &&
+			(opts.HistoryDownload == "" || opts.HistoryDownload == strconv.FormatInt(fill.HistoryDownload, 10)) &&
+			matchIsNull(opts.LastFillDateIsNull, fill.LastFillDate.IsZero()) &&
+			matchIsNull(opts.WrittenDateIsNull, fill.WrittenDate == nil) &&
+			matchTime(fill.LastFillDate, time.Time{}, truncateDay(opts.LastFillDateGTE), time.Time{}, opts.LastFillDateLTE) &&
+			matchTimePtr(fill.WrittenDate, time.Time{}, truncateDay(opts.WrittenDateGTE), time.Time{}, opts.WrittenDateLTE)
+	})
+}
+
+func (s *HistoryDownloadFillService) Get(ctx context.Context, id int64) (*elation.HistoryDownloadFill, *http.Response, error) {
+	return get(ctx, s.client, "HistoryDownloadFills.Get", "/medication_history_download_fills", s.client.historyDownloadFills, id)
+}
+
+var _ elation.ClinicalDocumentServicer = (*ClinicalDocumentService)(nil)
+
+type ClinicalDocumentService struct {
+	client *Client
+}
+
+func (s *ClinicalDocumentService) Find(ctx context.Context, opts *elation.FindClinicalDocumentsOptions) (*elation.Response[[]*elation.ClinicalDocument], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindClinicalDocumentsOptions{}
+	}
+
+	return find(ctx, s.client, "ClinicalDocuments.Find", "/clinical_documents", s.client.clinicalDocuments, opts.Pagination, func(document *elation.ClinicalDocument, _ time.Time) bool {
+		return matchID(opts.Patient, document.Patient)
+	})
+}
+
+func (s *ClinicalDocumentService) Get(ctx context.Context, id int64) (*elation.ClinicalDocument, *http.Response, error) {
+	return get(ctx, s.client, "ClinicalDocuments.Get", "/clinical_documents", s.client.clinicalDocuments, id)
+}
+
+var _ elation.VisitNoteServicer = (*VisitNoteService)(nil)
+
+type VisitNoteService struct {
+	client *Client
+}
+
+func (s *VisitNoteService) Create(ctx context.Context, visitNoteCreate *elation.VisitNoteCreate) (*elation.VisitNote, *http.Response, error) {
+	return create(ctx, s.client, "VisitNote.Create", s.client.visitNotes, func(now time.Time) (*elation.VisitNote, error) {
+		visitNote := &elation.VisitNote{
+			Bullets:      visitNoteCreate.Bullets,
+			Signatures:   visitNoteCreate.Signatures,
+			Type:         visitNoteCreate.Type,
+			Template:     visitNoteCreate.Template,
+			Patient:      visitNoteCreate.Patient,
+			Physician:    visitNoteCreate.Physician,
+			DocumentDate: visitNoteCreate.DocumentDate,
+			ChartDate:    visitNoteCreate.ChartDate,
+			SignedBy:     visitNoteCreate.SignedBy,
+			CreatedDate:  now,
+			LastModified: now,
+			Confidential: visitNoteCreate.Confidential,
+		}
+
+		if visitNoteCreate.SignedDate != nil {
+			visitNote.SignedDate = *visitNoteCreate.SignedDate
+		}
+
+		if physician, ok := s.client.physicians.get(visitNoteCreate.Physician); ok {
+			visitNote.Practice = int64(physician.Practice)
+		}
+
+		return visitNote, nil
+	})
+}
+
+func (s *VisitNoteService) Delete(ctx context.Context, id int64) (*http.Response, error) {
+	return remove(ctx, s.client, "VisitNote.Delete", "/visit_notes", s.client.visitNotes, id)
+}
+
+func (s *VisitNoteService) Find(ctx context.Context, opts *elation.FindVisitNotesOptions) (*elation.Response[[]*elation.VisitNote], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindVisitNotesOptions{}
+	}
+
+	return find(ctx, s.client, "VisitNote.Find", "/visit_notes", s.client.visitNotes, opts.Pagination, func(visitNote *elation.VisitNote, modified time.Time) bool {
+		signed := !visitNote.SignedDate.IsZero()
+
+		return matchID(opts.Patient, visitNote.Patient) &&
+			matchID(opts.Physician, visitNote.Physician) &&
+			matchID(opts.Practice, visitNote.Practice) &&
+			matchTime(modified, opts.LastModifiedGT, opts.LastModifiedGTE, opts.LastModifiedLT, opts.LastModifiedLTE) &&
+			(!opts.Unsigned || !signed) &&
+			(opts.FromSignedDate.IsZero() && opts.ToSignedDate.IsZero() ||
+				signed && matchTime(visitNote.SignedDate, time.Time{}, opts.FromSignedDate, time.Time{}, opts.ToSignedDate))
+	})
+}
+
+func (s *VisitNoteService) Get(ctx context.Context, id int64) (*elation.VisitNote, *http.Response, error) {
+	return get(ctx, s.client, "VisitNote.Get", "/visit_notes", s.client.visitNotes, id)
+}
+
+var _ elation.NonVisitNoteServicer = (*NonVisitNoteService)(nil)
+
+type NonVisitNoteService struct {
+	client *Client
+}
+
+func (s *NonVisitNoteService) Create(ctx context.Context, nonVisitNoteCreate *elation.NonVisitNoteCreate) (*elation.NonVisitNote, *http.Response, error) {
+	return create(ctx, s.client, "NonVisitNotes.Create", s.client.nonVisitNotes, func(now time.Time) (*elation.NonVisitNote, error) {
+		nonVisitNote := &elation.NonVisitNote{
+			Bullets:      nonVisitNoteCreate.Bullets,
+			ChartDate:    nonVisitNoteCreate.ChartDate,
+			CreatedDate:  now,
+			DocumentDate: nonVisitNoteCreate.DocumentDate,
+			Patient:      nonVisitNoteCreate.Patient,
+			SignedBy:     nonVisitNoteCreate.SignedBy,
+			SignedDate:   nonVisitNoteCreate.SignedDate,
+			Tags:         nonVisitNoteCreate.Tags,
+			Type:         nonVisitNoteCreate.Type,
+		}
+
+		if nonVisitNote.Type == "" {
+			nonVisitNote.Type = "nonvisit"
+		}
+
+		return nonVisitNote, nil
+	})
+}
+
+func (s *NonVisitNoteService) Find(ctx context.Context, opts *elation.FindNonVisitNotesOptions) (*elation.Response[[]*elation.NonVisitNote], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindNonVisitNotesOptions{}
+	}
+
+	return find(ctx, s.client, "NonVisitNotes.Find", "/non_visit_notes", s.client.nonVisitNotes, opts.Pagination, func(nonVisitNote *elation.NonVisitNote, _ time.Time) bool {
+		return matchID(opts.Patient, nonVisitNote.Patient)
+	})
+}
+
+func (s *NonVisitNoteService) Get(ctx context.Context, id int64) (*elation.NonVisitNote, *http.Response, error) {
+	return get(ctx, s.client, "NonVisitNotes.Get", "/non_visit_notes", s.client.nonVisitNotes, id)
+}
+
+var _ elation.LetterServicer = (*LetterService)(nil)
+
+type LetterService struct {
+	client *Client
+}
+
+func (s *LetterService) Find(ctx context.Context, opts *elation.FindLettersOptions) (*elation.Response[[]*elation.Letter], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindLettersOptions{}
+	}
+
+	return find(ctx, s.client, "Letters.Find", "/letters", s.client.letters, opts.Pagination, func(letter *elation.Letter, _ time.Time) bool {
+		recipientID := letter.SendToElationUser
+		if letter.SendToContact != nil {
+			recipientID = letter.SendToContact.ID
+		}
+
+		return matchID(opts.Patient, letter.Patient) &&
+			matchID(opts.Practice, letter.Practice) &&
+			matchID(opts.RecipientID, recipientID) &&
+			matchString(opts.RecipientName, letter.SendToName) &&
+			matchTime(letter.DocumentDate, opts.DocumentDateGT, opts.DocumentDateGTE, opts.DocumentDateLT, opts.DocumentDateLTE)
+	})
+}
+
+func (s *LetterService) Get(ctx context.Context, id int64) (*elation.Letter, *http.Response, error) {
+	return get(ctx, s.client, "Letters.Get", "/letters", s.client.letters, id)
+}
@@ -0,0 +1,238 @@
+package elationtest
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+)
+
+var _ elation.PatientServicer = (*PatientService)(nil)
+
+type PatientService struct {
+	client *Client
+}
+
+func (s *PatientService) Create(ctx context.Context, patientCreate *elation.PatientCreate) (*elation.Patient, *http.Response, error) {
+	return create(ctx, s.client, "Patients.Create", s.client.patients, func(now time.Time) (*elation.Patient, error) {
+		return &elation.Patient{
+			FirstName:         patientCreate.FirstName,
+			LastName:          patientCreate.LastName,
+			Sex:               patientCreate.Sex,
+			DOB:               patientCreate.DOB,
+			PrimaryPhysician:  patientCreate.PrimaryPhysician,
+			CaregiverPractice: patientCreate.CaregiverPractice,
+			Address:           patientCreate.Address,
+			Phones:            patientCreate.Phones,
+			Emails:            patientCreate.Emails,
+			CreatedDate:       now,
+		}, nil
+	})
+}
+
+func (s *PatientService) Find(ctx context.Context, opts *elation.FindPatientsOptions) (*elation.Response[[]*elation.Patient], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindPatientsOptions{}
+	}
+
+	return find(ctx, s.client, "Patients.Find", "/patients", s.client.patients, opts.Pagination, func(patient *elation.Patient, modified time.Time) bool {
+		return matchString(opts.FirstName, patient.FirstName) &&
+			matchString(opts.LastName, patient.LastName) &&
+			matchString(opts.DOB, patient.DOB) &&
+			matchString(opts.Sex, patient.Sex) &&
+			matchID(opts.Practice, patient.CaregiverPractice) &&
+			(opts.MasterPatient == 0 || (patient.MasterPatient != nil && *patient.MasterPatient == opts.MasterPatient)) &&
+			matchInsurance(opts, patient.Insurances) &&
+			matchTime(modified, opts.LastModifiedGT, opts.LastModifiedGTE, opts.LastModifiedLT, opts.LastModifiedLTE)
+	})
+}
+
+func matchInsurance(opts *elation.FindPatientsOptions, insurances []*elation.PatientInsurance) bool {
+	if opts.InsuranceCompany == "" && opts.InsurancePlan == "" && opts.GroupID == 0 && opts.MemberID == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(insurances, func(insurance *elation.PatientInsurance) bool {
+		return matchStringPtr(opts.InsuranceCompany, insurance.Carrier) &&
+			matchStringPtr(opts.InsurancePlan, insurance.Plan) &&
+			(opts.GroupID == 0 || matchStringPtr(strconv.FormatInt(opts.GroupID, 10), insurance.GroupID)) &&
+			(opts.MemberID == 0 || matchStringPtr(strconv.FormatInt(opts.MemberID, 10), insurance.MemberID))
+	})
+}
+
+func (s *PatientService) Get(ctx context.Context, id int64) (*elation.Patient, *http.Response, error) {
+	return get(ctx, s.client, "Patients.Get", "/patients", s.client.patients, id)
+}
+
+func (s *PatientService) Update(ctx context.Context, id int64, patientUpdate *elation.PatientUpdate) (*elation.Patient, *http.Response, error) {
+	now := s.client.now()
+
+	return update(ctx, s.client, "Patients.Update", "/patients", s.client.patients, id, func(patient *elation.Patient) {
+		setPtr(&patient.ActualName, patientUpdate.ActualName)
+		setPtr(&patient.DOB, patientUpdate.DOB)
+		setPtr(&patient.Ethnicity, patientUpdate.Ethnicity)
+		setPtr(&patient.FirstName, patientUpdate.FirstName)
+		setPtr(&patient.GenderIdentity, patientUpdate.GenderIdentity)
+		setPtr(&patient.LastName, patientUpdate.LastName)
+		setPtr(&patient.LegalGenderMarker, patientUpdate.LegalGenderMarker)
+		setPtr(&patient.MiddleName, patientUpdate.MiddleName)
+		setPtr(&patient.Notes, patientUpdate.Notes)
+		setPtr(&patient.PreferredLanguage, patientUpdate.PreferredLanguage)
+		setPtr(&patient.PrimaryCareProviderNPI, patientUpdate.PrimaryCareProviderNPI)
+		setPtr(&patient.PrimaryPhysician, patientUpdate.PrimaryPhysician)
+		setPtr(&patient.Pronouns, patientUpdate.Pronouns)
+		setPtr(&patient.Race, patientUpdate.Race)
+		setPtr(&patient.Sex, patientUpdate.Sex)
+		setPtr(&patient.SexualOrientation, patientUpdate.SexualOrientation)
+		setPtr(&patient.SSN, patientUpdate.SSN)
+		setPtr(&patient.Consents, patientUpdate.Consents)
+		setPtr(&patient.Emails, patientUpdate.Emails)
+		setPtr(&patient.Phones, patientUpdate.Phones)
+
+		if patientUpdate.Address != nil {
+			patient.Address = patientUpdate.Address
+		}
+
+		if patientUpdate.Metadata != nil {
+			patient.Metadata = patientUpdate.Metadata
+		}
+
+		if patientUpdate.Tags != nil {
+			patient.Tags = patientUpdate.Tags
+		}
+
+		if patientUpdate.PatientStatus != nil {
+			status := &elation.PatientStatus{}
+			if patient.PatientStatus != nil {
+				*status = *patient.PatientStatus
+			}
+
+			setPtr(&status.InactiveReason, patientUpdate.PatientStatus.InactiveReason)
+			setPtr(&status.Status, patientUpdate.PatientStatus.Status)
+			status.LastStatusChange = now
+			patient.PatientStatus = status
+		}
+
+		if patientUpdate.Insurances != nil {
+			patient.Insurances = updateInsurances(patient.Insurances, *patientUpdate.Insurances, now)
+		}
+	})
+}
+
+// updateInsurances replaces the insurances of a patient the way the API does: insurances are matched by rank, and
+// insurances of other ranks are removed.
+func updateInsurances(current []*elation.PatientInsurance, updates []*elation.PatientInsuranceUpdate, now time.Time) []*elation.PatientInsurance {
+	var out []*elation.PatientInsurance
+
+	for _, u := range updates {
+		insurance := &elation.PatientInsurance{
+			Rank:        u.Rank,
+			CreatedDate: now,
+		}
+
+		if i := slices.IndexFunc(current, func(i *elation.PatientInsurance) bool { return i.Rank == u.Rank }); i >= 0 {
+			*insurance = *current[i]
+		}
+
+		insurance.InsuranceCompany = u.InsuranceCompany
+		insurance.InsurancePlan = u.InsurancePlan
+		insurance.Carrier = u.Carrier
+		insurance.MemberID = u.MemberID
+		insurance.GroupID = u.GroupID
+		insurance.Plan = u.Plan
+		insurance.Phone = u.Phone
+		insurance.Extension = u.Extension
+		insurance.Address = u.Address
+		insurance.Suite = u.Suite
+		insurance.City = u.City
+		insurance.State = u.State
+		insurance.Zip = u.Zip
+		insurance.Copay = u.Copay
+		insurance.Deductible = u.Deductible
+		insurance.PaymentProgram = u.PaymentProgram
+		insurance.InsuredPersonFirstName = u.InsuredPersonFirstName
+		insurance.InsuredPersonLastName = u.InsuredPersonLastName
+		insurance.InsuredPersonAddress = u.InsuredPersonAddress
+		insurance.InsuredPersonCity = u.InsuredPersonCity
+		insurance.InsuredPersonState = u.InsuredPersonState
+		insurance.InsuredPersonZip = u.InsuredPersonZip
+		insurance.InsuredPersonID = u.InsuredPersonID
+		insurance.InsuredPersonDOB = u.InsuredPersonDOB
+		insurance.InsuredPersonGender = u.InsuredPersonGender
+		insurance.InsuredPersonSSN = u.InsuredPersonSSN
+		insurance.RelationshipToInsured = u.RelationshipToInsured
+		insurance.StartDate = u.StartDate
+		insurance.EndDate = u.EndDate
+
+		out = append(out, insurance)
+	}
+
+	return out
+}
+
+func (s *PatientService) Delete(ctx context.Context, id int64) (*http.Response, error) {
+	return remove(ctx, s.client, "Patients.Delete", "/patients", s.client.patients, id)
+}
+
+var _ elation.AllergyServicer = (*AllergyService)(nil)
+
+type AllergyService struct {
+	client *Client
+}
+
+func (s *AllergyService) Find(ctx context.Context, opts *elation.FindAllergiesOptions) (*elation.Response[[]*elation.Allergy], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindAllergiesOptions{}
+	}
+
+	return find(ctx, s.client, "Allergies.Find", "/allergies", s.client.allergies, opts.Pagination, func(allergy *elation.Allergy, _ time.Time) bool {
+		return matchAny(opts.Patient, allergy.Patient)
+	})
+}
+
+func (s *AllergyService) Get(ctx context.Context, id int64) (*elation.Allergy, *http.Response, error) {
+	return get(ctx, s.client, "Allergies.Get", "/allergies", s.client.allergies, id)
+}
+
+var _ elation.AllergyDocumentationServicer = (*AllergyDocumentationService)(nil)
+
+type AllergyDocumentationService struct {
+	client *Client
+}
+
+func (s *AllergyDocumentationService) Find(ctx context.Context, opts *elation.FindAllergiesDocumentationOptions) (*elation.Response[[]*elation.AllergyDocumentation], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindAllergiesDocumentationOptions{}
+	}
+
+	return find(ctx, s.client, "AllergyDocumentation.Find", "/allergy_documentation", s.client.allergyDocumentation, opts.Pagination, func(documentation *elation.AllergyDocumentation, _ time.Time) bool {
+		return matchAny(opts.Patient, documentation.Patient)
+	})
+}
+
+func (s *AllergyDocumentationService) Get(ctx context.Context, id int64) (*elation.AllergyDocumentation, *http.Response, error) {
+	return get(ctx, s.client, "AllergyDocumentation.Get", "/allergy_documentation", s.client.allergyDocumentation, id)
+}
+
+var _ elation.ProblemServicer = (*ProblemService)(nil)
+
+type ProblemService struct {
+	client *Client
+}
+
+func (s *ProblemService) Find(ctx context.Context, opts *elation.FindPatientProblemsOptions) (*elation.Response[[]*elation.PatientProblem], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindPatientProblemsOptions{}
+	}
+
+	return find(ctx, s.client, "Problems.Find", "/problems", s.client.problems, opts.Pagination, func(problem *elation.PatientProblem, _ time.Time) bool {
+		return matchID(opts.Patient, problem.Patient)
+	})
+}
+
+func (s *ProblemService) Get(ctx context.Context, id int64) (*elation.PatientProblem, *http.Response, error) {
+	return get(ctx, s.client, "Problems.Get", "/problems", s.client.problems, id)
+}
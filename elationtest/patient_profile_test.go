@@ -0,0 +1,147 @@
+package elationtest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatientService(t *testing.T) {
+	assert := assert.New(t)
+
+	client := NewClient()
+	ctx := context.Background()
+
+	created, _, err := client.Patients().Create(ctx, &elation.PatientCreate{
+		FirstName:         "Jane",
+		LastName:          "Doe",
+		Sex:               "Female",
+		DOB:               "1980-01-02",
+		CaregiverPractice: 10,
+	})
+	assert.NoError(err)
+
+	actual, _, err := client.Patients().Get(ctx, created.ID)
+	assert.NoError(err)
+	assert.Equal(created, actual)
+
+	updated, _, err := client.Patients().Update(ctx, created.ID, &elation.PatientUpdate{
+		FirstName: new("Janet"),
+		PatientStatus: &elation.PatientStatusUpdate{
+			Status: new("inactive"),
+		},
+		Insurances: &[]*elation.PatientInsuranceUpdate{
+			{
+				Rank:     "primary",
+				Carrier:  new("Aetna"),
+				MemberID: new("123"),
+			},
+		},
+	})
+	assert.NoError(err)
+	assert.Equal("Janet", updated.FirstName)
+	assert.Equal("Doe", updated.LastName)
+	assert.Equal("inactive", updated.PatientStatus.Status)
+	assert.Len(updated.Insurances, 1)
+
+	res, err := client.Patients().Delete(ctx, created.ID)
+	assert.NoError(err)
+	assert.Equal(http.StatusNoContent, res.StatusCode)
+
+	_, _, err = client.Patients().Get(ctx, created.ID)
+	assert.True(elation.IsNotFound(err))
+
+	_, _, err = client.Patients().Update(ctx, created.ID, &elation.PatientUpdate{})
+	assert.True(elation.IsNotFound(err))
+}
+
+func TestPatientService_Find(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		opts     *elation.FindPatientsOptions
+		expected []int64
+	}{
+		"nil options": {
+			expected: []int64{1, 2, 3},
+		},
+		"last name": {
+			opts:     &elation.FindPatientsOptions{LastName: "Doe"},
+			expected: []int64{1, 2},
+		},
+		"first and last name": {
+			opts:     &elation.FindPatientsOptions{FirstName: "John", LastName: "Doe"},
+			expected: []int64{2},
+		},
+		"practice": {
+			opts:     &elation.FindPatientsOptions{Practice: 20},
+			expected: []int64{3},
+		},
+		"insurance": {
+			opts:     &elation.FindPatientsOptions{InsuranceCompany: "Aetna", MemberID: 123},
+			expected: []int64{1},
+		},
+		"last modified": {
+			opts:     &elation.FindPatientsOptions{LastModifiedGT: now},
+			expected: []int64{2},
+		},
+		"no match": {
+			opts: &elation.FindPatientsOptions{DOB: "2000-01-01"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			client := NewClient()
+			client.Now = func() time.Time { return now }
+
+			Seed(client,
+				&elation.Patient{
+					FirstName:         "Jane",
+					LastName:          "Doe",
+					CaregiverPractice: 10,
+					Insurances: []*elation.PatientInsurance{
+						{Carrier: new("Aetna"), MemberID: new("123")},
+					},
+				},
+				&elation.Patient{FirstName: "John", LastName: "Doe", CaregiverPractice: 10},
+				&elation.Patient{FirstName: "Jane", LastName: "Roe", CaregiverPractice: 20},
+			)
+
+			client.Now = func() time.Time { return now.Add(time.Hour) }
+
+			_, _, err := client.Patients().Update(context.Background(), 2, &elation.PatientUpdate{})
+			assert.NoError(err)
+
+			res, _, err := client.Patients().Find(context.Background(), testCase.opts)
+			assert.NoError(err)
+
+			var ids []int64
+			for _, patient := range res.Results {
+				ids = append(ids, patient.ID)
+			}
+			assert.Equal(testCase.expected, ids)
+			assert.Equal(len(testCase.expected), res.Count)
+		})
+	}
+}
+
+func TestProblemService_Find(t *testing.T) {
+	assert := assert.New(t)
+
+	client := NewClient()
+	Seed(client,
+		&elation.PatientProblem{Patient: 1, Description: "foo"},
+		&elation.PatientProblem{Patient: 2, Description: "bar"},
+	)
+
+	res, _, err := client.Problems().Find(context.Background(), &elation.FindPatientProblemsOptions{Patient: 2})
+	assert.NoError(err)
+	assert.Len(res.Results, 1)
+	assert.Equal("bar", res.Results[0].Description)
+}
@@ -0,0 +1,106 @@
+package elationtest
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+)
+
+var _ elation.PracticeServicer = (*PracticeService)(nil)
+
+type PracticeService struct {
+	client *Client
+}
+
+func (s *PracticeService) Find(ctx context.Context, opts *elation.FindPracticesOptions) (*elation.Response[[]*elation.Practice], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindPracticesOptions{}
+	}
+
+	return find(ctx, s.client, "Practices.Find", "/practices", s.client.practices, opts.Pagination, nil)
+}
+
+func (s *PracticeService) Get(ctx context.Context, id int64) (*elation.Practice, *http.Response, error) {
+	return get(ctx, s.client, "Practices.Get", "/practices", s.client.practices, id)
+}
+
+var _ elation.PhysicianServicer = (*PhysicianService)(nil)
+
+type PhysicianService struct {
+	client *Client
+}
+
+func (s *PhysicianService) Find(ctx context.Context, opts *elation.FindPhysiciansOptions) (*elation.Response[[]*elation.Physician], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindPhysiciansOptions{}
+	}
+
+	return find(ctx, s.client, "Physicians.Find", "/physicians", s.client.physicians, opts.Pagination, func(physician *elation.Physician, _ time.Time) bool {
+		return matchString(opts.FirstName, physician.FirstName) &&
+			matchString(opts.LastName, physician.LastName) &&
+			matchString(opts.NPI, physician.Npi)
+	})
+}
+
+func (s *PhysicianService) Get(ctx context.Context, id int64) (*elation.Physician, *http.Response, error) {
+	return get(ctx, s.client, "Physicians.Get", "/physicians", s.client.physicians, id)
+}
+
+var _ elation.ServiceLocationServicer = (*ServiceLocationService)(nil)
+
+type ServiceLocationService struct {
+	client *Client
+}
+
+func (s *ServiceLocationService) Find(ctx context.Context, opts *elation.FindServiceLocationOptions) (*elation.Response[[]*elation.ServiceLocation], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindServiceLocationOptions{}
+	}
+
+	return find(ctx, s.client, "ServiceLocations.Find", "/service_locations", s.client.serviceLocations, opts.Pagination, nil)
+}
+
+var _ elation.ContactServicer = (*ContactService)(nil)
+
+type ContactService struct {
+	client *Client
+}
+
+func (s *ContactService) Get(ctx context.Context, id int64) (*elation.Contact, *http.Response, error) {
+	return get(ctx, s.client, "Contacts.Get", "/contacts", s.client.contacts, id)
+}
+
+func (s *ContactService) List(ctx context.Context, opts *elation.ListContactsOptions) (*elation.Response[[]*elation.Contact], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.ListContactsOptions{}
+	}
+
+	return find(ctx, s.client, "Contacts.List", "/contacts", s.client.contacts, opts.Pagination, func(contact *elation.Contact, _ time.Time) bool {
+		return matchString(opts.NPI, contact.NPI)
+	})
+}
+
+var _ elation.PharmacyServicer = (*PharmacyService)(nil)
+
+type PharmacyService struct {
+	client *Client
+}
+
+func (s *PharmacyService) Get(ctx context.Context, ncpdpid string) (*elation.Pharmacy, *http.Response, error) {
+	if err := s.client.begin(ctx, "Pharmacies.Get"); err != nil {
+		return nil, nil, err
+	}
+	defer s.client.end()
+
+	pharmacies := s.client.pharmacies.list(func(pharmacy *elation.Pharmacy, _ time.Time) bool {
+		return pharmacy.NCPDPID == ncpdpid
+	})
+	if len(pharmacies) == 0 {
+		err := notFound(http.MethodGet, "/pharmacies/"+ncpdpid)
+		return nil, errorResponse(err), err
+	}
+
+	return pharmacies[0], response(http.StatusOK), nil
+}
@@ -0,0 +1,413 @@
+package elationtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrUnmatchedRequest is returned by a strict Recorder for requests that are not in its cassette.
+var ErrUnmatchedRequest = errors.New("request not found in cassette")
+
+type RecorderMode int
+
+const (
+	// RecorderModeReplay answers requests from the cassette.
+	RecorderModeReplay RecorderMode = iota
+
+	// RecorderModeRecord sends requests to the underlying transport and adds them to the cassette.
+	RecorderModeRecord
+)
+
+// DefaultRedactions maps the JSON fields and query parameters redacted by a Recorder to their replacement. It covers
+// the OAuth tokens and the patient identifiers returned by the API. Dates of birth are replaced by a valid date so that
+// replayed responses can still be parsed.
+var DefaultRedactions = map[string]string{
+	"access_token":              "REDACTED",
+	"refresh_token":             "REDACTED",
+	"client_secret":             "REDACTED",
+	"ssn":                       "000-00-0000",
+	"insured_person_ssn":        "000-00-0000",
+	"dob":                       "1900-01-01",
+	"patient_dob":               "1900-01-01",
+	"insured_person_dob":        "1900-01-01",
+	"first_name":                "REDACTED",
+	"middle_name":               "REDACTED",
+	"last_name":                 "REDACTED",
+	"actual_name":               "REDACTED",
+	"previous_first_name":       "REDACTED",
+	"previous_last_name":        "REDACTED",
+	"patient_first_name":        "REDACTED",
+	"patient_last_name":         "REDACTED",
+	"insured_person_first_name": "REDACTED",
+	"insured_person_last_name":  "REDACTED",
+}
+
+// Cassette is the file format of a Recorder.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response. Requests are matched by method, path, normalized query and
+// redacted body. Headers are not stored.
+type Interaction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+type CassetteRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+
+	// Body is the redacted request body. JSON bodies are stored with sorted keys and form bodies like a query. A request
+	// recorded without a body matches requests with any body, so cassettes recorded before bodies were stored still
+	// replay.
+	Body string `json:"body,omitempty"`
+}
+
+type CassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records requests to a cassette file and replays them. Use it as the transport
+// of the http.Client passed to elation.NewHTTPClient, which also routes token requests through it:
+//
+//	recorder, err := elationtest.NewRecorder("testdata/patients.json", elationtest.RecorderModeReplay)
+//	client := elation.NewHTTPClient(&http.Client{Transport: recorder}, tokenURL, clientID, clientSecret, baseURL)
+//
+// Recorded requests and responses have the fields in DefaultRedactions redacted, along with the same query parameters.
+type Recorder struct {
+	path       string
+	mode       RecorderMode
+	transport  http.RoundTripper
+	strict     bool
+	redactions map[string]string
+
+	mu       sync.Mutex
+	cassette *Cassette
+	replayed map[*Interaction]bool
+}
+
+type RecorderOption func(*Recorder)
+
+// WithTransport sets the transport used to record requests, and to send unmatched requests when replaying without
+// WithStrictReplay. It defaults to http.DefaultTransport.
+func WithTransport(transport http.RoundTripper) RecorderOption {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithStrictReplay makes the Recorder fail requests that are not in the cassette with ErrUnmatchedRequest instead of
+// sending them.
+func WithStrictReplay() RecorderOption {
+	return func(r *Recorder) {
+		r.strict = true
+	}
+}
+
+// WithRedaction redacts field in response bodies and query parameters, replacing string values with replacement.
+func WithRedaction(field, replacement string) RecorderOption {
+	return func(r *Recorder) {
+		r.redactions[field] = replacement
+	}
+}
+
+// NewRecorder returns a Recorder for the cassette at path. In replay mode the cassette must exist. In record mode it is
+// written by Save.
+func NewRecorder(path string, mode RecorderMode, opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		path:       path,
+		mode:       mode,
+		transport:  http.DefaultTransport,
+		redactions: map[string]string{},
+		cassette:   &Cassette{},
+		replayed:   map[*Interaction]bool{},
+	}
+
+	for field, replacement := range DefaultRedactions {
+		r.redactions[field] = replacement
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if mode == RecorderModeReplay {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading cassette: %w", err)
+		}
+
+		err = json.Unmarshal(b, r.cassette)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshaling cassette: %w", err)
+		}
+	}
+
+	return r, nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	req, body, err := r.requestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	key := CassetteRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  r.normalizeQuery(req.URL.Query()),
+		Body:   body,
+	}
+
+	if r.mode == RecorderModeRecord {
+		return r.record(req, key)
+	}
+
+	interaction := r.match(key)
+	if interaction == nil {
+		if r.strict {
+			return nil, fmt.Errorf("%w: %s %s?%s %s", ErrUnmatchedRequest, key.Method, key.Path, key.Query, key.Body)
+		}
+
+		return r.transport.RoundTrip(req)
+	}
+
+	//nolint
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	res := &http.Response{
+		StatusCode:    interaction.Response.StatusCode,
+		Status:        strconv.Itoa(interaction.Response.StatusCode) + " " + http.StatusText(interaction.Response.StatusCode),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        interaction.Response.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       req,
+	}
+
+	if res.Header == nil {
+		res.Header = http.Header{}
+	}
+
+	return res, nil
+}
+
+// Save writes the cassette to its path, creating the directory if needed.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("marshaling cassette: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(r.path), 0o755)
+	if err != nil {
+		return fmt.Errorf("creating cassette directory: %w", err)
+	}
+
+	err = os.WriteFile(r.path, append(b, '\n'), 0o600)
+	if err != nil {
+		return fmt.Errorf("writing cassette: %w", err)
+	}
+
+	return nil
+}
+
+// Unused returns the interactions that have not been replayed, which can be used to detect requests a test no longer
+// makes.
+func (r *Recorder) Unused() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out []*Interaction
+	for _, interaction := range r.cassette.Interactions {
+		if !r.replayed[interaction] {
+			out = append(out, interaction)
+		}
+	}
+
+	return out
+}
+
+func (r *Recorder) record(req *http.Request, key CassetteRequest) (*http.Response, error) {
+	res, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	//nolint
+	res.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := res.Header.Clone()
+	header.Del("Set-Cookie")
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: key,
+		Response: CassetteResponse{
+			StatusCode: res.StatusCode,
+			Header:     header,
+			Body:       r.redactBody(body),
+		},
+	})
+	r.mu.Unlock()
+
+	return res, nil
+}
+
+// match returns the first interaction for key that has not been replayed yet. Once they all have, the last one is
+// replayed again.
+func (r *Recorder) match(key CassetteRequest) *Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var last *Interaction
+	for _, interaction := range r.cassette.Interactions {
+		if !interaction.Request.matches(key) {
+			continue
+		}
+
+		if !r.replayed[interaction] {
+			r.replayed[interaction] = true
+			return interaction
+		}
+
+		last = interaction
+	}
+
+	return last
+}
+
+func (c CassetteRequest) matches(key CassetteRequest) bool {
+	return c.Method == key.Method && c.Path == key.Path && c.Query == key.Query && (c.Body == "" || c.Body == key.Body)
+}
+
+// requestBody reads the body of req and returns it redacted, along with a copy of req that can still send the body.
+func (r *Recorder) requestBody(req *http.Request) (*http.Request, string, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, "", nil
+	}
+
+	b, err := io.ReadAll(req.Body)
+	//nolint
+	req.Body.Close()
+	if err != nil {
+		return nil, "", fmt.Errorf("reading request body: %w", err)
+	}
+
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(b))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(b)), nil
+	}
+
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(b))
+		if err == nil {
+			return req, r.normalizeQuery(form), nil
+		}
+	}
+
+	return req, r.redactBody(b), nil
+}
+
+// normalizeQuery encodes q with sorted keys and values, and redacted values.
+func (r *Recorder) normalizeQuery(q url.Values) string {
+	out := url.Values{}
+	for key, values := range q {
+		values = slices.Clone(values)
+		if replacement, ok := r.redactions[key]; ok {
+			for i := range values {
+				values[i] = replacement
+			}
+		}
+
+		slices.Sort(values)
+		out[key] = values
+	}
+
+	return out.Encode()
+}
+
+// redactBody redacts JSON bodies. Other bodies are stored unchanged. Numbers are kept as written so that IDs above
+// 2^53 are not rounded.
+func (r *Recorder) redactBody(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var v any
+	if err := decoder.Decode(&v); err != nil || decoder.More() {
+		return string(body)
+	}
+
+	b, err := json.Marshal(r.redact(v))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(b)
+}
+
+func (r *Recorder) redact(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			s, isString := value.(string)
+
+			switch replacement, ok := r.redactions[key]; {
+			case ok && isString:
+				v[key] = replacement
+			case isString && (key == "next" || key == "previous"):
+				v[key] = r.redactURL(s)
+			default:
+				v[key] = r.redact(value)
+			}
+		}
+
+	case []any:
+		for i, value := range v {
+			v[i] = r.redact(value)
+		}
+	}
+
+	return v
+}
+
+// redactURL redacts the query of pagination links, which repeat the filters of the request.
+func (r *Recorder) redactURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		return s
+	}
+
+	u.RawQuery = r.normalizeQuery(u.Query())
+
+	return u.String()
+}
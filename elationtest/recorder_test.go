@@ -0,0 +1,150 @@
+package elationtest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/authorhealth/go-elation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	assert := assert.New(t)
+
+	client := NewClient()
+	Seed(client,
+		&elation.Patient{ID: 1, FirstName: "Jane", LastName: "Doe", DOB: "1980-01-02", SSN: "123-45-6789", Sex: "Female"},
+		&elation.Patient{ID: 2, FirstName: "John", LastName: "Doe", DOB: "1981-01-02", Sex: "Male"},
+	)
+
+	srv := httptest.NewServer(NewServer(client))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "testdata", "patients.json")
+	ctx := context.Background()
+
+	recorder, err := NewRecorder(path, RecorderModeRecord, WithTransport(srv.Client().Transport))
+	assert.NoError(err)
+
+	httpClient := elation.NewHTTPClient(&http.Client{Transport: recorder}, srv.URL+"/token", "id", "secret", srv.URL)
+
+	recorded, _, err := httpClient.Patients().Find(ctx, &elation.FindPatientsOptions{
+		Pagination: &elation.Pagination{Limit: 1},
+		LastName:   "Doe",
+	})
+	assert.NoError(err)
+	assert.Equal("Jane", recorded.Results[0].FirstName)
+
+	_, _, err = httpClient.Patients().Get(ctx, 3)
+	assert.True(elation.IsNotFound(err))
+
+	assert.NoError(recorder.Save())
+
+	b, err := os.ReadFile(path)
+	assert.NoError(err)
+	assert.NotContains(string(b), "Jane")
+	assert.NotContains(string(b), "Doe")
+	assert.NotContains(string(b), "1980-01-02")
+	assert.NotContains(string(b), "123-45-6789")
+	assert.NotContains(string(b), serverAccessToken)
+
+	// Replay without the server.
+	srv.Close()
+
+	recorder, err = NewRecorder(path, RecorderModeReplay, WithStrictReplay())
+	assert.NoError(err)
+
+	httpClient = elation.NewHTTPClient(&http.Client{Transport: recorder}, srv.URL+"/token", "id", "secret", srv.URL)
+
+	// The query is matched regardless of parameter order and redacted values.
+	replayed, _, err := httpClient.Patients().Find(ctx, &elation.FindPatientsOptions{
+		LastName:   "Roe",
+		Pagination: &elation.Pagination{Limit: 1},
+	})
+	assert.NoError(err)
+	assert.Equal(2, replayed.Count)
+	assert.Equal(int64(1), replayed.Results[0].ID)
+	assert.Equal("REDACTED", replayed.Results[0].FirstName)
+	assert.Equal("1900-01-01", replayed.Results[0].DOB)
+	assert.Equal("000-00-0000", replayed.Results[0].SSN)
+	assert.Equal("Female", replayed.Results[0].Sex)
+	assert.Equal(&elation.Pagination{Limit: 1, Offset: 1}, replayed.PaginationNext())
+
+	_, _, err = httpClient.Patients().Get(ctx, 3)
+	assert.True(elation.IsNotFound(err))
+
+	assert.Empty(recorder.Unused())
+
+	_, _, err = httpClient.Patients().Get(ctx, 4)
+	assert.ErrorIs(err, ErrUnmatchedRequest)
+}
+
+func TestNewRecorder_missing_cassette(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), RecorderModeReplay)
+	assert.ErrorIs(err, os.ErrNotExist)
+}
+
+func TestRecorder_request_body(t *testing.T) {
+	assert := assert.New(t)
+
+	var largeID int64 = 1<<60 + 1
+
+	client := NewClient()
+	Seed(client, &elation.Patient{ID: largeID, FirstName: "Jane"})
+
+	srv := httptest.NewServer(NewServer(client))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "patients.json")
+	ctx := context.Background()
+
+	recorder, err := NewRecorder(path, RecorderModeRecord, WithTransport(srv.Client().Transport))
+	assert.NoError(err)
+
+	httpClient := elation.NewHTTPClient(&http.Client{Transport: recorder}, srv.URL+"/token", "id", "secret", srv.URL)
+
+	female, _, err := httpClient.Patients().Create(ctx, &elation.PatientCreate{FirstName: "Jane", Sex: "Female"})
+	assert.NoError(err)
+
+	male, _, err := httpClient.Patients().Create(ctx, &elation.PatientCreate{FirstName: "John", Sex: "Male"})
+	assert.NoError(err)
+
+	_, _, err = httpClient.Patients().Get(ctx, largeID)
+	assert.NoError(err)
+
+	assert.NoError(recorder.Save())
+
+	b, err := os.ReadFile(path)
+	assert.NoError(err)
+	assert.NotContains(string(b), "John")
+	assert.NotContains(string(b), "secret")
+
+	srv.Close()
+
+	recorder, err = NewRecorder(path, RecorderModeReplay, WithStrictReplay())
+	assert.NoError(err)
+
+	httpClient = elation.NewHTTPClient(&http.Client{Transport: recorder}, srv.URL+"/token", "id", "secret", srv.URL)
+
+	// Requests to the same path are told apart by their bodies, whatever order they are made in.
+	replayed, _, err := httpClient.Patients().Create(ctx, &elation.PatientCreate{FirstName: "John", Sex: "Male"})
+	assert.NoError(err)
+	assert.Equal(male.ID, replayed.ID)
+
+	replayed, _, err = httpClient.Patients().Create(ctx, &elation.PatientCreate{FirstName: "Jane", Sex: "Female"})
+	assert.NoError(err)
+	assert.Equal(female.ID, replayed.ID)
+
+	patient, _, err := httpClient.Patients().Get(ctx, largeID)
+	assert.NoError(err)
+	assert.Equal(largeID, patient.ID)
+
+	_, _, err = httpClient.Patients().Create(ctx, &elation.PatientCreate{FirstName: "Jane", Sex: "Unknown"})
+	assert.ErrorIs(err, ErrUnmatchedRequest)
+}
@@ -0,0 +1,239 @@
+package elationtest
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+)
+
+var _ elation.AppointmentServicer = (*AppointmentService)(nil)
+
+type AppointmentService struct {
+	client *Client
+}
+
+func (s *AppointmentService) Create(ctx context.Context, appointmentCreate *elation.AppointmentCreate) (*elation.Appointment, *http.Response, error) {
+	return create(ctx, s.client, "Appointments.Create", s.client.appointments, func(now time.Time) (*elation.Appointment, error) {
+		appointment := &elation.Appointment{
+			ScheduledDate: appointmentCreate.ScheduledDate,
+			Duration:      int(appointmentCreate.Duration),
+			TimeSlotType:  string(elation.AppointmentTimeSlotTypeAppointment),
+			Reason:        appointmentCreate.Reason,
+			Status: &elation.AppointmentStatus{
+				Status:     elation.AppointmentStatusScheduled,
+				StatusDate: now.Format(time.RFC3339),
+			},
+			Patient:          appointmentCreate.Patient,
+			Physician:        appointmentCreate.Physician,
+			Practice:         appointmentCreate.Practice,
+			Metadata:         appointmentCreate.Metadata,
+			CreatedDate:      now,
+			LastModifiedDate: now,
+			Mode:             elation.AppointmentModeInPerson,
+		}
+
+		setPtr(&appointment.Description, appointmentCreate.Description)
+		setPtr(&appointment.Mode, appointmentCreate.Mode)
+
+		if appointmentCreate.ServiceLocation != nil {
+			serviceLocation, ok := s.client.serviceLocations.get(*appointmentCreate.ServiceLocation)
+			if !ok {
+				return nil, badRequest(http.MethodPost, "/appointments", "service_location", "Invalid pk - object does not exist.")
+			}
+
+			appointment.ServiceLocation = appointmentServiceLocation(serviceLocation)
+		}
+
+		return appointment, nil
+	})
+}
+
+func appointmentServiceLocation(serviceLocation *elation.ServiceLocation) *elation.AppointmentServiceLocation {
+	return &elation.AppointmentServiceLocation{
+		ID:           int(serviceLocation.ID),
+		Name:         serviceLocation.Name,
+		AddressLine1: serviceLocation.AddressLine1,
+		AddressLine2: serviceLocation.AddressLine2,
+		City:         serviceLocation.City,
+		State:        serviceLocation.State,
+		Zip:          serviceLocation.Zip,
+		Phone:        serviceLocation.Phone,
+	}
+}
+
+func (s *AppointmentService) Find(ctx context.Context, opts *elation.FindAppointmentsOptions) (*elation.Response[[]*elation.Appointment], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindAppointmentsOptions{}
+	}
+
+	return find(ctx, s.client, "Appointments.Find", "/appointments", s.client.appointments, opts.Pagination, func(appointment *elation.Appointment, _ time.Time) bool {
+		return matchAny(opts.Patient, appointment.Patient) &&
+			matchAny(opts.Practice, appointment.Practice) &&
+			matchAny(opts.Physician, appointment.Physician) &&
+			matchString(opts.TimeSlotType, appointment.TimeSlotType) &&
+			matchTime(appointment.ScheduledDate, time.Time{}, opts.FromDate, time.Time{}, opts.ToDate)
+	})
+}
+
+func (s *AppointmentService) Get(ctx context.Context, id int64) (*elation.Appointment, *http.Response, error) {
+	return get(ctx, s.client, "Appointments.Get", "/appointments", s.client.appointments, id)
+}
+
+func (s *AppointmentService) Update(ctx context.Context, id int64, appointmentUpdate *elation.AppointmentUpdate) (*elation.Appointment, *http.Response, error) {
+	return update(ctx, s.client, "Appointments.Update", "/appointments", s.client.appointments, id, func(appointment *elation.Appointment) {
+		now := s.client.now()
+
+		setPtr(&appointment.Description, appointmentUpdate.Description)
+		setPtr(&appointment.Duration, appointmentUpdate.Duration)
+		setPtr(&appointment.Instructions, appointmentUpdate.Instructions)
+		setPtr(&appointment.Mode, appointmentUpdate.Mode)
+		setPtr(&appointment.TelehealthDetails, appointmentUpdate.TelehealthDetails)
+
+		if appointmentUpdate.ServiceLocation != nil {
+			if serviceLocation, ok := s.client.serviceLocations.get(int64(*appointmentUpdate.ServiceLocation)); ok {
+				appointment.ServiceLocation = appointmentServiceLocation(serviceLocation)
+			}
+		}
+
+		if appointmentUpdate.Status != nil {
+			appointment.Status = appointmentStatus(appointmentUpdate.Status, now)
+		}
+
+		appointment.LastModifiedDate = now
+	})
+}
+
+func appointmentStatus(status *elation.AppointmentUpdateStatus, now time.Time) *elation.AppointmentStatus {
+	return &elation.AppointmentStatus{
+		Status:       status.Status,
+		Room:         status.Room,
+		StatusDate:   now.Format(time.RFC3339),
+		StatusDetail: status.StatusDetail,
+	}
+}
+
+func (s *AppointmentService) Delete(ctx context.Context, id int64) (*http.Response, error) {
+	return remove(ctx, s.client, "Appointments.Delete", "/appointments", s.client.appointments, id)
+}
+
+func (s *AppointmentService) CheckIn(ctx context.Context, id int64, room string) (*elation.Appointment, *http.Response, error) {
+	return s.transition(ctx, "Appointments.CheckIn", id, &elation.AppointmentUpdateStatus{
+		Status: elation.AppointmentStatusCheckedIn,
+		Room:   room,
+	})
+}
+
+func (s *AppointmentService) Cancel(ctx context.Context, id int64, reason string) (*elation.Appointment, *http.Response, error) {
+	return s.transition(ctx, "Appointments.Cancel", id, &elation.AppointmentUpdateStatus{
+		Status:       elation.AppointmentStatusCancelled,
+		StatusDetail: reason,
+	})
+}
+
+func (s *AppointmentService) MarkNoShow(ctx context.Context, id int64) (*elation.Appointment, *http.Response, error) {
+	return s.transition(ctx, "Appointments.MarkNoShow", id, &elation.AppointmentUpdateStatus{
+		Status: elation.AppointmentStatusNotSeen,
+	})
+}
+
+// transition updates the status of an appointment if its current status can change to status, and otherwise returns
+// the 409 Conflict response and *elation.Error of the real client, which wraps elation.ErrInvalidStatusTransition.
+func (s *AppointmentService) transition(ctx context.Context, op string, id int64, status *elation.AppointmentUpdateStatus) (*elation.Appointment, *http.Response, error) {
+	if err := s.client.begin(ctx, op); err != nil {
+		return nil, nil, err
+	}
+	defer s.client.end()
+
+	appointment, ok := s.client.appointments.get(id)
+	if !ok {
+		err := notFound(http.MethodGet, idPath("/appointments", id))
+		return nil, errorResponse(err), err
+	}
+
+	if err := appointment.CurrentStatus().ValidateTransition(status.Status); err != nil {
+		err = conflict(http.MethodPatch, idPath("/appointments", id), err)
+		return nil, errorResponse(err), err
+	}
+
+	appointment, _ = s.client.appointments.update(s.client, id, func(appointment *elation.Appointment) {
+		now := s.client.now()
+
+		appointment.Status = appointmentStatus(status, now)
+		appointment.LastModifiedDate = now
+	})
+
+	return appointment, response(http.StatusOK), nil
+}
+
+var _ elation.RecurringEventGroupServicer = (*RecurringEventGroupService)(nil)
+
+type RecurringEventGroupService struct {
+	client *Client
+}
+
+func (s *RecurringEventGroupService) Create(ctx context.Context, groupCreate *elation.RecurringEventGroupCreate) (*elation.RecurringEventGroup, *http.Response, error) {
+	return create(ctx, s.client, "RecurringEventGroups.Create", s.client.recurringEventGroups, func(now time.Time) (*elation.RecurringEventGroup, error) {
+		return &elation.RecurringEventGroup{
+			Practice:     groupCreate.Practice,
+			CreatedDate:  now,
+			Reason:       groupCreate.Reason,
+			Schedules:    s.schedules(groupCreate.Schedules, now),
+			TimeSlotType: groupCreate.TimeSlotType,
+		}, nil
+	})
+}
+
+// schedules copies schedules, assigning IDs and created dates to new ones.
+func (s *RecurringEventGroupService) schedules(schedules []*elation.RecurringEventGroupSchedule, now time.Time) []*elation.RecurringEventGroupSchedule {
+	var out []*elation.RecurringEventGroupSchedule
+
+	for _, schedule := range schedules {
+		schedule := *schedule
+
+		if schedule.ID == 0 {
+			schedule.ID = s.client.newID()
+			schedule.CreatedDate = now
+		}
+
+		out = append(out, &schedule)
+	}
+
+	return out
+}
+
+func (s *RecurringEventGroupService) Find(ctx context.Context, opts *elation.FindRecurringEventGroupsOptions) (*elation.Response[[]*elation.RecurringEventGroup], *http.Response, error) {
+	if opts == nil {
+		opts = &elation.FindRecurringEventGroupsOptions{}
+	}
+
+	return find(ctx, s.client, "RecurringEventGroups.Find", "/recurring_event_groups", s.client.recurringEventGroups, opts.Pagination, func(group *elation.RecurringEventGroup, _ time.Time) bool {
+		return matchAny(opts.Practice, group.Practice) &&
+			matchString(opts.Reason, group.Reason) &&
+			matchString(string(opts.TimeSlotType), string(group.TimeSlotType)) &&
+			(len(opts.Physician) == 0 && opts.StartDate == "" && opts.EndDate == "" ||
+				slices.ContainsFunc(group.Schedules, func(schedule *elation.RecurringEventGroupSchedule) bool {
+					return matchAny(opts.Physician, schedule.Physician) &&
+						// Dates are formatted as YYYY-MM-DD, so they compare in chronological order.
+						(opts.EndDate == "" || schedule.SeriesStart <= opts.EndDate) &&
+						(opts.StartDate == "" || schedule.SeriesStop == "" || schedule.SeriesStop >= opts.StartDate)
+				}))
+	})
+}
+
+func (s *RecurringEventGroupService) Get(ctx context.Context, id int64) (*elation.RecurringEventGroup, *http.Response, error) {
+	return get(ctx, s.client, "RecurringEventGroups.Get", "/recurring_event_groups", s.client.recurringEventGroups, id)
+}
+
+func (s *RecurringEventGroupService) Update(ctx context.Context, id int64, groupUpdate *elation.RecurringEventGroupUpdate) (*elation.RecurringEventGroup, *http.Response, error) {
+	return update(ctx, s.client, "RecurringEventGroups.Update", "/recurring_event_groups", s.client.recurringEventGroups, id, func(group *elation.RecurringEventGroup) {
+		group.Reason = groupUpdate.Reason
+		group.Schedules = s.schedules(groupUpdate.Schedules, s.client.now())
+	})
+}
+
+func (s *RecurringEventGroupService) Delete(ctx context.Context, id int64) (*http.Response, error) {
+	return remove(ctx, s.client, "RecurringEventGroups.Delete", "/recurring_event_groups", s.client.recurringEventGroups, id)
+}
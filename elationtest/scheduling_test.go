@@ -0,0 +1,229 @@
+package elationtest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppointmentService(t *testing.T) {
+	assert := assert.New(t)
+
+	client := NewClient()
+	ctx := context.Background()
+
+	Seed(client, &elation.ServiceLocation{ID: 50, Name: "Main Office"})
+
+	scheduledDate := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	created, _, err := client.Appointments().Create(ctx, &elation.AppointmentCreate{
+		Duration:        30,
+		Patient:         1,
+		Physician:       2,
+		Practice:        3,
+		Reason:          "Follow-Up",
+		ScheduledDate:   scheduledDate,
+		ServiceLocation: new(int64(50)),
+	})
+	assert.NoError(err)
+	assert.Equal(elation.AppointmentStatusScheduled, created.Status.Status)
+	assert.Equal(elation.AppointmentModeInPerson, created.Mode)
+	assert.Equal("appointment", created.TimeSlotType)
+	assert.Equal("Main Office", created.ServiceLocation.Name)
+
+	updated, _, err := client.Appointments().Update(ctx, created.ID, &elation.AppointmentUpdate{
+		Duration: new(45),
+		Status: &elation.AppointmentUpdateStatus{
+			Status: "Checked In",
+			Room:   "1",
+		},
+	})
+	assert.NoError(err)
+	assert.Equal(45, updated.Duration)
+	assert.Equal(elation.AppointmentStatusCheckedIn, updated.Status.Status)
+	assert.Equal("1", updated.Status.Room)
+
+	_, _, err = client.Appointments().Create(ctx, &elation.AppointmentCreate{ServiceLocation: new(int64(51))})
+	assert.True(elation.IsBadRequest(err))
+}
+
+func TestAppointmentService_transitions(t *testing.T) {
+	assert := assert.New(t)
+
+	client := NewClient()
+	ctx := context.Background()
+
+	Seed(client,
+		&elation.Appointment{ID: 1, Status: &elation.AppointmentStatus{Status: elation.AppointmentStatusScheduled}},
+		&elation.Appointment{ID: 2, Status: &elation.AppointmentStatus{Status: elation.AppointmentStatusConfirmed}},
+		&elation.Appointment{ID: 3},
+	)
+
+	checkedIn, _, err := client.Appointments().CheckIn(ctx, 1, "2")
+	assert.NoError(err)
+	assert.Equal(elation.AppointmentStatusCheckedIn, checkedIn.Status.Status)
+	assert.Equal("2", checkedIn.Status.Room)
+
+	_, res, err := client.Appointments().MarkNoShow(ctx, 1)
+	assert.ErrorIs(err, elation.ErrInvalidStatusTransition)
+	if assert.NotNil(res) {
+		assert.Equal(http.StatusConflict, res.StatusCode)
+	}
+
+	cancelled, _, err := client.Appointments().Cancel(ctx, 2, "Patient request")
+	assert.NoError(err)
+	assert.Equal(elation.AppointmentStatusCancelled, cancelled.Status.Status)
+	assert.Equal("Patient request", cancelled.Status.StatusDetail)
+
+	notSeen, _, err := client.Appointments().MarkNoShow(ctx, 3)
+	assert.NoError(err)
+	assert.Equal(elation.AppointmentStatusNotSeen, notSeen.Status.Status)
+
+	_, _, err = client.Appointments().CheckIn(ctx, 4, "")
+	assert.True(elation.IsNotFound(err))
+}
+
+func TestAppointmentService_transitions_match_client(t *testing.T) {
+	assert := assert.New(t)
+
+	client := NewClient()
+	Seed(client, &elation.Appointment{ID: 1, Status: &elation.AppointmentStatus{Status: elation.AppointmentStatusCheckedIn}})
+
+	server := NewServer(client)
+	defer server.Close()
+
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	httpClient := elation.NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+	ctx := context.Background()
+
+	_, fakeRes, fakeErr := client.Appointments().MarkNoShow(ctx, 1)
+	_, res, err := httpClient.Appointments().MarkNoShow(ctx, 1)
+
+	for _, err := range []error{fakeErr, err} {
+		assert.ErrorIs(err, elation.ErrInvalidStatusTransition)
+		assert.True(elation.IsConflict(err))
+	}
+
+	assert.EqualError(fakeErr, err.Error())
+
+	if assert.NotNil(fakeRes) && assert.NotNil(res) {
+		assert.Equal(http.StatusConflict, res.StatusCode)
+		assert.Equal(res.StatusCode, fakeRes.StatusCode)
+	}
+}
+
+func TestAppointmentService_Find(t *testing.T) {
+	testCases := map[string]struct {
+		opts     *elation.FindAppointmentsOptions
+		expected []int64
+	}{
+		"physician": {
+			opts:     &elation.FindAppointmentsOptions{Physician: []int64{20}},
+			expected: []int64{3},
+		},
+		"patients": {
+			opts:     &elation.FindAppointmentsOptions{Patient: []int64{1, 2}},
+			expected: []int64{1, 2},
+		},
+		"date range": {
+			opts: &elation.FindAppointmentsOptions{
+				FromDate: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				ToDate:   time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+			},
+			expected: []int64{2},
+		},
+		"time slot type": {
+			opts:     &elation.FindAppointmentsOptions{TimeSlotType: "event"},
+			expected: []int64{3},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			client := NewClient()
+			Seed(client,
+				&elation.Appointment{Patient: 1, Physician: 10, ScheduledDate: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), TimeSlotType: "appointment"},
+				&elation.Appointment{Patient: 2, Physician: 10, ScheduledDate: time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC), TimeSlotType: "appointment"},
+				&elation.Appointment{Physician: 20, ScheduledDate: time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC), TimeSlotType: "event"},
+			)
+
+			res, _, err := client.Appointments().Find(context.Background(), testCase.opts)
+			assert.NoError(err)
+
+			var ids []int64
+			for _, appointment := range res.Results {
+				ids = append(ids, appointment.ID)
+			}
+			assert.Equal(testCase.expected, ids)
+		})
+	}
+}
+
+func TestRecurringEventGroupService(t *testing.T) {
+	assert := assert.New(t)
+
+	client := NewClient()
+	ctx := context.Background()
+
+	created, _, err := client.RecurringEventGroups().Create(ctx, &elation.RecurringEventGroupCreate{
+		Practice: 1,
+		Reason:   "Lunch",
+		Schedules: []*elation.RecurringEventGroupSchedule{
+			{
+				SeriesStart: "2024-01-01",
+				SeriesStop:  "2024-06-30",
+				EventTime:   "12:00:00",
+				Physician:   10,
+				Duration:    60,
+				Repeats:     "Weekly",
+				DOWMonday:   true,
+			},
+		},
+		TimeSlotType: elation.AppointmentTimeSlotTypeEvent,
+	})
+	assert.NoError(err)
+	assert.NotZero(created.Schedules[0].ID)
+
+	testCases := map[string]struct {
+		opts     *elation.FindRecurringEventGroupsOptions
+		expected int
+	}{
+		"physician": {
+			opts:     &elation.FindRecurringEventGroupsOptions{Physician: []int64{10}},
+			expected: 1,
+		},
+		"other physician": {
+			opts: &elation.FindRecurringEventGroupsOptions{Physician: []int64{11}},
+		},
+		"overlapping dates": {
+			opts:     &elation.FindRecurringEventGroupsOptions{StartDate: "2024-06-01", EndDate: "2024-07-31"},
+			expected: 1,
+		},
+		"after series stop": {
+			opts: &elation.FindRecurringEventGroupsOptions{StartDate: "2024-07-01"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			res, _, err := client.RecurringEventGroups().Find(ctx, testCase.opts)
+			assert.NoError(err)
+			assert.Len(res.Results, testCase.expected)
+		})
+	}
+
+	updated, _, err := client.RecurringEventGroups().Update(ctx, created.ID, &elation.RecurringEventGroupUpdate{
+		Reason:    "Admin",
+		Schedules: created.Schedules,
+	})
+	assert.NoError(err)
+	assert.Equal("Admin", updated.Reason)
+	assert.Equal(created.Schedules[0].ID, updated.Schedules[0].ID)
+}
@@ -0,0 +1,795 @@
+package elationtest
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+)
+
+const (
+	// serverAccessToken is the bearer token issued by the token endpoint.
+	serverAccessToken = "elationtest"
+
+	defaultApplicationID = "elationtest"
+)
+
+// Server is an http.Handler that serves the Elation API, including the OAuth token endpoint, from a Client. Requests
+// made by an elation.HTTPClient pointed at the server read and modify the client's store, and changes to subscribed
+// resources are delivered as signed webhooks in the background. Use it with httptest.NewServer:
+//
+//	server := elationtest.NewServer(client)
+//	defer server.Close()
+//
+//	srv := httptest.NewServer(server)
+//	httpClient := elation.NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+type Server struct {
+	client *Client
+	mux    *http.ServeMux
+
+	clientID      string
+	clientSecret  string
+	applicationID string
+	privateKey    ed25519.PrivateKey
+	webhookClient *http.Client
+
+	// removeListener unregisters the server from the client's changes.
+	removeListener func()
+
+	mu         sync.Mutex
+	idle       *sync.Cond
+	eventID    int64
+	deliveries []*Delivery
+	pending    []Change
+	sending    bool
+	closed     bool
+}
+
+// Delivery is a webhook sent by a Server.
+type Delivery struct {
+	Event  *elation.Event
+	Target string
+
+	// StatusCode is the status code returned by the target. It is zero if Err is set.
+	StatusCode int
+	Err        error
+}
+
+type ServerOption func(*Server)
+
+// WithCredentials makes the token endpoint only accept the given client ID and secret. By default any credentials are
+// accepted.
+func WithCredentials(clientID, clientSecret string) ServerOption {
+	return func(s *Server) {
+		s.clientID = clientID
+		s.clientSecret = clientSecret
+	}
+}
+
+// WithApplicationID sets the application ID of sent webhook events.
+func WithApplicationID(applicationID string) ServerOption {
+	return func(s *Server) {
+		s.applicationID = applicationID
+	}
+}
+
+// WithSigningKey sets the key used to sign webhooks. By default a new key is generated.
+func WithSigningKey(privateKey ed25519.PrivateKey) ServerOption {
+	return func(s *Server) {
+		s.privateKey = privateKey
+	}
+}
+
+// WithWebhookClient sets the HTTP client used to deliver webhooks.
+func WithWebhookClient(httpClient *http.Client) ServerOption {
+	return func(s *Server) {
+		s.webhookClient = httpClient
+	}
+}
+
+func NewServer(client *Client, opts ...ServerOption) *Server {
+	s := &Server{
+		client:        client,
+		mux:           http.NewServeMux(),
+		applicationID: defaultApplicationID,
+		webhookClient: &http.Client{Timeout: 10 * time.Second},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.privateKey == nil {
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			panic(fmt.Sprintf("elationtest: generating signing key: %v", err))
+		}
+
+		s.privateKey = privateKey
+	}
+
+	client.mu.Lock()
+	client.signingPubKey = base64.StdEncoding.EncodeToString(s.PublicKey())
+	client.mu.Unlock()
+
+	s.idle = sync.NewCond(&s.mu)
+	s.removeListener = client.OnChange(s.enqueue)
+
+	s.routes()
+
+	return s
+}
+
+// PublicKey returns the key that verifies the server's webhooks, as passed to elation.VerifyWebhook. New subscriptions
+// also return it base64 encoded as their SigningPubKey.
+func (s *Server) PublicKey() ed25519.PublicKey {
+	//nolint:forcetypeassert
+	return s.privateKey.Public().(ed25519.PublicKey)
+}
+
+// Wait blocks until every webhook for the changes made so far has been delivered.
+func (s *Server) Wait() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for s.sending {
+		s.idle.Wait()
+	}
+}
+
+// Close stops the server from sending webhooks for later changes and waits for the pending ones to be delivered. It
+// does not close the client.
+func (s *Server) Close() {
+	s.removeListener()
+
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	s.Wait()
+}
+
+// Deliveries returns the webhooks sent so far, in order. Call Wait first to include the webhooks of the latest changes.
+func (s *Server) Deliveries() []*Delivery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]*Delivery, len(s.deliveries))
+	copy(out, s.deliveries)
+
+	return out
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/token" && r.Header.Get("Authorization") != "Bearer "+serverAccessToken {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"detail": "Authentication credentials were not provided."})
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) routes() {
+	c := s.client
+	mux := s.mux
+
+	mux.HandleFunc("POST /token", s.token)
+
+	mux.Handle("GET /allergies", handleFind(c.Allergies().Find))
+	mux.Handle("GET /allergies/{id}", handleGet(c.Allergies().Get))
+
+	mux.Handle("GET /allergy_documentation", handleFind(c.AllergyDocumentation().Find))
+	mux.Handle("GET /allergy_documentation/{id}", handleGet(c.AllergyDocumentation().Get))
+
+	mux.Handle("POST /appointments", handleCreate(c.Appointments().Create))
+	mux.Handle("GET /appointments", handleFind(c.Appointments().Find))
+	mux.Handle("GET /appointments/{id}", handleGet(c.Appointments().Get))
+	mux.Handle("PATCH /appointments/{id}", handleUpdate(c.Appointments().Update))
+	mux.Handle("DELETE /appointments/{id}", handleDelete(c.Appointments().Delete))
+
+	mux.Handle("POST /bills", handleCreate(c.Bill().Create))
+	mux.Handle("GET /bills", handleFind(c.Bill().Find))
+	mux.Handle("GET /bills/{id}", handleGet(c.Bill().Get))
+	mux.Handle("PATCH /bills/{id}", handleUpdate(c.Bill().Update))
+	mux.Handle("DELETE /bills/{id}", handleDelete(c.Bill().Delete))
+
+	mux.Handle("GET /clinical_documents", handleFind(c.ClinicalDocuments().Find))
+	mux.Handle("GET /clinical_documents/{id}", handleGet(c.ClinicalDocuments().Get))
+
+	mux.Handle("GET /contacts", handleFind(c.Contacts().List))
+	mux.Handle("GET /contacts/{id}", handleGet(c.Contacts().Get))
+
+	mux.Handle("POST /discontinued_medications", handleCreate(c.DiscontinuedMedications().Create))
+	mux.Handle("GET /discontinued_medications", handleFind(c.DiscontinuedMedications().Find))
+	mux.Handle("GET /discontinued_medications/{id}", handleGet(c.DiscontinuedMedications().Get))
+
+	mux.Handle("GET /medication_history_download_fills", handleFind(c.HistoryDownloadFills().Find))
+	mux.Handle("GET /medication_history_download_fills/{id}", handleGet(c.HistoryDownloadFills().Get))
+
+	mux.Handle("POST /insurance_companies", handleCreate(c.InsuranceCompanies().Create))
+	mux.Handle("GET /insurance_companies", handleFind(c.InsuranceCompanies().Find))
+	mux.Handle("GET /insurance_companies/{id}", handleGet(c.InsuranceCompanies().Get))
+	mux.Handle("PATCH /insurance_companies/{id}", handleUpdate(c.InsuranceCompanies().Update))
+	mux.Handle("DELETE /insurance_companies/{id}", handleDelete(c.InsuranceCompanies().Delete))
+
+	mux.Handle("POST /insurance_plans", handleCreate(c.InsurancePlans().Create))
+	mux.Handle("GET /insurance_plans", handleFind(c.InsurancePlans().Find))
+	mux.Handle("GET /insurance_plans/{id}", handleGet(c.InsurancePlans().Get))
+	mux.Handle("PATCH /insurance_plans/{id}", handleUpdate(c.InsurancePlans().Update))
+	mux.Handle("DELETE /insurance_plans/{id}", handleDelete(c.InsurancePlans().Delete))
+
+	mux.Handle("POST /patients/{patient_id}/policies", serve(func(r *http.Request) (*elation.InsurancePolicy, *http.Response, error) {
+		patientID, err := pathID(r, "patient_id")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		create := &elation.InsurancePolicyCreate{}
+		if err := decodeBody(r, create); err != nil {
+			return nil, nil, err
+		}
+
+		return c.InsurancePolicies().Create(r.Context(), patientID, create)
+	}))
+	mux.Handle("GET /patients/{patient_id}/policies", serve(func(r *http.Request) (*elation.FindInsurancePoliciesResponse, *http.Response, error) {
+		patientID, err := pathID(r, "patient_id")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		opts := &elation.FindInsurancePoliciesOptions{}
+		if err := decodeQuery(r, opts); err != nil {
+			return nil, nil, err
+		}
+
+		res, httpRes, err := c.InsurancePolicies().Find(r.Context(), patientID, opts)
+		if err == nil {
+			rebasePages(r, res)
+		}
+
+		return res, httpRes, err
+	}))
+	mux.Handle("GET /patients/{patient_id}/policies/{id}", serve(func(r *http.Request) (*elation.InsurancePolicy, *http.Response, error) {
+		patientID, id, err := policyIDs(r)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return c.InsurancePolicies().Get(r.Context(), patientID, id)
+	}))
+	mux.Handle("PUT /patients/{patient_id}/policies/{id}", serve(func(r *http.Request) (*elation.InsurancePolicy, *http.Response, error) {
+		patientID, id, err := policyIDs(r)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		update := &elation.InsurancePolicyUpdate{}
+		if err := decodeBody(r, update); err != nil {
+			return nil, nil, err
+		}
+
+		return c.InsurancePolicies().Update(r.Context(), patientID, id, update)
+	}))
+	mux.Handle("DELETE /patients/{patient_id}/policies/{id}", serveNoContent(func(r *http.Request) (*http.Response, error) {
+		patientID, id, err := policyIDs(r)
+		if err != nil {
+			return nil, err
+		}
+
+		return c.InsurancePolicies().Delete(r.Context(), patientID, id)
+	}))
+
+	mux.Handle("POST /patient_insurances/{id}/eligibility/", serve(func(r *http.Request) (*elation.InsuranceEligibility, *http.Response, error) {
+		id, err := pathID(r, "id")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		create := &elation.InsuranceEligibilityCreate{}
+		if err := decodeBody(r, create); err != nil {
+			return nil, nil, err
+		}
+
+		return c.InsuranceEligibility().Create(r.Context(), id, create)
+	}))
+	mux.Handle("GET /patient_insurances/{id}/eligibility/", handleGet(c.InsuranceEligibility().Get))
+	mux.Handle("GET /patient_insurances/{id}/eligibility_full_report/", handleGet(c.InsuranceEligibility().GetFullReport))
+
+	mux.Handle("GET /letters", handleFind(c.Letters().Find))
+	mux.Handle("GET /letters/{id}", handleGet(c.Letters().Get))
+
+	mux.Handle("POST /medications", handleCreate(c.Medications().Create))
+	mux.Handle("GET /medications", handleFind(c.Medications().Find))
+	mux.Handle("GET /medications/{id}", handleGet(c.Medications().Get))
+
+	mux.Handle("GET /message_threads", handleFind(c.MessageThreads().Find))
+	mux.Handle("GET /message_threads/{id}", handleGet(c.MessageThreads().Get))
+
+	mux.Handle("POST /non_visit_notes", handleCreate(c.NonVisitNotes().Create))
+	mux.Handle("GET /non_visit_notes", handleFind(c.NonVisitNotes().Find))
+	mux.Handle("GET /non_visit_notes/{id}", handleGet(c.NonVisitNotes().Get))
+
+	mux.Handle("POST /patients", handleCreate(c.Patients().Create))
+	mux.Handle("GET /patients", handleFind(c.Patients().Find))
+	mux.Handle("GET /patients/{id}", handleGet(c.Patients().Get))
+	mux.Handle("PATCH /patients/{id}", handleUpdate(c.Patients().Update))
+	mux.Handle("DELETE /patients/{id}", handleDelete(c.Patients().Delete))
+
+	mux.Handle("GET /pharmacies/{ncpdpid}", serve(func(r *http.Request) (*elation.Pharmacy, *http.Response, error) {
+		return c.Pharmacies().Get(r.Context(), r.PathValue("ncpdpid"))
+	}))
+
+	mux.Handle("GET /physicians", handleFind(c.Physicians().Find))
+	mux.Handle("GET /physicians/{id}", handleGet(c.Physicians().Get))
+
+	mux.Handle("GET /practices", handleFind(c.Practices().Find))
+	mux.Handle("GET /practices/{id}", handleGet(c.Practices().Get))
+
+	mux.Handle("GET /prescription_fills", handleFind(c.PrescriptionFills().Find))
+	mux.Handle("GET /prescription_fills/{id}", handleGet(c.PrescriptionFills().Get))
+
+	mux.Handle("GET /problems", handleFind(c.Problems().Find))
+	mux.Handle("GET /problems/{id}", handleGet(c.Problems().Get))
+
+	mux.Handle("POST /recurring_event_groups", handleCreate(c.RecurringEventGroups().Create))
+	mux.Handle("GET /recurring_event_groups", handleFind(c.RecurringEventGroups().Find))
+	mux.Handle("GET /recurring_event_groups/{id}", handleGet(c.RecurringEventGroups().Get))
+	mux.Handle("PATCH /recurring_event_groups/{id}", handleUpdate(c.RecurringEventGroups().Update))
+	mux.Handle("DELETE /recurring_event_groups/{id}", handleDelete(c.RecurringEventGroups().Delete))
+
+	mux.Handle("GET /service_locations", handleFind(c.ServiceLocations().Find))
+
+	mux.Handle("POST /app/subscriptions/", handleCreate(c.Subscriptions().Subscribe))
+	mux.Handle("GET /app/subscriptions/", serve(func(r *http.Request) (*elation.Response[[]*elation.Subscription], *http.Response, error) {
+		subscriptions, res, err := c.Subscriptions().Find(r.Context())
+		if err != nil {
+			return nil, res, err
+		}
+
+		return &elation.Response[[]*elation.Subscription]{Count: len(subscriptions), Results: subscriptions}, res, nil
+	}))
+	mux.Handle("DELETE /app/subscriptions/{id}/", handleDelete(c.Subscriptions().Delete))
+
+	mux.Handle("GET /thread_members", handleFind(c.ThreadMembers().Find))
+	mux.Handle("GET /thread_members/{id}", handleGet(c.ThreadMembers().Get))
+
+	mux.Handle("POST /visit_notes", handleCreate(c.VisitNote().Create))
+	mux.Handle("GET /visit_notes", handleFind(c.VisitNote().Find))
+	mux.Handle("GET /visit_notes/{id}", handleGet(c.VisitNote().Get))
+	mux.Handle("DELETE /visit_notes/{id}", handleDelete(c.VisitNote().Delete))
+}
+
+// token implements the OAuth client credentials grant, accepting credentials in the Authorization header or the form.
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	if r.PostForm.Get("grant_type") != "client_credentials" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientID = r.PostForm.Get("client_id")
+		clientSecret = r.PostForm.Get("client_secret")
+	}
+
+	if s.clientID != "" && (clientID != s.clientID || clientSecret != s.clientSecret) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": serverAccessToken,
+		"token_type":   "Bearer",
+		"expires_in":   36000,
+	})
+}
+
+// send delivers a webhook for change to every subscription to its resource.
+// enqueue queues the webhooks for a change. They are sent in order by a separate goroutine, so that the API request
+// that made the change returns without waiting for the targets, and targets can call back into the server.
+func (s *Server) enqueue(change Change) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	s.pending = append(s.pending, change)
+
+	if !s.sending {
+		s.sending = true
+		go s.sendPending()
+	}
+}
+
+func (s *Server) sendPending() {
+	for {
+		s.mu.Lock()
+		if len(s.pending) == 0 {
+			s.sending = false
+			s.idle.Broadcast()
+			s.mu.Unlock()
+
+			return
+		}
+
+		change := s.pending[0]
+		s.pending = s.pending[1:]
+		s.mu.Unlock()
+
+		s.send(change)
+	}
+}
+
+func (s *Server) send(change Change) {
+	s.client.mu.Lock()
+	subscriptions := s.client.subscriptions.list(func(subscription *elation.Subscription, _ time.Time) bool {
+		return subscription.Resource == change.Resource && subscription.DeletedDate == nil
+	})
+	s.client.mu.Unlock()
+
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	var data []byte
+	var err error
+	if change.Action == elation.WebhookEventActionDeleted {
+		data, err = json.Marshal(map[string]int64{"id": change.ID})
+	} else {
+		data, err = json.Marshal(change.Data)
+	}
+
+	for _, subscription := range subscriptions {
+		s.mu.Lock()
+		s.eventID++
+		event := &elation.Event{
+			Data:          data,
+			Action:        change.Action,
+			EventID:       s.eventID,
+			ApplicationID: s.applicationID,
+			Resource:      change.Resource,
+		}
+		s.mu.Unlock()
+
+		delivery := &Delivery{
+			Event:  event,
+			Target: subscription.Target,
+			Err:    err,
+		}
+
+		if delivery.Err == nil {
+			delivery.StatusCode, delivery.Err = s.deliver(subscription.Target, event)
+		}
+
+		s.mu.Lock()
+		s.deliveries = append(s.deliveries, delivery)
+		s.mu.Unlock()
+	}
+}
+
+func (s *Server) deliver(target string, event *elation.Event) (int, error) {
+	body, signature, err := elation.SignWebhook(s.privateKey, event)
+	if err != nil {
+		return 0, fmt.Errorf("signing event: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("making new HTTP request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(elation.WebhookSignatureHeader, signature)
+
+	res, err := s.webhookClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("doing HTTP request: %w", err)
+	}
+	defer res.Body.Close() //nolint
+
+	//nolint
+	io.Copy(io.Discard, res.Body)
+
+	return res.StatusCode, nil
+}
+
+func handleFind[O, T any](find func(context.Context, *O) (*elation.Response[[]*T], *http.Response, error)) http.Handler {
+	return serve(func(r *http.Request) (*elation.Response[[]*T], *http.Response, error) {
+		opts := new(O)
+		if err := decodeQuery(r, opts); err != nil {
+			return nil, nil, err
+		}
+
+		res, httpRes, err := find(r.Context(), opts)
+		if err == nil {
+			rebasePages(r, res)
+		}
+
+		return res, httpRes, err
+	})
+}
+
+func handleGet[T any](get func(context.Context, int64) (*T, *http.Response, error)) http.Handler {
+	return serve(func(r *http.Request) (*T, *http.Response, error) {
+		id, err := pathID(r, "id")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return get(r.Context(), id)
+	})
+}
+
+func handleCreate[C, T any](create func(context.Context, *C) (*T, *http.Response, error)) http.Handler {
+	return serve(func(r *http.Request) (*T, *http.Response, error) {
+		body := new(C)
+		if err := decodeBody(r, body); err != nil {
+			return nil, nil, err
+		}
+
+		return create(r.Context(), body)
+	})
+}
+
+func handleUpdate[U, T any](update func(context.Context, int64, *U) (*T, *http.Response, error)) http.Handler {
+	return serve(func(r *http.Request) (*T, *http.Response, error) {
+		id, err := pathID(r, "id")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		body := new(U)
+		if err := decodeBody(r, body); err != nil {
+			return nil, nil, err
+		}
+
+		return update(r.Context(), id, body)
+	})
+}
+
+func handleDelete(remove func(context.Context, int64) (*http.Response, error)) http.Handler {
+	return serveNoContent(func(r *http.Request) (*http.Response, error) {
+		id, err := pathID(r, "id")
+		if err != nil {
+			return nil, err
+		}
+
+		return remove(r.Context(), id)
+	})
+}
+
+func serve[T any](fn func(r *http.Request) (T, *http.Response, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		out, res, err := fn(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, res.StatusCode, out)
+	})
+}
+
+func serveNoContent(fn func(r *http.Request) (*http.Response, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, err := fn(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(res.StatusCode)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	//nolint
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes API errors with their status code and body. Any other error, such as one injected with FailNext,
+// is a server error.
+func writeError(w http.ResponseWriter, err error) {
+	var apiErr *elation.Error
+	if !errors.As(err, &apiErr) {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"detail": err.Error()})
+		return
+	}
+
+	body := apiErr.Body
+	if body == "" {
+		b, _ := json.Marshal(map[string]string{"detail": http.StatusText(apiErr.StatusCode)})
+		body = string(b)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(apiErr.StatusCode)
+
+	//nolint
+	io.WriteString(w, body)
+}
+
+func pathID(r *http.Request, name string) (int64, error) {
+	id, err := strconv.ParseInt(r.PathValue(name), 10, 64)
+	if err != nil {
+		return 0, notFound(r.Method, r.URL.Path)
+	}
+
+	return id, nil
+}
+
+func policyIDs(r *http.Request) (int64, int64, error) {
+	patientID, err := pathID(r, "patient_id")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	id, err := pathID(r, "id")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return patientID, id, nil
+}
+
+// rebasePages points the next and previous links of res at the server handling r.
+func rebasePages[T any](r *http.Request, res *elation.Response[T]) {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	base := scheme + "://" + r.Host
+
+	if res.Next != "" {
+		res.Next = base + strings.TrimPrefix(res.Next, baseURL)
+	}
+
+	if res.Previous != "" {
+		res.Previous = base + strings.TrimPrefix(res.Previous, baseURL)
+	}
+}
+
+func decodeBody(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return badRequest(r.Method, r.URL.Path, "non_field_errors", "JSON parse error - "+err.Error())
+	}
+
+	return nil
+}
+
+// decodeQuery sets the fields of the options struct v from the query of r, reversing the encoding of
+// github.com/google/go-querystring for the field types used by Find options.
+func decodeQuery(r *http.Request, v any) error {
+	err := decodeQueryStruct(r.URL.Query(), reflect.ValueOf(v).Elem())
+	if err != nil {
+		return badRequest(r.Method, r.URL.Path, "non_field_errors", err.Error())
+	}
+
+	return nil
+}
+
+func decodeQueryStruct(q url.Values, v reflect.Value) error {
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("url")
+		if tag == "-" {
+			continue
+		}
+
+		value := v.Field(i)
+
+		// Embedded structs, such as *Pagination, are flattened into the query.
+		if field.Anonymous && tag == "" {
+			if value.Kind() == reflect.Pointer {
+				value.Set(reflect.New(field.Type.Elem()))
+				value = value.Elem()
+			}
+
+			if err := decodeQueryStruct(q, value); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if values := q[name]; len(values) > 0 {
+			if err := decodeQueryValue(value, values); err != nil {
+				return fmt.Errorf("invalid %s: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func decodeQueryValue(v reflect.Value, values []string) error {
+	if v.Type() == reflect.TypeFor[time.Time]() {
+		t, err := time.Parse(time.RFC3339, values[0])
+		if err != nil {
+			return err
+		}
+
+		v.Set(reflect.ValueOf(t))
+
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		v.Set(reflect.New(v.Type().Elem()))
+		return decodeQueryValue(v.Elem(), values)
+
+	case reflect.Slice:
+		// Slices are encoded as repeated parameters, or as one comma separated parameter with the comma option.
+		var parts []string
+		for _, value := range values {
+			parts = append(parts, strings.Split(value, ",")...)
+		}
+
+		slice := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := decodeQueryValue(slice.Index(i), []string{part}); err != nil {
+				return err
+			}
+		}
+
+		v.Set(slice)
+
+	case reflect.String:
+		v.SetString(values[0])
+
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(values[0], 10, 64)
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(n)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(values[0])
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(b)
+
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
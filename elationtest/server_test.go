@@ -0,0 +1,296 @@
+package elationtest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer(t *testing.T) {
+	assert := assert.New(t)
+
+	client := NewClient()
+	Seed(client, &elation.ServiceLocation{ID: 50, Name: "Main Office"})
+
+	server := NewServer(client, WithCredentials("id", "secret"), WithApplicationID("app"))
+	defer server.Close()
+
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	var mu sync.Mutex
+	var events []*elation.Event
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event, err := elation.VerifyWebhook(r, server.PublicKey())
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+	}))
+	defer receiver.Close()
+
+	httpClient := elation.NewHTTPClient(srv.Client(), srv.URL+"/token", "id", "secret", srv.URL)
+	ctx := context.Background()
+
+	subscription, _, err := httpClient.Subscriptions().Subscribe(ctx, &elation.Subscribe{
+		Resource: elation.ResourcePatients,
+		Target:   receiver.URL,
+	})
+	assert.NoError(err)
+	assert.Equal(base64.StdEncoding.EncodeToString(server.PublicKey()), subscription.SigningPubKey)
+
+	patient, res, err := httpClient.Patients().Create(ctx, &elation.PatientCreate{FirstName: "Jane", LastName: "Doe"})
+	assert.NoError(err)
+	assert.Equal(http.StatusCreated, res.StatusCode)
+
+	updated, _, err := httpClient.Patients().Update(ctx, patient.ID, &elation.PatientUpdate{FirstName: new("Janet")})
+	assert.NoError(err)
+	assert.Equal("Janet", updated.FirstName)
+
+	found, _, err := httpClient.Patients().Find(ctx, &elation.FindPatientsOptions{LastName: "Doe"})
+	assert.NoError(err)
+	assert.Len(found.Results, 1)
+
+	res, err = httpClient.Patients().Delete(ctx, patient.ID)
+	assert.NoError(err)
+	assert.Equal(http.StatusNoContent, res.StatusCode)
+
+	_, _, err = httpClient.Patients().Get(ctx, patient.ID)
+	assert.True(elation.IsNotFound(err))
+
+	// Appointments are not subscribed to.
+	_, _, err = httpClient.Appointments().Create(ctx, &elation.AppointmentCreate{ServiceLocation: new(int64(50))})
+	assert.NoError(err)
+
+	server.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Len(events, 3)
+	assert.Equal(elation.WebhookEventActionSaved, events[0].Action)
+	assert.Equal(elation.WebhookEventActionSaved, events[1].Action)
+	assert.Equal(elation.WebhookEventActionDeleted, events[2].Action)
+
+	received := &elation.Patient{}
+	assert.NoError(json.Unmarshal(events[1].Data, received))
+	assert.Equal("Janet", received.FirstName)
+	assert.JSONEq(fmt.Sprintf(`{"id":%d}`, patient.ID), string(events[2].Data))
+
+	for i, event := range events {
+		assert.Equal(int64(i+1), event.EventID)
+		assert.Equal("app", event.ApplicationID)
+		assert.Equal(elation.ResourcePatients, event.Resource)
+	}
+
+	deliveries := server.Deliveries()
+	assert.Len(deliveries, 3)
+	assert.Equal(http.StatusOK, deliveries[0].StatusCode)
+}
+
+func TestServer_Find(t *testing.T) {
+	assert := assert.New(t)
+
+	client := NewClient()
+	for range 12 {
+		Seed(client, &elation.Appointment{Physician: 10, ScheduledDate: time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)})
+	}
+	Seed(client, &elation.Appointment{Physician: 20, ScheduledDate: time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)})
+	Seed(client, &elation.Appointment{Physician: 10, ScheduledDate: time.Date(2024, 2, 2, 9, 0, 0, 0, time.UTC)})
+
+	srv := httptest.NewServer(NewServer(client))
+	defer srv.Close()
+
+	httpClient := elation.NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+	opts := &elation.FindAppointmentsOptions{
+		Pagination: &elation.Pagination{Limit: 5},
+		Physician:  []int64{10},
+		FromDate:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		ToDate:     time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	res, _, err := httpClient.Appointments().Find(context.Background(), opts)
+	assert.NoError(err)
+	assert.Equal(12, res.Count)
+
+	next, err := url.Parse(res.Next)
+	assert.NoError(err)
+	assert.Equal(srv.URL, "http://"+next.Host)
+
+	count := 0
+	for appointment, err := range elation.All(context.Background(), httpClient.Appointments().Find, opts) {
+		assert.NoError(err)
+		assert.Equal(int64(10), appointment.Physician)
+		count++
+	}
+
+	assert.Equal(12, count)
+}
+
+func TestServer_errors(t *testing.T) {
+	testCases := map[string]struct {
+		do                 func(ctx context.Context, client elation.Client) error
+		expectedStatusCode int
+	}{
+		"not found": {
+			do: func(ctx context.Context, client elation.Client) error {
+				_, _, err := client.Appointments().Get(ctx, 1)
+				return err
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		"validation": {
+			do: func(ctx context.Context, client elation.Client) error {
+				_, _, err := client.Subscriptions().Subscribe(ctx, &elation.Subscribe{Resource: elation.ResourcePatients})
+				return err
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"injected": {
+			do: func(ctx context.Context, client elation.Client) error {
+				_, _, err := client.Patients().Find(ctx, nil)
+				return err
+			},
+			expectedStatusCode: http.StatusServiceUnavailable,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			client := NewClient()
+			client.FailNext("Patients.Find", &elation.Error{StatusCode: http.StatusServiceUnavailable})
+
+			srv := httptest.NewServer(NewServer(client))
+			defer srv.Close()
+
+			httpClient := elation.NewClient(
+				elation.WithHTTPClient(srv.Client()),
+				elation.WithClientCredentials(srv.URL+"/token", "", ""),
+				elation.WithBaseURL(srv.URL),
+				elation.WithRetryPolicy(nil),
+			)
+
+			err := testCase.do(context.Background(), httpClient)
+
+			apiErr := &elation.Error{}
+			assert.ErrorAs(err, &apiErr)
+			assert.Equal(testCase.expectedStatusCode, apiErr.StatusCode)
+		})
+	}
+}
+
+func TestServer_token(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := httptest.NewServer(NewServer(NewClient(), WithCredentials("id", "secret")))
+	defer srv.Close()
+
+	httpClient := elation.NewHTTPClient(srv.Client(), srv.URL+"/token", "id", "wrong", srv.URL)
+
+	_, _, err := httpClient.Patients().Find(context.Background(), nil)
+	assert.Error(err)
+
+	res, err := srv.Client().Get(srv.URL + "/patients")
+	assert.NoError(err)
+	assert.Equal(http.StatusUnauthorized, res.StatusCode)
+	assert.NoError(res.Body.Close())
+}
+
+func TestServer_Close(t *testing.T) {
+	assert := assert.New(t)
+
+	var mu sync.Mutex
+	var received int
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received++
+		mu.Unlock()
+	}))
+	defer receiver.Close()
+
+	client := NewClient()
+	Seed(client, &elation.Subscription{Resource: elation.ResourcePatients, Target: receiver.URL})
+
+	closed := NewServer(client)
+	closed.Close()
+
+	server := NewServer(client)
+	defer server.Close()
+
+	ctx := context.Background()
+
+	_, _, err := client.Patients().Create(ctx, &elation.PatientCreate{FirstName: "Jane"})
+	assert.NoError(err)
+
+	server.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Equal(1, received)
+	assert.Empty(closed.Deliveries())
+	assert.Len(server.Deliveries(), 1)
+}
+
+func TestServer_webhook_calls_server(t *testing.T) {
+	assert := assert.New(t)
+
+	client := NewClient()
+
+	server := NewServer(client)
+	defer server.Close()
+
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	httpClient := elation.NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+	var mu sync.Mutex
+	var names []string
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event, err := elation.VerifyWebhook(r, server.PublicKey())
+		if !assert.NoError(err) {
+			return
+		}
+
+		data := &elation.Patient{}
+		assert.NoError(json.Unmarshal(event.Data, data))
+
+		// Read the patient back through the API while its webhook is being delivered.
+		patient, _, err := httpClient.Patients().Get(r.Context(), data.ID)
+		if assert.NoError(err) {
+			mu.Lock()
+			names = append(names, patient.FirstName)
+			mu.Unlock()
+		}
+	}))
+	defer receiver.Close()
+
+	Seed(client, &elation.Subscription{Resource: elation.ResourcePatients, Target: receiver.URL})
+
+	_, _, err := httpClient.Patients().Create(context.Background(), &elation.PatientCreate{FirstName: "Jane"})
+	assert.NoError(err)
+
+	server.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Equal([]string{"Jane"}, names)
+}
@@ -0,0 +1,226 @@
+package elation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrServer       = errors.New("server error")
+)
+
+var requestIDHeaders = []string{
+	"X-Request-Id",
+	"X-Amzn-Trace-Id",
+}
+
+// Error is returned for every response with a status code of 400 or greater. It matches the sentinel errors above with
+// errors.Is based on its status code, and unwraps to a *ValidationError when the response body could be parsed and to
+// Err when it is set.
+type Error struct {
+	StatusCode int
+	Body       string
+
+	Method    string
+	Path      string // The URL path without the query string, which may contain PHI.
+	RequestID string
+
+	Validation *ValidationError
+
+	// Err is the reason the client refused a request without sending it, such as ErrInvalidStatusTransition.
+	Err error
+}
+
+func newError(res *http.Response, body []byte) *Error {
+	e := &Error{
+		StatusCode: res.StatusCode,
+		Body:       string(body),
+		Validation: parseValidationError(body),
+	}
+
+	if res.Request != nil {
+		e.Method = res.Request.Method
+		e.Path = res.Request.URL.Path
+	}
+
+	for _, header := range requestIDHeaders {
+		if v := res.Header.Get(header); v != "" {
+			e.RequestID = v
+			break
+		}
+	}
+
+	return e
+}
+
+func (e Error) Error() string {
+	msg := fmt.Sprintf("API error (status code %d)", e.StatusCode)
+
+	if e.Method != "" {
+		msg += fmt.Sprintf(" from %s %s", e.Method, e.Path)
+	}
+
+	if e.Validation != nil {
+		msg += ": " + e.Validation.Error()
+	}
+
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+
+	return msg
+}
+
+func (e Error) Unwrap() []error {
+	var errs []error
+	if e.Validation != nil {
+		errs = append(errs, e.Validation)
+	}
+
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+
+	return errs
+}
+
+func (e Error) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= http.StatusInternalServerError
+	default:
+		return false
+	}
+}
+
+// ValidationError is the structured form of an error response body. Elation returns field errors as a map of field
+// name to messages, alongside optional "detail" and "non_field_errors" keys.
+type ValidationError struct {
+	Detail         string
+	NonFieldErrors []string
+	Fields         map[string][]string
+}
+
+func (e *ValidationError) Error() string {
+	var parts []string
+
+	if e.Detail != "" {
+		parts = append(parts, e.Detail)
+	}
+
+	parts = append(parts, e.NonFieldErrors...)
+
+	fields := make([]string, 0, len(e.Fields))
+	for field := range e.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		parts = append(parts, field+": "+strings.Join(e.Fields[field], ", "))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// HasFieldError reports whether the given field has an error with the given message.
+func (e *ValidationError) HasFieldError(field, message string) bool {
+	return slices.Contains(e.Fields[field], message)
+}
+
+func parseValidationError(body []byte) *ValidationError {
+	raw := map[string]json.RawMessage{}
+	err := json.Unmarshal(body, &raw)
+	if err != nil || len(raw) == 0 {
+		return nil
+	}
+
+	e := &ValidationError{
+		Fields: map[string][]string{},
+	}
+
+	for key, value := range raw {
+		switch key {
+		case "detail":
+			e.Detail = strings.Join(parseMessages(value), " ")
+		case "non_field_errors":
+			e.NonFieldErrors = parseMessages(value)
+		default:
+			e.Fields[key] = parseMessages(value)
+		}
+	}
+
+	return e
+}
+
+// parseMessages accepts a single message, a list of messages or any nested JSON value, which is kept as-is.
+func parseMessages(value json.RawMessage) []string {
+	var message string
+	if json.Unmarshal(value, &message) == nil {
+		return []string{message}
+	}
+
+	var messages []string
+	if json.Unmarshal(value, &messages) == nil {
+		return messages
+	}
+
+	return []string{string(value)}
+}
+
+func IsBadRequest(err error) bool {
+	return errors.Is(err, ErrBadRequest)
+}
+
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// IsRetryable reports whether err is an API error with a status code that the retry policy would retry.
+func IsRetryable(err error) bool {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return isRetryableStatus(apiErr.StatusCode)
+}
@@ -0,0 +1,184 @@
+package elation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_Is(t *testing.T) {
+	testCases := map[string]struct {
+		statusCode int
+		expected   error
+		is         func(error) bool
+	}{
+		"bad request": {
+			statusCode: http.StatusBadRequest,
+			expected:   ErrBadRequest,
+			is:         IsBadRequest,
+		},
+		"unauthorized": {
+			statusCode: http.StatusUnauthorized,
+			expected:   ErrUnauthorized,
+			is:         IsUnauthorized,
+		},
+		"forbidden": {
+			statusCode: http.StatusForbidden,
+			expected:   ErrForbidden,
+			is:         IsForbidden,
+		},
+		"not found": {
+			statusCode: http.StatusNotFound,
+			expected:   ErrNotFound,
+			is:         IsNotFound,
+		},
+		"conflict": {
+			statusCode: http.StatusConflict,
+			expected:   ErrConflict,
+			is:         IsConflict,
+		},
+		"rate limited": {
+			statusCode: http.StatusTooManyRequests,
+			expected:   ErrRateLimited,
+			is:         IsRateLimited,
+		},
+		"server error": {
+			statusCode: http.StatusBadGateway,
+			expected:   ErrServer,
+			is:         IsRetryable,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			err := fmt.Errorf("making request: %w", &Error{StatusCode: testCase.statusCode})
+
+			assert.ErrorIs(err, testCase.expected)
+			assert.True(testCase.is(err))
+
+			for _, other := range []error{ErrBadRequest, ErrUnauthorized, ErrForbidden, ErrNotFound, ErrConflict, ErrRateLimited, ErrServer} {
+				if other != testCase.expected {
+					assert.NotErrorIs(err, other)
+				}
+			}
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(IsRetryable(&Error{StatusCode: http.StatusTooManyRequests}))
+	assert.True(IsRetryable(&Error{StatusCode: http.StatusServiceUnavailable}))
+	assert.False(IsRetryable(&Error{StatusCode: http.StatusBadRequest}))
+	assert.False(IsRetryable(errors.New("foo")))
+	assert.False(IsRetryable(nil))
+}
+
+func TestParseValidationError(t *testing.T) {
+	testCases := map[string]struct {
+		body     string
+		expected *ValidationError
+	}{
+		"field errors": {
+			body: `{"first_name":["This field is required."],"dob":"Invalid date."}`,
+			expected: &ValidationError{
+				Fields: map[string][]string{
+					"first_name": {"This field is required."},
+					"dob":        {"Invalid date."},
+				},
+			},
+		},
+		"detail": {
+			body: `{"detail":"Not found."}`,
+			expected: &ValidationError{
+				Detail: "Not found.",
+				Fields: map[string][]string{},
+			},
+		},
+		"non field errors": {
+			body: `{"non_field_errors":["Patient and practice do not match."]}`,
+			expected: &ValidationError{
+				NonFieldErrors: []string{"Patient and practice do not match."},
+				Fields:         map[string][]string{},
+			},
+		},
+		"nested field errors": {
+			body: `{"address":{"zip":["Invalid zip."]}}`,
+			expected: &ValidationError{
+				Fields: map[string][]string{
+					"address": {`{"zip":["Invalid zip."]}`},
+				},
+			},
+		},
+		"not JSON": {
+			body: `<html></html>`,
+		},
+		"empty": {
+			body: ``,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			assert.Equal(testCase.expected, parseValidationError([]byte(testCase.body)))
+		})
+	}
+}
+
+func TestValidationError_Error(t *testing.T) {
+	assert := assert.New(t)
+
+	err := &ValidationError{
+		Detail:         "Invalid input.",
+		NonFieldErrors: []string{"foo"},
+		Fields: map[string][]string{
+			"last_name":  {"bar"},
+			"first_name": {"baz", "qux"},
+		},
+	}
+
+	assert.Equal("Invalid input.; foo; first_name: baz, qux; last_name: bar", err.Error())
+}
+
+func TestHTTPClient_request_error(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "request-id")
+		w.WriteHeader(http.StatusBadRequest)
+		//nolint
+		w.Write([]byte(`{"first_name":["This field is required."]}`))
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+	_, err := client.request(context.Background(), http.MethodPost, "/patients", &FindPatientsOptions{LastName: "Doe"}, nil, nil)
+	assert.ErrorIs(err, ErrBadRequest)
+
+	apiErr := &Error{}
+	assert.ErrorAs(err, &apiErr)
+	assert.Equal(http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(http.MethodPost, apiErr.Method)
+	assert.Equal("/patients", apiErr.Path)
+	assert.Equal("request-id", apiErr.RequestID)
+	assert.Equal(`{"first_name":["This field is required."]}`, apiErr.Body)
+	assert.Equal("API error (status code 400) from POST /patients: first_name: This field is required.", apiErr.Error())
+
+	validationErr := &ValidationError{}
+	assert.ErrorAs(err, &validationErr)
+	assert.True(validationErr.HasFieldError("first_name", "This field is required."))
+}
@@ -0,0 +1,448 @@
+package elation
+
+import (
+	"bufio"
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrEventInProgress is returned by WebhookHandler.Handle for an event that another call is processing, such as a
+// concurrent redelivery. The error response makes Elation retry the event, which is then either a duplicate or, if
+// processing failed, processed again.
+var ErrEventInProgress = errors.New("event is being processed")
+
+type EventStatus int
+
+const (
+	// EventStatusNew is an event that has not been processed.
+	EventStatusNew EventStatus = iota
+
+	// EventStatusDuplicate is an event whose EventID has already been processed.
+	EventStatusDuplicate
+
+	// EventStatusOutOfOrder is an event for a resource ID that has already processed an event with a greater EventID,
+	// so its data is older than what has already been applied.
+	EventStatusOutOfOrder
+
+	// EventStatusInProgress is an event whose EventID has been claimed but not yet recorded or released.
+	EventStatusInProgress
+)
+
+func (s EventStatus) String() string {
+	switch s {
+	case EventStatusNew:
+		return "new"
+	case EventStatusDuplicate:
+		return "duplicate"
+	case EventStatusOutOfOrder:
+		return "out of order"
+	case EventStatusInProgress:
+		return "in progress"
+	default:
+		return fmt.Sprintf("EventStatus(%d)", int(s))
+	}
+}
+
+// EventStore remembers processed webhook events to detect redelivered and out-of-order events. An event is claimed
+// before it is processed, so that concurrent deliveries of it are not processed twice. It is then recorded once it has
+// been processed, or released so that it is processed again when Elation retries it.
+type EventStore interface {
+	// Claim returns the status of event. New and out-of-order events are claimed until Record or Release is called
+	// with them; until then, Claim returns EventStatusInProgress for events with the same EventID.
+	Claim(ctx context.Context, event *Event) (EventStatus, error)
+
+	// Record records event as processed and releases its claim, even if recording fails.
+	Record(ctx context.Context, event *Event) error
+
+	// Release releases the claim on event without recording it.
+	Release(ctx context.Context, event *Event) error
+}
+
+// ResourceID returns the id field of the event data, or zero if it has none.
+func (e *Event) ResourceID() int64 {
+	var data struct {
+		ID int64 `json:"id"`
+	}
+
+	//nolint
+	json.Unmarshal(e.Data, &data)
+
+	return data.ID
+}
+
+type resourceKey struct {
+	resource Resource
+	id       int64
+}
+
+// eventIndex holds the processed event IDs, the latest event ID of each resource ID and the claimed event IDs.
+type eventIndex struct {
+	seen    func(eventID int64) bool
+	latest  func(key resourceKey) (int64, bool)
+	claimed map[int64]struct{}
+}
+
+// claim returns the status of event, and claims it if it is new or out of order.
+func (idx eventIndex) claim(event *Event) EventStatus {
+	status := idx.status(event)
+	if status == EventStatusNew || status == EventStatusOutOfOrder {
+		idx.claimed[event.EventID] = struct{}{}
+	}
+
+	return status
+}
+
+func (idx eventIndex) status(event *Event) EventStatus {
+	if idx.seen(event.EventID) {
+		return EventStatusDuplicate
+	}
+
+	if _, ok := idx.claimed[event.EventID]; ok {
+		return EventStatusInProgress
+	}
+
+	if id := event.ResourceID(); id != 0 {
+		if latest, ok := idx.latest(resourceKey{event.Resource, id}); ok && latest > event.EventID {
+			return EventStatusOutOfOrder
+		}
+	}
+
+	return EventStatusNew
+}
+
+// MemoryEventStore is an EventStore that keeps the most recently processed events in memory.
+type MemoryEventStore struct {
+	mu        sync.Mutex
+	events    *lru[int64, struct{}]
+	resources *lru[resourceKey, int64]
+	claimed   map[int64]struct{}
+}
+
+var _ EventStore = (*MemoryEventStore)(nil)
+
+// NewMemoryEventStore returns a store that remembers the last size event IDs, and the latest event ID of the last size
+// resource IDs.
+func NewMemoryEventStore(size int) *MemoryEventStore {
+	return &MemoryEventStore{
+		events:    newLRU[int64, struct{}](size),
+		resources: newLRU[resourceKey, int64](size),
+		claimed:   map[int64]struct{}{},
+	}
+}
+
+func (s *MemoryEventStore) Claim(ctx context.Context, event *Event) (EventStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.index().claim(event), nil
+}
+
+func (s *MemoryEventStore) Release(ctx context.Context, event *Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.claimed, event.EventID)
+
+	return nil
+}
+
+func (s *MemoryEventStore) Record(ctx context.Context, event *Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.claimed, event.EventID)
+	s.events.put(event.EventID, struct{}{})
+
+	if id := event.ResourceID(); id != 0 {
+		key := resourceKey{event.Resource, id}
+		if latest, ok := s.resources.get(key); !ok || event.EventID > latest {
+			s.resources.put(key, event.EventID)
+		}
+	}
+
+	return nil
+}
+
+func (s *MemoryEventStore) index() eventIndex {
+	return eventIndex{
+		seen: func(eventID int64) bool {
+			_, ok := s.events.get(eventID)
+			return ok
+		},
+		latest:  s.resources.get,
+		claimed: s.claimed,
+	}
+}
+
+// FileEventStore is an EventStore that appends processed events to a file, so that they are remembered across
+// restarts. Like a MemoryEventStore, it remembers the most recently processed events, and the file is rewritten with
+// only those once it holds four times as many.
+type FileEventStore struct {
+	mu        sync.Mutex
+	path      string
+	size      int
+	file      *os.File
+	records   int
+	events    *lru[int64, *fileEventRecord]
+	resources *lru[resourceKey, int64]
+	claimed   map[int64]struct{}
+}
+
+var _ EventStore = (*FileEventStore)(nil)
+
+type fileEventRecord struct {
+	EventID    int64    `json:"event_id"`
+	Resource   Resource `json:"resource"`
+	ResourceID int64    `json:"resource_id,omitempty"`
+}
+
+// NewFileEventStore opens the store at path, creating it if needed. Like NewMemoryEventStore, it remembers the last
+// size event IDs, and the latest event ID of the last size resource IDs. The caller must call Close.
+func NewFileEventStore(path string, size int) (*FileEventStore, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening event store: %w", err)
+	}
+
+	s := &FileEventStore{
+		path:      path,
+		size:      max(size, 1),
+		file:      file,
+		events:    newLRU[int64, *fileEventRecord](size),
+		resources: newLRU[resourceKey, int64](size),
+		claimed:   map[int64]struct{}{},
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		record := &fileEventRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			//nolint
+			file.Close()
+			return nil, fmt.Errorf("unmarshaling event store record: %w", err)
+		}
+
+		s.add(record)
+		s.records++
+	}
+
+	if err := scanner.Err(); err != nil {
+		//nolint
+		file.Close()
+		return nil, fmt.Errorf("reading event store: %w", err)
+	}
+
+	if err := s.compactIfNeeded(); err != nil {
+		//nolint
+		s.file.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *FileEventStore) Claim(ctx context.Context, event *Event) (EventStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.index().claim(event), nil
+}
+
+func (s *FileEventStore) Release(ctx context.Context, event *Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.claimed, event.EventID)
+
+	return nil
+}
+
+func (s *FileEventStore) Record(ctx context.Context, event *Event) error {
+	record := &fileEventRecord{
+		EventID:    event.EventID,
+		Resource:   event.Resource,
+		ResourceID: event.ResourceID(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.claimed, event.EventID)
+
+	b, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshaling event store record: %w", err)
+	}
+
+	_, err = s.file.Write(append(b, '\n'))
+	if err != nil {
+		return fmt.Errorf("writing event store record: %w", err)
+	}
+
+	err = s.file.Sync()
+	if err != nil {
+		return fmt.Errorf("syncing event store: %w", err)
+	}
+
+	s.add(record)
+	s.records++
+
+	return s.compactIfNeeded()
+}
+
+func (s *FileEventStore) Close() error {
+	return s.file.Close()
+}
+
+func (s *FileEventStore) add(record *fileEventRecord) {
+	s.events.put(record.EventID, record)
+
+	if record.ResourceID != 0 {
+		key := resourceKey{record.Resource, record.ResourceID}
+		if latest, ok := s.resources.get(key); !ok || record.EventID > latest {
+			s.resources.put(key, record.EventID)
+		}
+	}
+}
+
+// compactIfNeeded rewrites the file with the remembered events once it holds four times as many records as the store
+// remembers. The new file is written next to it and renamed over it, so a failure leaves the old file in place.
+func (s *FileEventStore) compactIfNeeded() error {
+	if s.records <= 4*s.size {
+		return nil
+	}
+
+	// The latest events of resources come first so that the events they share with the event IDs keep their recency.
+	var records []*fileEventRecord
+	for _, entry := range s.resources.all() {
+		records = append(records, &fileEventRecord{EventID: entry.value, Resource: entry.key.resource, ResourceID: entry.key.id})
+	}
+
+	for _, entry := range s.events.all() {
+		records = append(records, entry.value)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("compacting event store: %w", err)
+	}
+	//nolint
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	for _, record := range records {
+		b, err := json.Marshal(record)
+		if err != nil {
+			//nolint
+			tmp.Close()
+			return fmt.Errorf("marshaling event store record: %w", err)
+		}
+
+		//nolint
+		w.Write(append(b, '\n'))
+	}
+
+	err = errors.Join(w.Flush(), tmp.Sync(), tmp.Close())
+	if err != nil {
+		return fmt.Errorf("compacting event store: %w", err)
+	}
+
+	err = os.Rename(tmp.Name(), s.path)
+	if err != nil {
+		return fmt.Errorf("compacting event store: %w", err)
+	}
+
+	file, err := os.OpenFile(s.path, os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("reopening event store: %w", err)
+	}
+
+	//nolint
+	s.file.Close()
+	s.file = file
+	s.records = len(records)
+
+	return nil
+}
+
+func (s *FileEventStore) index() eventIndex {
+	return eventIndex{
+		seen: func(eventID int64) bool {
+			_, ok := s.events.get(eventID)
+			return ok
+		},
+		latest:  s.resources.get,
+		claimed: s.claimed,
+	}
+}
+
+// lru is a map that evicts its least recently used entry once it holds size entries. It is not safe for concurrent
+// use.
+type lru[K comparable, V any] struct {
+	size    int
+	order   *list.List
+	entries map[K]*list.Element
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func newLRU[K comparable, V any](size int) *lru[K, V] {
+	return &lru[K, V]{
+		size:    max(size, 1),
+		order:   list.New(),
+		entries: map[K]*list.Element{},
+	}
+}
+
+// all returns the entries from the least to the most recently used.
+func (l *lru[K, V]) all() []*lruEntry[K, V] {
+	out := make([]*lruEntry[K, V], 0, l.order.Len())
+	for e := l.order.Back(); e != nil; e = e.Prev() {
+		//nolint:forcetypeassert
+		out = append(out, e.Value.(*lruEntry[K, V]))
+	}
+
+	return out
+}
+
+func (l *lru[K, V]) get(key K) (V, bool) {
+	e, ok := l.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	l.order.MoveToFront(e)
+
+	//nolint:forcetypeassert
+	return e.Value.(*lruEntry[K, V]).value, true
+}
+
+func (l *lru[K, V]) put(key K, value V) {
+	if e, ok := l.entries[key]; ok {
+		//nolint:forcetypeassert
+		e.Value.(*lruEntry[K, V]).value = value
+		l.order.MoveToFront(e)
+
+		return
+	}
+
+	l.entries[key] = l.order.PushFront(&lruEntry[K, V]{key, value})
+
+	if l.order.Len() > l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+
+		//nolint:forcetypeassert
+		delete(l.entries, oldest.Value.(*lruEntry[K, V]).key)
+	}
+}
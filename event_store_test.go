@@ -0,0 +1,167 @@
+package elation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testEvent(eventID int64, resource Resource, resourceID int64) *Event {
+	return &Event{
+		Data:     fmt.Appendf(nil, `{"id":%d}`, resourceID),
+		Action:   WebhookEventActionSaved,
+		EventID:  eventID,
+		Resource: resource,
+	}
+}
+
+func TestEventStore(t *testing.T) {
+	testCases := map[string]struct {
+		newStore func(t *testing.T) EventStore
+	}{
+		"memory": {
+			newStore: func(t *testing.T) EventStore {
+				return NewMemoryEventStore(100)
+			},
+		},
+		"file": {
+			newStore: func(t *testing.T) EventStore {
+				store, err := NewFileEventStore(filepath.Join(t.TempDir(), "events.jsonl"), 100)
+				assert.NoError(t, err)
+				t.Cleanup(func() { assert.NoError(t, store.Close()) })
+
+				return store
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			store := testCase.newStore(t)
+			ctx := context.Background()
+
+			steps := []struct {
+				event          *Event
+				expectedStatus EventStatus
+			}{
+				{testEvent(10, ResourcePatients, 1), EventStatusNew},
+				{testEvent(10, ResourcePatients, 1), EventStatusDuplicate},
+				{testEvent(12, ResourcePatients, 1), EventStatusNew},
+				{testEvent(11, ResourcePatients, 1), EventStatusOutOfOrder},
+				{testEvent(9, ResourcePatients, 2), EventStatusNew},
+				{testEvent(8, ResourceAppointments, 1), EventStatusNew},
+			}
+			for _, step := range steps {
+				status, err := store.Claim(ctx, step.event)
+				assert.NoError(err)
+				assert.Equal(step.expectedStatus, status, "event %d", step.event.EventID)
+
+				assert.NoError(store.Record(ctx, step.event))
+			}
+
+			// The out-of-order event was recorded, so it is now a duplicate.
+			status, err := store.Claim(ctx, testEvent(11, ResourcePatients, 1))
+			assert.NoError(err)
+			assert.Equal(EventStatusDuplicate, status)
+
+			// A claimed event is in progress until it is recorded or released.
+			status, err = store.Claim(ctx, testEvent(20, ResourcePatients, 3))
+			assert.NoError(err)
+			assert.Equal(EventStatusNew, status)
+
+			status, err = store.Claim(ctx, testEvent(20, ResourcePatients, 3))
+			assert.NoError(err)
+			assert.Equal(EventStatusInProgress, status)
+
+			assert.NoError(store.Release(ctx, testEvent(20, ResourcePatients, 3)))
+
+			status, err = store.Claim(ctx, testEvent(20, ResourcePatients, 3))
+			assert.NoError(err)
+			assert.Equal(EventStatusNew, status)
+		})
+	}
+}
+
+func TestMemoryEventStore_eviction(t *testing.T) {
+	assert := assert.New(t)
+
+	store := NewMemoryEventStore(2)
+	ctx := context.Background()
+
+	for i := range int64(3) {
+		assert.NoError(store.Record(ctx, testEvent(i+1, ResourcePatients, i+1)))
+	}
+
+	status, err := store.Claim(ctx, testEvent(1, ResourcePatients, 1))
+	assert.NoError(err)
+	assert.Equal(EventStatusNew, status)
+
+	status, err = store.Claim(ctx, testEvent(3, ResourcePatients, 3))
+	assert.NoError(err)
+	assert.Equal(EventStatusDuplicate, status)
+}
+
+func TestFileEventStore_reopen(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	ctx := context.Background()
+
+	store, err := NewFileEventStore(path, 100)
+	assert.NoError(err)
+	assert.NoError(store.Record(ctx, testEvent(2, ResourcePatients, 1)))
+	assert.NoError(store.Close())
+
+	store, err = NewFileEventStore(path, 100)
+	assert.NoError(err)
+	defer store.Close() //nolint
+
+	status, err := store.Claim(ctx, testEvent(2, ResourcePatients, 1))
+	assert.NoError(err)
+	assert.Equal(EventStatusDuplicate, status)
+
+	status, err = store.Claim(ctx, testEvent(1, ResourcePatients, 1))
+	assert.NoError(err)
+	assert.Equal(EventStatusOutOfOrder, status)
+}
+
+func TestFileEventStore_compaction(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	ctx := context.Background()
+
+	store, err := NewFileEventStore(path, 2)
+	assert.NoError(err)
+
+	for i := range int64(20) {
+		assert.NoError(store.Record(ctx, testEvent(i+1, ResourcePatients, i%3+1)))
+	}
+	assert.NoError(store.Close())
+
+	b, err := os.ReadFile(path)
+	assert.NoError(err)
+	assert.LessOrEqual(bytes.Count(b, []byte("\n")), 8)
+
+	store, err = NewFileEventStore(path, 2)
+	assert.NoError(err)
+	defer store.Close() //nolint
+
+	status, err := store.Claim(ctx, testEvent(20, ResourcePatients, 2))
+	assert.NoError(err)
+	assert.Equal(EventStatusDuplicate, status)
+
+	status, err = store.Claim(ctx, testEvent(1, ResourcePatients, 4))
+	assert.NoError(err)
+	assert.Equal(EventStatusNew, status, "forgotten")
+
+	status, err = store.Claim(ctx, testEvent(17, ResourcePatients, 2))
+	assert.NoError(err)
+	assert.Equal(EventStatusOutOfOrder, status, "event 20 is the latest of patient 2")
+}
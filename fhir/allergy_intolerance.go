@@ -0,0 +1,68 @@
+package fhir
+
+import (
+	"strings"
+
+	"github.com/authorhealth/go-elation"
+)
+
+type AllergyIntolerance struct {
+	ResourceType       string                       `json:"resourceType"`
+	ID                 string                       `json:"id,omitempty"`
+	Meta               *Meta                        `json:"meta,omitempty"`
+	Identifier         []Identifier                 `json:"identifier,omitempty"`
+	ClinicalStatus     *CodeableConcept             `json:"clinicalStatus,omitempty"`
+	VerificationStatus *CodeableConcept             `json:"verificationStatus,omitempty"`
+	Code               CodeableConcept              `json:"code"`
+	Patient            Reference                    `json:"patient"`
+	OnsetDateTime      string                       `json:"onsetDateTime,omitempty"`
+	RecordedDate       string                       `json:"recordedDate,omitempty"`
+	Reaction           []AllergyIntoleranceReaction `json:"reaction,omitempty"`
+}
+
+type AllergyIntoleranceReaction struct {
+	Manifestation []CodeableConcept `json:"manifestation"`
+	Severity      string            `json:"severity,omitempty"`
+}
+
+// FromElationAllergy converts a to a US Core AllergyIntolerance. The allergen is only mapped as text, since Elation
+// identifies it by Medi-Span IDs. Deleted allergies are marked entered-in-error.
+func FromElationAllergy(a *elation.Allergy) *AllergyIntolerance {
+	allergy := &AllergyIntolerance{
+		ResourceType:       "AllergyIntolerance",
+		ID:                 formatID(a.ID),
+		Meta:               &Meta{Profile: []string{ProfileAllergyIntolerance}},
+		Identifier:         []Identifier{identifier(SystemAllergy, a.ID)},
+		VerificationStatus: concept(SystemAllergyVerification, "confirmed", "Confirmed"),
+		Code:               CodeableConcept{Text: a.Name},
+		Patient:            reference("Patient", a.Patient),
+		OnsetDateTime:      deref(a.StartDate),
+		RecordedDate:       dateTime(a.CreatedDate),
+	}
+
+	switch {
+	case a.DeletedDate != nil:
+		allergy.VerificationStatus = concept(SystemAllergyVerification, "entered-in-error", "Entered in Error")
+	case strings.EqualFold(a.Status, "active"):
+		allergy.ClinicalStatus = concept(SystemAllergyClinical, "active", "Active")
+	case strings.EqualFold(a.Status, "inactive"):
+		allergy.ClinicalStatus = concept(SystemAllergyClinical, "inactive", "Inactive")
+	case strings.EqualFold(a.Status, "resolved"):
+		allergy.ClinicalStatus = concept(SystemAllergyClinical, "resolved", "Resolved")
+	}
+
+	if a.Reaction != "" {
+		reaction := AllergyIntoleranceReaction{
+			Manifestation: []CodeableConcept{{Text: a.Reaction}},
+		}
+
+		switch severity := strings.ToLower(a.Severity); severity {
+		case "mild", "moderate", "severe":
+			reaction.Severity = severity
+		}
+
+		allergy.Reaction = []AllergyIntoleranceReaction{reaction}
+	}
+
+	return allergy
+}
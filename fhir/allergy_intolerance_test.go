@@ -0,0 +1,73 @@
+package fhir
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/authorhealth/go-elation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromElationAllergy(t *testing.T) {
+	assert := assert.New(t)
+
+	allergy := FromElationAllergy(&elation.Allergy{
+		ID:          1,
+		Status:      "Active",
+		StartDate:   new("2019-05-01"),
+		Reaction:    "Hives",
+		Name:        "Penicillin",
+		Severity:    "Moderate",
+		Patient:     2,
+		CreatedDate: testTime,
+	})
+
+	b, err := json.Marshal(allergy)
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"resourceType": "AllergyIntolerance",
+		"id": "1",
+		"meta": {"profile": ["http://hl7.org/fhir/us/core/StructureDefinition/us-core-allergyintolerance"]},
+		"identifier": [{"system": "https://elationhealth.com/fhir/allergy", "value": "1"}],
+		"clinicalStatus": {"coding": [{"system": "http://terminology.hl7.org/CodeSystem/allergyintolerance-clinical", "code": "active", "display": "Active"}]},
+		"verificationStatus": {"coding": [{"system": "http://terminology.hl7.org/CodeSystem/allergyintolerance-verification", "code": "confirmed", "display": "Confirmed"}]},
+		"code": {"text": "Penicillin"},
+		"patient": {"reference": "Patient/2"},
+		"onsetDateTime": "2019-05-01",
+		"recordedDate": "2024-01-02T03:04:05Z",
+		"reaction": [{"manifestation": [{"text": "Hives"}], "severity": "moderate"}]
+	}`, string(b))
+}
+
+func TestFromElationAllergy_status(t *testing.T) {
+	testCases := map[string]struct {
+		allergy              *elation.Allergy
+		expectedClinical     string
+		expectedVerification string
+	}{
+		"inactive": {
+			allergy:              &elation.Allergy{Status: "Inactive"},
+			expectedClinical:     "inactive",
+			expectedVerification: "confirmed",
+		},
+		"resolved": {
+			allergy:              &elation.Allergy{Status: "Resolved"},
+			expectedClinical:     "resolved",
+			expectedVerification: "confirmed",
+		},
+		"deleted": {
+			allergy:              &elation.Allergy{Status: "Active", DeletedDate: &testTime},
+			expectedVerification: "entered-in-error",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			allergy := roundTrip(t, FromElationAllergy(testCase.allergy))
+			assert.Equal(testCase.expectedClinical, allergy.ClinicalStatus.code(SystemAllergyClinical))
+			assert.Equal(testCase.expectedVerification, allergy.VerificationStatus.code(SystemAllergyVerification))
+			assert.Empty(allergy.Reaction)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package fhir
+
+import (
+	"time"
+
+	"github.com/authorhealth/go-elation"
+)
+
+type Appointment struct {
+	ResourceType       string                   `json:"resourceType"`
+	ID                 string                   `json:"id,omitempty"`
+	Identifier         []Identifier             `json:"identifier,omitempty"`
+	Status             string                   `json:"status"`
+	AppointmentType    *CodeableConcept         `json:"appointmentType,omitempty"`
+	ReasonCode         []CodeableConcept        `json:"reasonCode,omitempty"`
+	Description        string                   `json:"description,omitempty"`
+	Start              string                   `json:"start,omitempty"`
+	End                string                   `json:"end,omitempty"`
+	MinutesDuration    int                      `json:"minutesDuration,omitempty"`
+	Created            string                   `json:"created,omitempty"`
+	Comment            string                   `json:"comment,omitempty"`
+	PatientInstruction string                   `json:"patientInstruction,omitempty"`
+	Participant        []AppointmentParticipant `json:"participant"`
+}
+
+type AppointmentParticipant struct {
+	Type   []CodeableConcept `json:"type,omitempty"`
+	Actor  *Reference        `json:"actor,omitempty"`
+	Status string            `json:"status"`
+}
+
+// appointmentStatuses are the FHIR statuses of the Elation appointment statuses.
+var appointmentStatuses = map[elation.AppointmentStatusType]string{
+	elation.AppointmentStatusScheduled:         "booked",
+	elation.AppointmentStatusConfirmed:         "booked",
+	elation.AppointmentStatusCheckedIn:         "checked-in",
+	elation.AppointmentStatusInRoom:            "arrived",
+	elation.AppointmentStatusInRoomVitalsTaken: "arrived",
+	elation.AppointmentStatusWithDoctor:        "arrived",
+	elation.AppointmentStatusCheckedOut:        "fulfilled",
+	elation.AppointmentStatusBilled:            "fulfilled",
+	elation.AppointmentStatusCancelled:         "cancelled",
+	elation.AppointmentStatusNotSeen:           "noshow",
+}
+
+// FromElationAppointment converts a to a FHIR Appointment between the patient, the physician and the service location.
+// The mode is coded as an ambulatory or virtual encounter type, alongside the Elation mode.
+func FromElationAppointment(a *elation.Appointment) *Appointment {
+	appointment := &Appointment{
+		ResourceType:       "Appointment",
+		ID:                 formatID(a.ID),
+		Identifier:         []Identifier{identifier(SystemAppointment, a.ID)},
+		Status:             appointmentStatus(a),
+		Description:        a.Reason,
+		Start:              dateTime(a.ScheduledDate),
+		MinutesDuration:    a.Duration,
+		Created:            dateTime(a.CreatedDate),
+		Comment:            a.Description,
+		PatientInstruction: a.Instructions,
+		Participant: []AppointmentParticipant{
+			{Actor: new(reference("Patient", a.Patient)), Status: "accepted"},
+			{
+				Type:   []CodeableConcept{*concept(SystemParticipationType, "PPRF", "primary performer")},
+				Actor:  new(reference("Practitioner", a.Physician)),
+				Status: "accepted",
+			},
+		},
+	}
+
+	if a.Reason != "" {
+		appointment.ReasonCode = []CodeableConcept{{Text: a.Reason}}
+	}
+
+	if !a.ScheduledDate.IsZero() && a.Duration > 0 {
+		appointment.End = dateTime(a.ScheduledDate.Add(time.Duration(a.Duration) * time.Minute))
+	}
+
+	switch a.Mode {
+	case elation.AppointmentModeInPerson:
+		appointment.AppointmentType = concept(SystemActCode, "AMB", "ambulatory")
+	case elation.AppointmentModeVideo:
+		appointment.AppointmentType = concept(SystemActCode, "VR", "virtual")
+	}
+
+	if a.Mode != "" {
+		if appointment.AppointmentType == nil {
+			appointment.AppointmentType = &CodeableConcept{}
+		}
+
+		appointment.AppointmentType.Coding = append(appointment.AppointmentType.Coding, Coding{System: SystemAppointmentMode, Code: a.Mode})
+	}
+
+	if location := a.ServiceLocation; location != nil && location.ID != 0 {
+		appointment.Participant = append(appointment.Participant, AppointmentParticipant{
+			Actor:  &Reference{Reference: "Location/" + formatID(int64(location.ID)), Display: location.Name},
+			Status: "accepted",
+		})
+	}
+
+	return appointment
+}
+
+// appointmentStatus maps the status of a. Appointments without a status are booked, and deleted appointments are
+// cancelled.
+func appointmentStatus(a *elation.Appointment) string {
+	if a.DeletedDate != nil {
+		return "cancelled"
+	}
+
+	if status, ok := appointmentStatuses[a.CurrentStatus()]; ok {
+		return status
+	}
+
+	return "booked"
+}
@@ -0,0 +1,115 @@
+package fhir
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/authorhealth/go-elation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromElationAppointment(t *testing.T) {
+	assert := assert.New(t)
+
+	appointment := FromElationAppointment(&elation.Appointment{
+		ID:            1,
+		ScheduledDate: testTime,
+		Duration:      30,
+		Reason:        "Follow-up",
+		Description:   "Bring labs",
+		Status:        &elation.AppointmentStatus{Status: "Checked In", Room: "2"},
+		ServiceLocation: &elation.AppointmentServiceLocation{
+			ID:   4,
+			Name: "Main Office",
+		},
+		Patient:      2,
+		Physician:    3,
+		CreatedDate:  testTime,
+		Mode:         elation.AppointmentModeVideo,
+		Instructions: "Join 5 minutes early",
+	})
+
+	b, err := json.Marshal(appointment)
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"resourceType": "Appointment",
+		"id": "1",
+		"identifier": [{"system": "https://elationhealth.com/fhir/appointment", "value": "1"}],
+		"status": "checked-in",
+		"appointmentType": {"coding": [
+			{"system": "http://terminology.hl7.org/CodeSystem/v3-ActCode", "code": "VR", "display": "virtual"},
+			{"system": "https://elationhealth.com/fhir/appointment-mode", "code": "VIDEO"}
+		]},
+		"reasonCode": [{"text": "Follow-up"}],
+		"description": "Follow-up",
+		"start": "2024-01-02T03:04:05Z",
+		"end": "2024-01-02T03:34:05Z",
+		"minutesDuration": 30,
+		"created": "2024-01-02T03:04:05Z",
+		"comment": "Bring labs",
+		"patientInstruction": "Join 5 minutes early",
+		"participant": [
+			{"actor": {"reference": "Patient/2"}, "status": "accepted"},
+			{
+				"type": [{"coding": [{"system": "http://terminology.hl7.org/CodeSystem/v3-ParticipationType", "code": "PPRF", "display": "primary performer"}]}],
+				"actor": {"reference": "Practitioner/3"},
+				"status": "accepted"
+			},
+			{"actor": {"reference": "Location/4", "display": "Main Office"}, "status": "accepted"}
+		]
+	}`, string(b))
+}
+
+func TestFromElationAppointment_status(t *testing.T) {
+	testCases := map[string]struct {
+		appointment    *elation.Appointment
+		expectedStatus string
+	}{
+		"no status": {
+			appointment:    &elation.Appointment{},
+			expectedStatus: "booked",
+		},
+		"scheduled": {
+			appointment:    &elation.Appointment{Status: &elation.AppointmentStatus{Status: "Scheduled"}},
+			expectedStatus: "booked",
+		},
+		"with doctor": {
+			appointment:    &elation.Appointment{Status: &elation.AppointmentStatus{Status: "With Doctor"}},
+			expectedStatus: "arrived",
+		},
+		"checked out": {
+			appointment:    &elation.Appointment{Status: &elation.AppointmentStatus{Status: "Checked Out"}},
+			expectedStatus: "fulfilled",
+		},
+		"not seen": {
+			appointment:    &elation.Appointment{Status: &elation.AppointmentStatus{Status: "Not Seen"}},
+			expectedStatus: "noshow",
+		},
+		"cancelled": {
+			appointment:    &elation.Appointment{Status: &elation.AppointmentStatus{Status: "Cancelled"}},
+			expectedStatus: "cancelled",
+		},
+		"deleted": {
+			appointment:    &elation.Appointment{Status: &elation.AppointmentStatus{Status: "Scheduled"}, DeletedDate: &testTime},
+			expectedStatus: "cancelled",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			appointment := roundTrip(t, FromElationAppointment(testCase.appointment))
+			assert.Equal(testCase.expectedStatus, appointment.Status)
+		})
+	}
+}
+
+func TestFromElationAppointment_inPerson(t *testing.T) {
+	assert := assert.New(t)
+
+	appointment := roundTrip(t, FromElationAppointment(&elation.Appointment{Mode: elation.AppointmentModeInPerson}))
+	assert.Equal("AMB", appointment.AppointmentType.code(SystemActCode))
+	assert.Equal(elation.AppointmentModeInPerson, appointment.AppointmentType.code(SystemAppointmentMode))
+	assert.Empty(appointment.End)
+	assert.Len(appointment.Participant, 2)
+}
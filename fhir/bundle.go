@@ -0,0 +1,116 @@
+package fhir
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/authorhealth/go-elation"
+)
+
+type Bundle struct {
+	ResourceType string        `json:"resourceType"`
+	Type         string        `json:"type"`
+	Total        int           `json:"total,omitempty"`
+	Entry        []BundleEntry `json:"entry,omitempty"`
+}
+
+type BundleEntry struct {
+	FullURL  string `json:"fullUrl,omitempty"`
+	Resource any    `json:"resource"`
+}
+
+// NewBundle returns a collection Bundle of resources.
+func NewBundle(resources ...any) *Bundle {
+	bundle := &Bundle{
+		ResourceType: "Bundle",
+		Type:         "collection",
+	}
+
+	for _, resource := range resources {
+		bundle.Entry = append(bundle.Entry, BundleEntry{Resource: resource})
+	}
+
+	return bundle
+}
+
+// PatientBundle fetches the chart and prescription fills of a patient and returns them as a collection Bundle of the
+// Patient and its RelatedPersons, Coverages, Conditions, AllergyIntolerances, MedicationRequests,
+// MedicationStatements and MedicationDispenses. If some of the chart fails to load, the Bundle of what did load is
+// returned along with the error.
+func PatientBundle(ctx context.Context, client elation.Client, patientID int64) (*Bundle, error) {
+	chart, chartErr := elation.GetChart(ctx, client, patientID, elation.ChartOptions{
+		Sections: []elation.ChartSection{
+			elation.ChartSectionPatient,
+			elation.ChartSectionInsurancePolicies,
+			elation.ChartSectionProblems,
+			elation.ChartSectionAllergies,
+			elation.ChartSectionMedications,
+			elation.ChartSectionDiscontinuedMedications,
+		},
+	})
+	if chart == nil {
+		return nil, chartErr
+	}
+
+	var fills []*elation.PrescriptionFill
+	var fillsErr error
+	for fill, err := range elation.All(ctx, client.PrescriptionFills().Find, &elation.FindPrescriptionFillsOptions{
+		Patient: []int64{patientID},
+	}) {
+		if err != nil {
+			fillsErr = fmt.Errorf("finding prescription fills: %w", err)
+			break
+		}
+
+		fills = append(fills, fill)
+	}
+
+	bundle := NewBundle()
+
+	if chart.Patient != nil {
+		bundle.add(FromElationPatient(chart.Patient))
+		for _, person := range RelatedPersons(chart.Patient) {
+			bundle.add(person)
+		}
+	}
+
+	for _, policy := range chart.InsurancePolicies {
+		bundle.add(FromElationInsurancePolicy(policy))
+	}
+
+	for _, problem := range chart.Problems {
+		bundle.add(FromElationProblem(problem))
+	}
+
+	for _, allergy := range chart.Allergies {
+		bundle.add(FromElationAllergy(allergy))
+	}
+
+	orders := map[int64]*elation.PatientMedication{}
+	for _, medication := range chart.Medications {
+		orders[medication.ID] = medication
+
+		if medication.IsDocMed {
+			bundle.add(FromElationMedicationStatement(medication))
+		} else {
+			bundle.add(FromElationMedicationOrder(medication))
+		}
+	}
+
+	for _, medication := range chart.DiscontinuedMedications {
+		bundle.add(FromElationDiscontinuedMedication(medication))
+	}
+
+	for _, fill := range fills {
+		bundle.add(FromElationPrescriptionFill(fill, orders[fill.MedicationOrder]))
+	}
+
+	bundle.Total = len(bundle.Entry)
+
+	return bundle, errors.Join(chartErr, fillsErr)
+}
+
+func (b *Bundle) add(resource any) {
+	b.Entry = append(b.Entry, BundleEntry{Resource: resource})
+}
@@ -0,0 +1,83 @@
+package fhir
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/authorhealth/go-elation"
+	"github.com/authorhealth/go-elation/elationtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatientBundle(t *testing.T) {
+	assert := assert.New(t)
+
+	client := elationtest.NewClient()
+	elationtest.Seed(client, &elation.Patient{ID: 1, FirstName: "Jane", LastName: "Doe", Sex: "Female"})
+	elationtest.Seed(client, &elation.InsurancePolicy{ID: 2, PatientID: 1, Status: "active"})
+	elationtest.Seed(client, &elation.PatientProblem{ID: 3, Patient: 1, Status: "Active"})
+	elationtest.Seed(client, &elation.Allergy{ID: 4, Patient: 1, Status: "Active"})
+	elationtest.Seed(client,
+		&elation.PatientMedication{ID: 5, Patient: 1, Medication: &elation.Medication{RxnormCuis: []string{"314076"}}},
+		&elation.PatientMedication{ID: 6, Patient: 1, IsDocMed: true},
+	)
+	elationtest.Seed(client, &elation.DiscontinuedMedication{ID: 7, Patient: 1, MedOrder: 5})
+	elationtest.Seed(client, &elation.PrescriptionFill{ID: 8, Patient: 1, MedicationOrder: 5, FillStatus: "filled"})
+	elationtest.Seed(client, &elation.PatientProblem{ID: 9, Patient: 10, Status: "Active"})
+
+	bundle, err := PatientBundle(context.Background(), client, 1)
+	assert.NoError(err)
+	assert.Equal("Bundle", bundle.ResourceType)
+	assert.Equal("collection", bundle.Type)
+	assert.Equal(8, bundle.Total)
+
+	var resourceTypes []string
+	for _, entry := range bundle.Entry {
+		switch resource := entry.Resource.(type) {
+		case *Patient:
+			resourceTypes = append(resourceTypes, resource.ResourceType)
+		case *Coverage:
+			resourceTypes = append(resourceTypes, resource.ResourceType)
+		case *Condition:
+			resourceTypes = append(resourceTypes, resource.ResourceType)
+		case *AllergyIntolerance:
+			resourceTypes = append(resourceTypes, resource.ResourceType)
+		case *MedicationRequest:
+			resourceTypes = append(resourceTypes, resource.ResourceType)
+		case *MedicationStatement:
+			resourceTypes = append(resourceTypes, resource.ResourceType)
+		case *MedicationDispense:
+			resourceTypes = append(resourceTypes, resource.ResourceType)
+			assert.Equal("314076", resource.MedicationCodeableConcept.code(SystemRxNorm))
+		}
+	}
+
+	assert.Equal([]string{
+		"Patient",
+		"Coverage",
+		"Condition",
+		"AllergyIntolerance",
+		"MedicationRequest",
+		"MedicationStatement",
+		"MedicationStatement",
+		"MedicationDispense",
+	}, resourceTypes)
+}
+
+func TestPatientBundle_error(t *testing.T) {
+	assert := assert.New(t)
+
+	client := elationtest.NewClient()
+	elationtest.Seed(client, &elation.Patient{ID: 1})
+	elationtest.Seed(client, &elation.PatientProblem{ID: 2, Patient: 1})
+
+	expectedErr := errors.New("boom")
+	client.FailNext("Allergies.Find", expectedErr)
+	client.FailNext("PrescriptionFills.Find", expectedErr)
+
+	bundle, err := PatientBundle(context.Background(), client, 1)
+	assert.ErrorIs(err, expectedErr)
+	assert.ErrorContains(err, "finding prescription fills")
+	assert.Equal(2, bundle.Total)
+}
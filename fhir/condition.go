@@ -0,0 +1,83 @@
+package fhir
+
+import (
+	"strings"
+
+	"github.com/authorhealth/go-elation"
+)
+
+type Condition struct {
+	ResourceType       string            `json:"resourceType"`
+	ID                 string            `json:"id,omitempty"`
+	Meta               *Meta             `json:"meta,omitempty"`
+	Identifier         []Identifier      `json:"identifier,omitempty"`
+	ClinicalStatus     *CodeableConcept  `json:"clinicalStatus,omitempty"`
+	VerificationStatus *CodeableConcept  `json:"verificationStatus,omitempty"`
+	Category           []CodeableConcept `json:"category"`
+	Code               CodeableConcept   `json:"code"`
+	Subject            Reference         `json:"subject"`
+	OnsetDateTime      string            `json:"onsetDateTime,omitempty"`
+	AbatementDateTime  string            `json:"abatementDateTime,omitempty"`
+	RecordedDate       string            `json:"recordedDate,omitempty"`
+	Note               []Annotation      `json:"note,omitempty"`
+}
+
+// FromElationProblem converts p to a US Core problem list Condition, coded with its ICD-10-CM, ICD-9-CM and SNOMED CT
+// codes. Deleted problems are marked entered-in-error.
+func FromElationProblem(p *elation.PatientProblem) *Condition {
+	condition := &Condition{
+		ResourceType:       "Condition",
+		ID:                 formatID(p.ID),
+		Meta:               &Meta{Profile: []string{ProfileCondition}},
+		Identifier:         []Identifier{identifier(SystemProblem, p.ID)},
+		VerificationStatus: concept(SystemConditionVerification, "confirmed", "Confirmed"),
+		Category:           []CodeableConcept{*concept(SystemConditionCategory, "problem-list-item", "Problem List Item")},
+		Code:               CodeableConcept{Text: p.Description},
+		Subject:            reference("Patient", p.Patient),
+		OnsetDateTime:      p.StartDate,
+		AbatementDateTime:  p.ResolvedDate,
+		RecordedDate:       dateTime(p.CreatedDate),
+	}
+
+	for _, dx := range p.Dx {
+		for _, code := range dx.Icd10 {
+			condition.Code.Coding = append(condition.Code.Coding, Coding{System: SystemICD10CM, Code: code})
+		}
+
+		for _, code := range dx.Icd9 {
+			condition.Code.Coding = append(condition.Code.Coding, Coding{System: SystemICD9CM, Code: code})
+		}
+
+		if dx.Snomed != "" {
+			condition.Code.Coding = append(condition.Code.Coding, Coding{System: SystemSNOMED, Code: dx.Snomed})
+		}
+	}
+
+	if p.DeletedDate != nil {
+		condition.VerificationStatus = concept(SystemConditionVerification, "entered-in-error", "Entered in Error")
+	} else if status := conditionClinicalStatus(p.Status); status != "" {
+		condition.ClinicalStatus = concept(SystemConditionClinical, status, "")
+		condition.ClinicalStatus.Coding = append(condition.ClinicalStatus.Coding, Coding{System: SystemProblemStatus, Code: p.Status})
+	}
+
+	if p.Synopsis != "" {
+		condition.Note = []Annotation{{Text: p.Synopsis}}
+	}
+
+	return condition
+}
+
+// conditionClinicalStatus maps the Active, Controlled and Resolved problem statuses. Controlled problems are still
+// active.
+func conditionClinicalStatus(status string) string {
+	switch strings.ToLower(status) {
+	case "active", "controlled":
+		return "active"
+	case "resolved":
+		return "resolved"
+	case "inactive":
+		return "inactive"
+	default:
+		return ""
+	}
+}
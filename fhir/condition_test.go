@@ -0,0 +1,91 @@
+package fhir
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/authorhealth/go-elation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromElationProblem(t *testing.T) {
+	assert := assert.New(t)
+
+	condition := FromElationProblem(&elation.PatientProblem{
+		ID:           1,
+		Description:  "Type 2 diabetes mellitus",
+		Status:       "Controlled",
+		Synopsis:     "Diet controlled",
+		StartDate:    "2020-01-01",
+		ResolvedDate: "",
+		Dx: []*elation.PatientProblemDX{
+			{Icd10: []string{"E11.9"}, Icd9: []string{"250.00"}, Snomed: "44054006"},
+		},
+		Patient:     2,
+		CreatedDate: testTime,
+	})
+
+	b, err := json.Marshal(condition)
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"resourceType": "Condition",
+		"id": "1",
+		"meta": {"profile": ["http://hl7.org/fhir/us/core/StructureDefinition/us-core-condition-problems-health-concerns"]},
+		"identifier": [{"system": "https://elationhealth.com/fhir/problem", "value": "1"}],
+		"clinicalStatus": {"coding": [
+			{"system": "http://terminology.hl7.org/CodeSystem/condition-clinical", "code": "active"},
+			{"system": "https://elationhealth.com/fhir/problem-status", "code": "Controlled"}
+		]},
+		"verificationStatus": {"coding": [{"system": "http://terminology.hl7.org/CodeSystem/condition-ver-status", "code": "confirmed", "display": "Confirmed"}]},
+		"category": [{"coding": [{"system": "http://terminology.hl7.org/CodeSystem/condition-category", "code": "problem-list-item", "display": "Problem List Item"}]}],
+		"code": {
+			"coding": [
+				{"system": "http://hl7.org/fhir/sid/icd-10-cm", "code": "E11.9"},
+				{"system": "http://hl7.org/fhir/sid/icd-9-cm", "code": "250.00"},
+				{"system": "http://snomed.info/sct", "code": "44054006"}
+			],
+			"text": "Type 2 diabetes mellitus"
+		},
+		"subject": {"reference": "Patient/2"},
+		"onsetDateTime": "2020-01-01",
+		"recordedDate": "2024-01-02T03:04:05Z",
+		"note": [{"text": "Diet controlled"}]
+	}`, string(b))
+}
+
+func TestFromElationProblem_status(t *testing.T) {
+	testCases := map[string]struct {
+		problem              *elation.PatientProblem
+		expectedClinical     string
+		expectedVerification string
+	}{
+		"active": {
+			problem:              &elation.PatientProblem{Status: "Active"},
+			expectedClinical:     "active",
+			expectedVerification: "confirmed",
+		},
+		"resolved": {
+			problem:              &elation.PatientProblem{Status: "Resolved", ResolvedDate: "2021-01-01"},
+			expectedClinical:     "resolved",
+			expectedVerification: "confirmed",
+		},
+		"unknown": {
+			problem:              &elation.PatientProblem{Status: "Other"},
+			expectedVerification: "confirmed",
+		},
+		"deleted": {
+			problem:              &elation.PatientProblem{Status: "Active", DeletedDate: &testTime},
+			expectedVerification: "entered-in-error",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			condition := roundTrip(t, FromElationProblem(testCase.problem))
+			assert.Equal(testCase.expectedClinical, condition.ClinicalStatus.code(SystemConditionClinical))
+			assert.Equal(testCase.expectedVerification, condition.VerificationStatus.code(SystemConditionVerification))
+			assert.Equal(testCase.problem.ResolvedDate, condition.AbatementDateTime)
+		})
+	}
+}
@@ -0,0 +1,384 @@
+package fhir
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/civil"
+	"github.com/authorhealth/go-elation"
+)
+
+type Coverage struct {
+	ResourceType string           `json:"resourceType"`
+	ID           string           `json:"id,omitempty"`
+	Meta         *Meta            `json:"meta,omitempty"`
+	Identifier   []Identifier     `json:"identifier,omitempty"`
+	Status       string           `json:"status"`
+	Type         *CodeableConcept `json:"type,omitempty"`
+	Subscriber   *Reference       `json:"subscriber,omitempty"`
+	SubscriberID string           `json:"subscriberId,omitempty"`
+	Beneficiary  Reference        `json:"beneficiary"`
+	Relationship *CodeableConcept `json:"relationship,omitempty"`
+	Period       *Period          `json:"period,omitempty"`
+	Payor        []Reference      `json:"payor"`
+	Class        []CoverageClass  `json:"class,omitempty"`
+	Order        int64            `json:"order,omitempty"`
+}
+
+type CoverageClass struct {
+	Type  CodeableConcept `json:"type"`
+	Value string          `json:"value"`
+	Name  string          `json:"name,omitempty"`
+}
+
+// paymentTypologies are the Source of Payment Typology categories of the Elation payment programs.
+var paymentTypologies = map[string]Coding{
+	"medicare_part_b":      {SystemPaymentTypology, "1", "MEDICARE"},
+	"medicare_advantage":   {SystemPaymentTypology, "1", "MEDICARE"},
+	"medicaid":             {SystemPaymentTypology, "2", "MEDICAID"},
+	"commercial_hmsa":      {SystemPaymentTypology, "5", "PRIVATE HEALTH INSURANCE"},
+	"commercial_sfhp":      {SystemPaymentTypology, "5", "PRIVATE HEALTH INSURANCE"},
+	"commercial_other":     {SystemPaymentTypology, "5", "PRIVATE HEALTH INSURANCE"},
+	"workers_compensation": {SystemPaymentTypology, "95", "Worker's Compensation"},
+}
+
+var ranks = []string{"primary", "secondary", "tertiary"}
+
+// FromElationInsurancePolicy converts p to a US Core Coverage. The carrier is the payor, the member ID a member
+// identifier, and the group and plan are coverage classes.
+func FromElationInsurancePolicy(p *elation.InsurancePolicy) *Coverage {
+	coverage := newCoverage(p.PatientID, coverageFields{
+		carrierID:             p.CarrierID,
+		carrierName:           p.CarrierName,
+		planID:                p.PlanID,
+		planName:              p.PlanName,
+		groupID:               p.GroupID,
+		memberID:              p.MemberID,
+		paymentProgram:        p.PaymentProgram,
+		insuredPersonID:       p.InsuredPersonID,
+		insuredPersonName:     insuredPersonName(p.InsuredPersonFirstName, p.InsuredPersonLastName),
+		relationshipToInsured: p.RelationshipToInsured,
+		startDate:             deref(p.StartDate),
+		endDate:               deref(p.EndDate),
+	})
+
+	coverage.ID = formatID(p.ID)
+	coverage.Identifier = append([]Identifier{identifier(SystemInsurancePolicy, p.ID)}, coverage.Identifier...)
+	coverage.Order = deref(p.Rank)
+
+	coverage.Status = "active"
+	if p.Status == "inactive" {
+		coverage.Status = "cancelled"
+	}
+
+	return coverage
+}
+
+// ToElationInsurancePolicy converts a Coverage created by FromElationInsurancePolicy back to an
+// elation.InsurancePolicy.
+func ToElationInsurancePolicy(coverage *Coverage) (*elation.InsurancePolicy, error) {
+	fields, err := coverage.fields()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &elation.InsurancePolicy{
+		ID:                    identifierID(coverage.Identifier, SystemInsurancePolicy),
+		PatientID:             fields.patientID,
+		Status:                "active",
+		CarrierID:             fields.carrierID,
+		CarrierName:           fields.carrierName,
+		PlanID:                fields.planID,
+		PlanName:              fields.planName,
+		GroupID:               fields.groupID,
+		MemberID:              fields.memberID,
+		PaymentProgram:        fields.paymentProgram,
+		InsuredPersonID:       fields.insuredPersonID,
+		RelationshipToInsured: fields.relationshipToInsured,
+		StartDate:             ptr(fields.startDate),
+		EndDate:               ptr(fields.endDate),
+	}
+
+	p.InsuredPersonFirstName, p.InsuredPersonLastName = splitInsuredPersonName(fields.insuredPersonName)
+
+	if coverage.Status == "cancelled" {
+		p.Status = "inactive"
+	}
+
+	if coverage.Order != 0 {
+		p.Rank = &coverage.Order
+	}
+
+	return p, nil
+}
+
+// FromElationPatientInsurance converts an insurance of the patient with patientID to a US Core Coverage.
+func FromElationPatientInsurance(patientID int64, i *elation.PatientInsurance) *Coverage {
+	fields := coverageFields{
+		carrierID:             i.InsuranceCompany,
+		carrierName:           i.Carrier,
+		planID:                i.InsurancePlan,
+		planName:              i.Plan,
+		groupID:               i.GroupID,
+		memberID:              i.MemberID,
+		paymentProgram:        i.PaymentProgram,
+		insuredPersonID:       i.InsuredPersonID,
+		insuredPersonName:     insuredPersonName(i.InsuredPersonFirstName, i.InsuredPersonLastName),
+		relationshipToInsured: i.RelationshipToInsured,
+	}
+
+	if i.StartDate != nil {
+		fields.startDate = i.StartDate.String()
+	}
+	if i.EndDate != nil {
+		fields.endDate = i.EndDate.String()
+	}
+
+	coverage := newCoverage(patientID, fields)
+	coverage.ID = formatID(i.ID)
+	coverage.Identifier = append([]Identifier{identifier(SystemPatientInsurance, i.ID)}, coverage.Identifier...)
+
+	coverage.Status = "active"
+	if i.DeletedDate != nil {
+		coverage.Status = "cancelled"
+	}
+
+	for n, rank := range ranks {
+		if strings.EqualFold(i.Rank, rank) {
+			coverage.Order = int64(n + 1)
+		}
+	}
+
+	if i.Rank != "" {
+		coverage.Type = appendCoding(coverage.Type, Coding{System: SystemInsuranceRank, Code: i.Rank})
+	}
+
+	return coverage
+}
+
+// ToElationPatientInsurance converts a Coverage created by FromElationPatientInsurance back to an
+// elation.PatientInsurance, returning the ID of the patient along with it. The deleted date is not restored.
+func ToElationPatientInsurance(coverage *Coverage) (int64, *elation.PatientInsurance, error) {
+	fields, err := coverage.fields()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	i := &elation.PatientInsurance{
+		ID:                    identifierID(coverage.Identifier, SystemPatientInsurance),
+		InsuranceCompany:      fields.carrierID,
+		InsurancePlan:         fields.planID,
+		Rank:                  coverage.Type.code(SystemInsuranceRank),
+		Carrier:               fields.carrierName,
+		MemberID:              fields.memberID,
+		GroupID:               fields.groupID,
+		Plan:                  fields.planName,
+		PaymentProgram:        fields.paymentProgram,
+		InsuredPersonID:       fields.insuredPersonID,
+		RelationshipToInsured: fields.relationshipToInsured,
+	}
+
+	i.InsuredPersonFirstName, i.InsuredPersonLastName = splitInsuredPersonName(fields.insuredPersonName)
+
+	if i.Rank == "" && coverage.Order > 0 && int(coverage.Order) <= len(ranks) {
+		i.Rank = ranks[coverage.Order-1]
+	}
+
+	if fields.startDate != "" {
+		date, err := civil.ParseDate(fields.startDate)
+		if err != nil {
+			return 0, nil, fmt.Errorf("parsing start date: %w", err)
+		}
+
+		i.StartDate = &date
+	}
+
+	if fields.endDate != "" {
+		date, err := civil.ParseDate(fields.endDate)
+		if err != nil {
+			return 0, nil, fmt.Errorf("parsing end date: %w", err)
+		}
+
+		i.EndDate = &date
+	}
+
+	return fields.patientID, i, nil
+}
+
+// coverageFields are the fields shared by elation.InsurancePolicy and elation.PatientInsurance.
+type coverageFields struct {
+	patientID             int64
+	carrierID             *int64
+	carrierName           *string
+	planID                *int64
+	planName              *string
+	groupID               *string
+	memberID              *string
+	paymentProgram        *string
+	insuredPersonID       *string
+	insuredPersonName     string
+	relationshipToInsured *string
+	startDate             string
+	endDate               string
+}
+
+func newCoverage(patientID int64, fields coverageFields) *Coverage {
+	coverage := &Coverage{
+		ResourceType: "Coverage",
+		Meta:         &Meta{Profile: []string{ProfileCoverage}},
+		Beneficiary:  reference("Patient", patientID),
+		SubscriberID: deref(fields.insuredPersonID),
+	}
+
+	if fields.memberID != nil {
+		coverage.Identifier = append(coverage.Identifier, Identifier{
+			Use:   "official",
+			Type:  &CodeableConcept{Coding: []Coding{{System: SystemIdentifierType, Code: "MB", Display: "Member Number"}}},
+			Value: *fields.memberID,
+		})
+	}
+
+	payor := Reference{Display: deref(fields.carrierName)}
+	if fields.carrierID != nil {
+		payor.Identifier = new(identifier(SystemInsuranceCarrier, *fields.carrierID))
+	}
+	coverage.Payor = []Reference{payor}
+
+	if fields.paymentProgram != nil {
+		if coding, ok := paymentTypologies[*fields.paymentProgram]; ok {
+			coverage.Type = appendCoding(coverage.Type, coding)
+		}
+
+		coverage.Type = appendCoding(coverage.Type, Coding{System: SystemPaymentProgram, Code: *fields.paymentProgram})
+	}
+
+	if fields.relationshipToInsured != nil {
+		coverage.Relationship = &CodeableConcept{Coding: []Coding{{System: SystemSubscriberRelationship, Code: *fields.relationshipToInsured}}}
+	}
+
+	if fields.insuredPersonName != "" {
+		coverage.Subscriber = &Reference{Display: fields.insuredPersonName}
+	}
+
+	if fields.groupID != nil {
+		coverage.Class = append(coverage.Class, CoverageClass{
+			Type:  CodeableConcept{Coding: []Coding{{System: SystemCoverageClass, Code: "group"}}},
+			Value: *fields.groupID,
+		})
+	}
+
+	if fields.planID != nil || fields.planName != nil {
+		class := CoverageClass{
+			Type: CodeableConcept{Coding: []Coding{{System: SystemCoverageClass, Code: "plan"}}},
+			Name: deref(fields.planName),
+		}
+
+		if fields.planID != nil {
+			class.Value = strconv.FormatInt(*fields.planID, 10)
+		} else {
+			class.Value = *fields.planName
+		}
+
+		coverage.Class = append(coverage.Class, class)
+	}
+
+	if fields.startDate != "" || fields.endDate != "" {
+		coverage.Period = &Period{Start: fields.startDate, End: fields.endDate}
+	}
+
+	return coverage
+}
+
+func (c *Coverage) fields() (*coverageFields, error) {
+	if c.ResourceType != "Coverage" {
+		return nil, fmt.Errorf("%w %q", ErrResourceType, c.ResourceType)
+	}
+
+	patientID, ok := c.Beneficiary.ID("Patient")
+	if !ok {
+		return nil, fmt.Errorf("unexpected beneficiary %q", c.Beneficiary.Reference)
+	}
+
+	fields := &coverageFields{
+		patientID:         patientID,
+		insuredPersonID:   ptr(c.SubscriberID),
+		paymentProgram:    ptr(c.Type.code(SystemPaymentProgram)),
+		insuredPersonName: c.Subscriber.display(),
+	}
+
+	for _, identifier := range c.Identifier {
+		if identifier.Type.code(SystemIdentifierType) == "MB" {
+			fields.memberID = new(identifier.Value)
+		}
+	}
+
+	if len(c.Payor) > 0 {
+		fields.carrierName = ptr(c.Payor[0].Display)
+		if c.Payor[0].Identifier != nil {
+			id := identifierID([]Identifier{*c.Payor[0].Identifier}, SystemInsuranceCarrier)
+			if id != 0 {
+				fields.carrierID = &id
+			}
+		}
+	}
+
+	if c.Relationship != nil {
+		fields.relationshipToInsured = ptr(c.Relationship.code(SystemSubscriberRelationship))
+	}
+
+	for _, class := range c.Class {
+		switch class.Type.code(SystemCoverageClass) {
+		case "group":
+			fields.groupID = new(class.Value)
+		case "plan":
+			fields.planName = ptr(class.Name)
+			if id, err := strconv.ParseInt(class.Value, 10, 64); err == nil {
+				fields.planID = &id
+			}
+		}
+	}
+
+	if c.Period != nil {
+		fields.startDate = c.Period.Start
+		fields.endDate = c.Period.End
+	}
+
+	return fields, nil
+}
+
+func (r *Reference) display() string {
+	if r == nil {
+		return ""
+	}
+
+	return r.Display
+}
+
+func appendCoding(concept *CodeableConcept, coding Coding) *CodeableConcept {
+	if concept == nil {
+		concept = &CodeableConcept{}
+	}
+
+	concept.Coding = append(concept.Coding, coding)
+
+	return concept
+}
+
+func insuredPersonName(first *string, last *string) string {
+	return strings.Join(nonEmpty(deref(first), deref(last)), " ")
+}
+
+// splitInsuredPersonName splits a name joined by insuredPersonName. Multi-word first names are not restored.
+func splitInsuredPersonName(name string) (*string, *string) {
+	if name == "" {
+		return nil, nil
+	}
+
+	first, last, ok := strings.Cut(name, " ")
+	if !ok {
+		return &first, nil
+	}
+
+	return &first, &last
+}
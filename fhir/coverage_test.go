@@ -0,0 +1,134 @@
+package fhir
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/authorhealth/go-elation"
+	"github.com/stretchr/testify/assert"
+)
+
+var testTime = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestInsurancePolicy(t *testing.T) {
+	testCases := map[string]struct {
+		policy *elation.InsurancePolicy
+	}{
+		"full": {
+			policy: &elation.InsurancePolicy{
+				ID:                     1,
+				PatientID:              2,
+				Status:                 "active",
+				Rank:                   new(int64(1)),
+				CarrierID:              new(int64(3)),
+				CarrierName:            new("Acme Health"),
+				PlanID:                 new(int64(4)),
+				PlanName:               new("Gold"),
+				GroupID:                new("G123"),
+				MemberID:               new("M456"),
+				StartDate:              new("2024-01-01"),
+				EndDate:                new("2024-12-31"),
+				InsuredPersonFirstName: new("John"),
+				InsuredPersonLastName:  new("Doe"),
+				InsuredPersonID:        new("S789"),
+				RelationshipToInsured:  new("spouse"),
+				PaymentProgram:         new("commercial_other"),
+			},
+		},
+		"inactive": {
+			policy: &elation.InsurancePolicy{
+				ID:          1,
+				PatientID:   2,
+				Status:      "inactive",
+				CarrierName: new("Acme Health"),
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			coverage := roundTrip(t, FromElationInsurancePolicy(testCase.policy))
+
+			actual, err := ToElationInsurancePolicy(coverage)
+			assert.NoError(err)
+			assert.Equal(testCase.policy, actual)
+		})
+	}
+}
+
+func TestFromElationInsurancePolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	coverage := FromElationInsurancePolicy(&elation.InsurancePolicy{
+		ID:                    1,
+		PatientID:             2,
+		Status:                "active",
+		Rank:                  new(int64(2)),
+		CarrierID:             new(int64(3)),
+		CarrierName:           new("Acme Health"),
+		GroupID:               new("G123"),
+		MemberID:              new("M456"),
+		RelationshipToInsured: new("self"),
+		PaymentProgram:        new("medicaid"),
+	})
+
+	b, err := json.Marshal(coverage)
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"resourceType": "Coverage",
+		"id": "1",
+		"meta": {"profile": ["http://hl7.org/fhir/us/core/StructureDefinition/us-core-coverage"]},
+		"identifier": [
+			{"system": "https://elationhealth.com/fhir/insurance-policy", "value": "1"},
+			{"use": "official", "type": {"coding": [{"system": "http://terminology.hl7.org/CodeSystem/v2-0203", "code": "MB", "display": "Member Number"}]}, "value": "M456"}
+		],
+		"status": "active",
+		"type": {"coding": [
+			{"system": "https://nahdo.org/sopt", "code": "2", "display": "MEDICAID"},
+			{"system": "https://elationhealth.com/fhir/payment-program", "code": "medicaid"}
+		]},
+		"beneficiary": {"reference": "Patient/2"},
+		"relationship": {"coding": [{"system": "http://terminology.hl7.org/CodeSystem/subscriber-relationship", "code": "self"}]},
+		"payor": [{"identifier": {"system": "https://elationhealth.com/fhir/insurance-carrier", "value": "3"}, "display": "Acme Health"}],
+		"class": [{"type": {"coding": [{"system": "http://terminology.hl7.org/CodeSystem/coverage-class", "code": "group"}]}, "value": "G123"}],
+		"order": 2
+	}`, string(b))
+}
+
+func TestPatientInsurance(t *testing.T) {
+	assert := assert.New(t)
+
+	insurance := &elation.PatientInsurance{
+		ID:                     1,
+		InsuranceCompany:       new(int64(3)),
+		InsurancePlan:          new(int64(4)),
+		Rank:                   "secondary",
+		Carrier:                new("Acme Health"),
+		MemberID:               new("M456"),
+		GroupID:                new("G123"),
+		Plan:                   new("Gold"),
+		PaymentProgram:         new("medicare_part_b"),
+		InsuredPersonFirstName: new("Jane"),
+		InsuredPersonID:        new("S789"),
+		RelationshipToInsured:  new("self"),
+		StartDate:              &civil.Date{Year: 2024, Month: time.January, Day: 1},
+	}
+
+	coverage := roundTrip(t, FromElationPatientInsurance(2, insurance))
+	assert.Equal(int64(2), coverage.Order)
+	assert.Equal("active", coverage.Status)
+
+	patientID, actual, err := ToElationPatientInsurance(coverage)
+	assert.NoError(err)
+	assert.Equal(int64(2), patientID)
+	assert.Equal(insurance, actual)
+
+	insurance.DeletedDate = &testTime
+	assert.Equal("cancelled", FromElationPatientInsurance(2, insurance).Status)
+
+	_, _, err = ToElationPatientInsurance(&Coverage{ResourceType: "Coverage", Beneficiary: Reference{Reference: "Group/1"}})
+	assert.EqualError(err, `unexpected beneficiary "Group/1"`)
+}
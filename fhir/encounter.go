@@ -0,0 +1,217 @@
+package fhir
+
+import (
+	"errors"
+	"html"
+	"strings"
+
+	"github.com/authorhealth/go-elation"
+)
+
+var ErrVisitNoteNotSigned = errors.New("visit note is not signed")
+
+type Encounter struct {
+	ResourceType    string                 `json:"resourceType"`
+	ID              string                 `json:"id,omitempty"`
+	Meta            *Meta                  `json:"meta,omitempty"`
+	Identifier      []Identifier           `json:"identifier,omitempty"`
+	Status          string                 `json:"status"`
+	Class           Coding                 `json:"class"`
+	Type            []CodeableConcept      `json:"type"`
+	Subject         Reference              `json:"subject"`
+	Participant     []EncounterParticipant `json:"participant,omitempty"`
+	Period          *Period                `json:"period,omitempty"`
+	ServiceProvider *Reference             `json:"serviceProvider,omitempty"`
+}
+
+type EncounterParticipant struct {
+	Type       []CodeableConcept `json:"type,omitempty"`
+	Individual *Reference        `json:"individual,omitempty"`
+}
+
+type Composition struct {
+	ResourceType    string                `json:"resourceType"`
+	ID              string                `json:"id,omitempty"`
+	Identifier      *Identifier           `json:"identifier,omitempty"`
+	Status          string                `json:"status"`
+	Type            CodeableConcept       `json:"type"`
+	Subject         Reference             `json:"subject"`
+	Encounter       *Reference            `json:"encounter,omitempty"`
+	Date            string                `json:"date"`
+	Author          []Reference           `json:"author"`
+	Title           string                `json:"title"`
+	Confidentiality string                `json:"confidentiality,omitempty"`
+	Attester        []CompositionAttester `json:"attester,omitempty"`
+	Section         []CompositionSection  `json:"section,omitempty"`
+}
+
+type CompositionAttester struct {
+	Mode  string     `json:"mode"`
+	Time  string     `json:"time,omitempty"`
+	Party *Reference `json:"party,omitempty"`
+}
+
+type CompositionSection struct {
+	Title string           `json:"title,omitempty"`
+	Code  *CodeableConcept `json:"code,omitempty"`
+	Text  *Narrative       `json:"text,omitempty"`
+}
+
+// sectionCodes are the LOINC section codes of the visit note bullet categories. Categories without an equivalent
+// section, such as Narrative, are only coded with the Elation category.
+var sectionCodes = map[string]Coding{
+	"reason":     {SystemLOINC, "29299-5", "Reason for visit Narrative"},
+	"subjective": {SystemLOINC, "61150-9", "Subjective Narrative"},
+	"hpi":        {SystemLOINC, "10164-2", "History of Present illness Narrative"},
+	"problem":    {SystemLOINC, "11450-4", "Problem list - Reported"},
+	"past":       {SystemLOINC, "11348-0", "History of Past illness Narrative"},
+	"surgical":   {SystemLOINC, "10167-5", "History of Surgical procedures Narrative"},
+	"family":     {SystemLOINC, "10157-6", "History of family member diseases Narrative"},
+	"social":     {SystemLOINC, "29762-2", "Social history Narrative"},
+	"habits":     {SystemLOINC, "29762-2", "Social history Narrative"},
+	"med":        {SystemLOINC, "10160-0", "History of Medication use Narrative"},
+	"allergies":  {SystemLOINC, "48765-2", "Allergies and adverse reactions Document"},
+	"ros":        {SystemLOINC, "10187-3", "Review of systems Narrative - Reported"},
+	"pe":         {SystemLOINC, "29545-1", "Physical findings Narrative"},
+	"objective":  {SystemLOINC, "61149-1", "Objective Narrative"},
+	"data":       {SystemLOINC, "30954-2", "Relevant diagnostic tests/laboratory data Narrative"},
+	"test":       {SystemLOINC, "30954-2", "Relevant diagnostic tests/laboratory data Narrative"},
+	"assessment": {SystemLOINC, "51848-0", "Evaluation note"},
+	"assessplan": {SystemLOINC, "51847-2", "Evaluation + Plan note"},
+	"plan":       {SystemLOINC, "18776-5", "Plan of care note"},
+	"tx":         {SystemLOINC, "18776-5", "Plan of care note"},
+	"orders":     {SystemLOINC, "46209-3", "Provider orders"},
+	"procedure":  {SystemLOINC, "47519-4", "History of Procedures Document"},
+	"instr":      {SystemLOINC, "69730-0", "Instructions"},
+}
+
+// FromElationVisitNote converts a signed visit note to a US Core Encounter and a progress note Composition of the
+// encounter, with a section for each bullet category. Unsigned notes return ErrVisitNoteNotSigned.
+func FromElationVisitNote(n *elation.VisitNote) (*Encounter, *Composition, error) {
+	if n.SignedDate.IsZero() {
+		return nil, nil, ErrVisitNoteNotSigned
+	}
+
+	status := "finished"
+	compositionStatus := "final"
+	if n.DeletedDate != nil {
+		status = "entered-in-error"
+		compositionStatus = "entered-in-error"
+	}
+
+	encounter := &Encounter{
+		ResourceType: "Encounter",
+		ID:           formatID(n.ID),
+		Meta:         &Meta{Profile: []string{ProfileEncounter}},
+		Identifier:   []Identifier{identifier(SystemVisitNote, n.ID)},
+		Status:       status,
+		Class:        Coding{System: SystemActCode, Code: "AMB", Display: "ambulatory"},
+		Type: []CodeableConcept{{
+			Coding: []Coding{{System: SystemVisitNoteType, Code: n.Type}},
+			Text:   n.Type,
+		}},
+		Subject: reference("Patient", n.Patient),
+		Participant: []EncounterParticipant{{
+			Type:       []CodeableConcept{*concept(SystemParticipationType, "PPRF", "primary performer")},
+			Individual: new(reference("Practitioner", n.Physician)),
+		}},
+		Period:          &Period{Start: dateTime(n.DocumentDate)},
+		ServiceProvider: new(reference("Organization", n.Practice)),
+	}
+
+	title := n.Type
+	if title == "" {
+		title = "Visit Note"
+	}
+
+	composition := &Composition{
+		ResourceType:    "Composition",
+		ID:              formatID(n.ID),
+		Identifier:      new(identifier(SystemVisitNote, n.ID)),
+		Status:          compositionStatus,
+		Type:            *concept(SystemLOINC, "11506-3", "Progress note"),
+		Subject:         reference("Patient", n.Patient),
+		Encounter:       new(reference("Encounter", n.ID)),
+		Date:            dateTime(n.SignedDate),
+		Author:          []Reference{reference("Practitioner", n.Physician)},
+		Title:           title,
+		Confidentiality: "N",
+		Attester: []CompositionAttester{{
+			Mode:  "legal",
+			Time:  dateTime(n.SignedDate),
+			Party: new(reference("Practitioner", n.SignedBy)),
+		}},
+		Section: visitNoteSections(n.Bullets),
+	}
+
+	if n.Confidential {
+		composition.Confidentiality = "R"
+	}
+
+	for _, signature := range n.Signatures {
+		if signature.User == n.SignedBy {
+			continue
+		}
+
+		composition.Attester = append(composition.Attester, CompositionAttester{
+			Mode:  "professional",
+			Time:  dateTime(signature.SignedDate),
+			Party: &Reference{Display: signature.UserName},
+		})
+	}
+
+	return encounter, composition, nil
+}
+
+// visitNoteSections groups bullets by category, in the order the categories first appear, with the text of the bullets
+// and their children as a list.
+func visitNoteSections(bullets []*elation.VisitNoteBullet) []CompositionSection {
+	var categories []string
+	items := map[string][]string{}
+
+	for _, bullet := range bullets {
+		if bullet.DeletedDate != nil {
+			continue
+		}
+
+		item := html.EscapeString(bullet.Text)
+
+		var children []string
+		for _, child := range bullet.Children {
+			if child.DeletedDate == nil {
+				children = append(children, "<li>"+html.EscapeString(child.Text)+"</li>")
+			}
+		}
+
+		if len(children) > 0 {
+			item += "<ul>" + strings.Join(children, "") + "</ul>"
+		}
+
+		if _, ok := items[bullet.Category]; !ok {
+			categories = append(categories, bullet.Category)
+		}
+
+		items[bullet.Category] = append(items[bullet.Category], "<li>"+item+"</li>")
+	}
+
+	var sections []CompositionSection
+	for _, category := range categories {
+		code := &CodeableConcept{Text: category}
+		if coding, ok := sectionCodes[strings.ToLower(category)]; ok {
+			code.Coding = append(code.Coding, coding)
+		}
+
+		code.Coding = append(code.Coding, Coding{System: SystemVisitNoteCategory, Code: category})
+
+		sections = append(sections, CompositionSection{
+			Title: category,
+			Code:  code,
+			Text: &Narrative{
+				Status: "generated",
+				Div:    `<div xmlns="http://www.w3.org/1999/xhtml"><ul>` + strings.Join(items[category], "") + "</ul></div>",
+			},
+		})
+	}
+
+	return sections
+}
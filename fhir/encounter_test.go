@@ -0,0 +1,142 @@
+package fhir
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/authorhealth/go-elation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromElationVisitNote(t *testing.T) {
+	assert := assert.New(t)
+
+	note := &elation.VisitNote{
+		ID: 1,
+		Bullets: []*elation.VisitNoteBullet{
+			{Category: "Reason", Text: "Headache"},
+			{Category: "Hpi", Text: "Started 3 days ago", Children: []*elation.VisitNoteChild{
+				{Text: "Worse in the morning"},
+				{Text: "Deleted", DeletedDate: &testTime},
+			}},
+			{Category: "Narrative", Text: "Patient & family <present>"},
+			{Category: "Reason", Text: "Dizziness"},
+			{Category: "Plan", Text: "Deleted", DeletedDate: &testTime},
+		},
+		Signatures: []*elation.VisitNoteSignature{
+			{User: 3, UserName: "Jane Doe, MD", SignedDate: testTime},
+			{User: 5, UserName: "John Roe, MD", SignedDate: testTime, Role: "cosigner"},
+		},
+		Type:         "Office Visit Note",
+		Patient:      2,
+		Physician:    3,
+		Practice:     4,
+		DocumentDate: testTime,
+		SignedDate:   testTime,
+		SignedBy:     3,
+	}
+
+	encounter, composition, err := FromElationVisitNote(note)
+	assert.NoError(err)
+
+	b, err := json.Marshal(encounter)
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"resourceType": "Encounter",
+		"id": "1",
+		"meta": {"profile": ["http://hl7.org/fhir/us/core/StructureDefinition/us-core-encounter"]},
+		"identifier": [{"system": "https://elationhealth.com/fhir/visit-note", "value": "1"}],
+		"status": "finished",
+		"class": {"system": "http://terminology.hl7.org/CodeSystem/v3-ActCode", "code": "AMB", "display": "ambulatory"},
+		"type": [{"coding": [{"system": "https://elationhealth.com/fhir/visit-note-type", "code": "Office Visit Note"}], "text": "Office Visit Note"}],
+		"subject": {"reference": "Patient/2"},
+		"participant": [{
+			"type": [{"coding": [{"system": "http://terminology.hl7.org/CodeSystem/v3-ParticipationType", "code": "PPRF", "display": "primary performer"}]}],
+			"individual": {"reference": "Practitioner/3"}
+		}],
+		"period": {"start": "2024-01-02T03:04:05Z"},
+		"serviceProvider": {"reference": "Organization/4"}
+	}`, string(b))
+
+	b, err = json.Marshal(composition)
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"resourceType": "Composition",
+		"id": "1",
+		"identifier": {"system": "https://elationhealth.com/fhir/visit-note", "value": "1"},
+		"status": "final",
+		"type": {"coding": [{"system": "http://loinc.org", "code": "11506-3", "display": "Progress note"}]},
+		"subject": {"reference": "Patient/2"},
+		"encounter": {"reference": "Encounter/1"},
+		"date": "2024-01-02T03:04:05Z",
+		"author": [{"reference": "Practitioner/3"}],
+		"title": "Office Visit Note",
+		"confidentiality": "N",
+		"attester": [
+			{"mode": "legal", "time": "2024-01-02T03:04:05Z", "party": {"reference": "Practitioner/3"}},
+			{"mode": "professional", "time": "2024-01-02T03:04:05Z", "party": {"display": "John Roe, MD"}}
+		],
+		"section": [
+			{
+				"title": "Reason",
+				"code": {"coding": [
+					{"system": "http://loinc.org", "code": "29299-5", "display": "Reason for visit Narrative"},
+					{"system": "https://elationhealth.com/fhir/visit-note-category", "code": "Reason"}
+				], "text": "Reason"},
+				"text": {"status": "generated", "div": "<div xmlns=\"http://www.w3.org/1999/xhtml\"><ul><li>Headache</li><li>Dizziness</li></ul></div>"}
+			},
+			{
+				"title": "Hpi",
+				"code": {"coding": [
+					{"system": "http://loinc.org", "code": "10164-2", "display": "History of Present illness Narrative"},
+					{"system": "https://elationhealth.com/fhir/visit-note-category", "code": "Hpi"}
+				], "text": "Hpi"},
+				"text": {"status": "generated", "div": "<div xmlns=\"http://www.w3.org/1999/xhtml\"><ul><li>Started 3 days ago<ul><li>Worse in the morning</li></ul></li></ul></div>"}
+			},
+			{
+				"title": "Narrative",
+				"code": {"coding": [{"system": "https://elationhealth.com/fhir/visit-note-category", "code": "Narrative"}], "text": "Narrative"},
+				"text": {"status": "generated", "div": "<div xmlns=\"http://www.w3.org/1999/xhtml\"><ul><li>Patient &amp; family &lt;present&gt;</li></ul></div>"}
+			}
+		]
+	}`, string(b))
+}
+
+func TestFromElationVisitNote_status(t *testing.T) {
+	testCases := map[string]struct {
+		note                    *elation.VisitNote
+		expectedErr             error
+		expectedStatus          string
+		expectedConfidentiality string
+	}{
+		"unsigned": {
+			note:        &elation.VisitNote{ID: 1},
+			expectedErr: ErrVisitNoteNotSigned,
+		},
+		"confidential": {
+			note:                    &elation.VisitNote{ID: 1, SignedDate: testTime, Confidential: true},
+			expectedStatus:          "finished",
+			expectedConfidentiality: "R",
+		},
+		"deleted": {
+			note:                    &elation.VisitNote{ID: 1, SignedDate: testTime, DeletedDate: &testTime},
+			expectedStatus:          "entered-in-error",
+			expectedConfidentiality: "N",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			encounter, composition, err := FromElationVisitNote(testCase.note)
+			assert.ErrorIs(err, testCase.expectedErr)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(testCase.expectedStatus, encounter.Status)
+			assert.Equal(testCase.expectedConfidentiality, composition.Confidentiality)
+			assert.Equal("Visit Note", composition.Title)
+		})
+	}
+}
@@ -0,0 +1,304 @@
+// Package fhir converts Elation resources to FHIR R4 resources, following the US Core profiles, and back where the
+// mapping is lossless enough to be useful.
+//
+// Only the elements that are mapped are modeled, so these types are not a general purpose FHIR library.
+package fhir
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrResourceType = errors.New("unexpected resource type")
+
+// Identifier systems of Elation IDs.
+const (
+	SystemElation           = "https://elationhealth.com/fhir/"
+	SystemPatient           = SystemElation + "patient"
+	SystemGuarantor         = SystemElation + "guarantor"
+	SystemInsurancePolicy   = SystemElation + "insurance-policy"
+	SystemPatientInsurance  = SystemElation + "patient-insurance"
+	SystemInsuranceCarrier  = SystemElation + "insurance-carrier"
+	SystemInsurancePlan     = SystemElation + "insurance-plan"
+	SystemPaymentProgram    = SystemElation + "payment-program"
+	SystemInsuranceRank     = SystemElation + "insurance-rank"
+	SystemRelatedPersonRole = SystemElation + "related-person-role"
+
+	SystemProblem                = SystemElation + "problem"
+	SystemProblemStatus          = SystemElation + "problem-status"
+	SystemAllergy                = SystemElation + "allergy"
+	SystemMedicationOrder        = SystemElation + "medication-order"
+	SystemDiscontinuedMedication = SystemElation + "discontinued-medication"
+	SystemPrescriptionFill       = SystemElation + "prescription-fill"
+
+	SystemAppointment            = SystemElation + "appointment"
+	SystemAppointmentMode        = SystemElation + "appointment-mode"
+	SystemRecurringEventGroup    = SystemElation + "recurring-event-group"
+	SystemRecurringEventSchedule = SystemElation + "recurring-event-schedule"
+	SystemVisitNote              = SystemElation + "visit-note"
+	SystemVisitNoteType          = SystemElation + "visit-note-type"
+	SystemVisitNoteCategory      = SystemElation + "visit-note-category"
+)
+
+// External code systems.
+const (
+	SystemSSN                    = "http://hl7.org/fhir/sid/us-ssn"
+	SystemCDCRace                = "urn:oid:2.16.840.1.113883.6.238"
+	SystemNullFlavor             = "http://terminology.hl7.org/CodeSystem/v3-NullFlavor"
+	SystemLanguage               = "urn:ietf:bcp:47"
+	SystemIdentifierType         = "http://terminology.hl7.org/CodeSystem/v2-0203"
+	SystemContactRole            = "http://terminology.hl7.org/CodeSystem/v2-0131"
+	SystemRoleCode               = "http://terminology.hl7.org/CodeSystem/v3-RoleCode"
+	SystemCoverageClass          = "http://terminology.hl7.org/CodeSystem/coverage-class"
+	SystemSubscriberRelationship = "http://terminology.hl7.org/CodeSystem/subscriber-relationship"
+	SystemPaymentTypology        = "https://nahdo.org/sopt"
+
+	SystemICD10CM                     = "http://hl7.org/fhir/sid/icd-10-cm"
+	SystemICD9CM                      = "http://hl7.org/fhir/sid/icd-9-cm"
+	SystemSNOMED                      = "http://snomed.info/sct"
+	SystemRxNorm                      = "http://www.nlm.nih.gov/research/umls/rxnorm"
+	SystemNDC                         = "http://hl7.org/fhir/sid/ndc"
+	SystemConditionClinical           = "http://terminology.hl7.org/CodeSystem/condition-clinical"
+	SystemConditionVerification       = "http://terminology.hl7.org/CodeSystem/condition-ver-status"
+	SystemConditionCategory           = "http://terminology.hl7.org/CodeSystem/condition-category"
+	SystemAllergyClinical             = "http://terminology.hl7.org/CodeSystem/allergyintolerance-clinical"
+	SystemAllergyVerification         = "http://terminology.hl7.org/CodeSystem/allergyintolerance-verification"
+	SystemMedicationRequestCategory   = "http://terminology.hl7.org/CodeSystem/medicationrequest-category"
+	SystemMedicationStatementCategory = "http://terminology.hl7.org/CodeSystem/medication-statement-category"
+
+	SystemLOINC             = "http://loinc.org"
+	SystemActCode           = "http://terminology.hl7.org/CodeSystem/v3-ActCode"
+	SystemParticipationType = "http://terminology.hl7.org/CodeSystem/v3-ParticipationType"
+	SystemConfidentiality   = "http://terminology.hl7.org/CodeSystem/v3-Confidentiality"
+)
+
+// US Core profiles and extensions.
+const (
+	ProfilePatient       = "http://hl7.org/fhir/us/core/StructureDefinition/us-core-patient"
+	ProfileRelatedPerson = "http://hl7.org/fhir/us/core/StructureDefinition/us-core-relatedperson"
+	ProfileCoverage      = "http://hl7.org/fhir/us/core/StructureDefinition/us-core-coverage"
+
+	ProfileCondition          = "http://hl7.org/fhir/us/core/StructureDefinition/us-core-condition-problems-health-concerns"
+	ProfileAllergyIntolerance = "http://hl7.org/fhir/us/core/StructureDefinition/us-core-allergyintolerance"
+	ProfileMedicationRequest  = "http://hl7.org/fhir/us/core/StructureDefinition/us-core-medicationrequest"
+	ProfileMedicationDispense = "http://hl7.org/fhir/us/core/StructureDefinition/us-core-medicationdispense"
+	ProfileEncounter          = "http://hl7.org/fhir/us/core/StructureDefinition/us-core-encounter"
+
+	ExtensionRace      = "http://hl7.org/fhir/us/core/StructureDefinition/us-core-race"
+	ExtensionEthnicity = "http://hl7.org/fhir/us/core/StructureDefinition/us-core-ethnicity"
+	ExtensionBirthSex  = "http://hl7.org/fhir/us/core/StructureDefinition/us-core-birthsex"
+)
+
+type Meta struct {
+	Profile []string `json:"profile,omitempty"`
+}
+
+type Extension struct {
+	URL          string      `json:"url"`
+	ValueString  string      `json:"valueString,omitempty"`
+	ValueCode    string      `json:"valueCode,omitempty"`
+	ValueCoding  *Coding     `json:"valueCoding,omitempty"`
+	ValueBoolean *bool       `json:"valueBoolean,omitempty"`
+	Extension    []Extension `json:"extension,omitempty"`
+}
+
+type Coding struct {
+	System  string `json:"system,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+type CodeableConcept struct {
+	Coding []Coding `json:"coding,omitempty"`
+	Text   string   `json:"text,omitempty"`
+}
+
+type Identifier struct {
+	Use    string           `json:"use,omitempty"`
+	Type   *CodeableConcept `json:"type,omitempty"`
+	System string           `json:"system,omitempty"`
+	Value  string           `json:"value,omitempty"`
+}
+
+type HumanName struct {
+	Use    string   `json:"use,omitempty"`
+	Text   string   `json:"text,omitempty"`
+	Family string   `json:"family,omitempty"`
+	Given  []string `json:"given,omitempty"`
+}
+
+type ContactPoint struct {
+	System string `json:"system,omitempty"`
+	Value  string `json:"value,omitempty"`
+	Use    string `json:"use,omitempty"`
+}
+
+type Address struct {
+	Use        string   `json:"use,omitempty"`
+	Text       string   `json:"text,omitempty"`
+	Line       []string `json:"line,omitempty"`
+	City       string   `json:"city,omitempty"`
+	State      string   `json:"state,omitempty"`
+	PostalCode string   `json:"postalCode,omitempty"`
+	Country    string   `json:"country,omitempty"`
+}
+
+type Annotation struct {
+	Text string `json:"text"`
+}
+
+type Quantity struct {
+	Value json.Number `json:"value,omitempty"`
+	Unit  string      `json:"unit,omitempty"`
+}
+
+type Dosage struct {
+	Text string `json:"text,omitempty"`
+}
+
+type Narrative struct {
+	Status string `json:"status"`
+	Div    string `json:"div"`
+}
+
+type Period struct {
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+}
+
+type Reference struct {
+	Reference  string      `json:"reference,omitempty"`
+	Identifier *Identifier `json:"identifier,omitempty"`
+	Display    string      `json:"display,omitempty"`
+}
+
+// ID returns the ID of a relative reference of type resourceType, such as "Patient/1".
+func (r *Reference) ID(resourceType string) (int64, bool) {
+	if r == nil {
+		return 0, false
+	}
+
+	id, ok := strings.CutPrefix(r.Reference, resourceType+"/")
+	if !ok {
+		return 0, false
+	}
+
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return n, true
+}
+
+func reference(resourceType string, id int64) Reference {
+	return Reference{Reference: resourceType + "/" + strconv.FormatInt(id, 10)}
+}
+
+func identifier(system string, id int64) Identifier {
+	return Identifier{System: system, Value: strconv.FormatInt(id, 10)}
+}
+
+// identifierID returns the first identifier of system parsed as an ID.
+func identifierID(identifiers []Identifier, system string) int64 {
+	for _, identifier := range identifiers {
+		if identifier.System == system {
+			id, err := strconv.ParseInt(identifier.Value, 10, 64)
+			if err == nil {
+				return id
+			}
+		}
+	}
+
+	return 0
+}
+
+// identifierValue returns the value of the first identifier of system.
+func identifierValue(identifiers []Identifier, system string) string {
+	for _, identifier := range identifiers {
+		if identifier.System == system {
+			return identifier.Value
+		}
+	}
+
+	return ""
+}
+
+// code returns the code of the first coding of system.
+func (c *CodeableConcept) code(system string) string {
+	if c == nil {
+		return ""
+	}
+
+	for _, coding := range c.Coding {
+		if coding.System == system {
+			return coding.Code
+		}
+	}
+
+	return ""
+}
+
+func extension(extensions []Extension, url string) *Extension {
+	for i := range extensions {
+		if extensions[i].URL == url {
+			return &extensions[i]
+		}
+	}
+
+	return nil
+}
+
+// nonEmpty returns the non-empty strings of ss.
+func nonEmpty(ss ...string) []string {
+	var out []string
+	for _, s := range ss {
+		if s != "" {
+			out = append(out, s)
+		}
+	}
+
+	return out
+}
+
+func deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+
+	return *p
+}
+
+// ptr returns a pointer to s, or nil if s is empty.
+func ptr(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+func formatID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+
+	return strconv.FormatInt(id, 10)
+}
+
+// dateTime formats t as a FHIR dateTime, or returns an empty string if t is zero.
+func dateTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+func concept(system string, code string, display string) *CodeableConcept {
+	return &CodeableConcept{Coding: []Coding{{System: system, Code: code, Display: display}}}
+}
@@ -0,0 +1,271 @@
+package fhir
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/authorhealth/go-elation"
+)
+
+type MedicationRequest struct {
+	ResourceType              string                            `json:"resourceType"`
+	ID                        string                            `json:"id,omitempty"`
+	Meta                      *Meta                             `json:"meta,omitempty"`
+	Identifier                []Identifier                      `json:"identifier,omitempty"`
+	Status                    string                            `json:"status"`
+	Intent                    string                            `json:"intent"`
+	Category                  []CodeableConcept                 `json:"category,omitempty"`
+	MedicationCodeableConcept CodeableConcept                   `json:"medicationCodeableConcept"`
+	Subject                   Reference                         `json:"subject"`
+	AuthoredOn                string                            `json:"authoredOn,omitempty"`
+	Requester                 *Reference                        `json:"requester,omitempty"`
+	ReasonCode                []CodeableConcept                 `json:"reasonCode,omitempty"`
+	Note                      []Annotation                      `json:"note,omitempty"`
+	DosageInstruction         []Dosage                          `json:"dosageInstruction,omitempty"`
+	DispenseRequest           *MedicationRequestDispenseRequest `json:"dispenseRequest,omitempty"`
+}
+
+type MedicationRequestDispenseRequest struct {
+	NumberOfRepeatsAllowed int       `json:"numberOfRepeatsAllowed,omitempty"`
+	Quantity               *Quantity `json:"quantity,omitempty"`
+}
+
+type MedicationStatement struct {
+	ResourceType              string            `json:"resourceType"`
+	ID                        string            `json:"id,omitempty"`
+	Meta                      *Meta             `json:"meta,omitempty"`
+	Identifier                []Identifier      `json:"identifier,omitempty"`
+	Status                    string            `json:"status"`
+	StatusReason              []CodeableConcept `json:"statusReason,omitempty"`
+	Category                  *CodeableConcept  `json:"category,omitempty"`
+	MedicationCodeableConcept CodeableConcept   `json:"medicationCodeableConcept"`
+	Subject                   Reference         `json:"subject"`
+	EffectiveDateTime         string            `json:"effectiveDateTime,omitempty"`
+	EffectivePeriod           *Period           `json:"effectivePeriod,omitempty"`
+	DateAsserted              string            `json:"dateAsserted,omitempty"`
+	ReasonCode                []CodeableConcept `json:"reasonCode,omitempty"`
+	Note                      []Annotation      `json:"note,omitempty"`
+	Dosage                    []Dosage          `json:"dosage,omitempty"`
+}
+
+type MedicationDispense struct {
+	ResourceType              string          `json:"resourceType"`
+	ID                        string          `json:"id,omitempty"`
+	Meta                      *Meta           `json:"meta,omitempty"`
+	Identifier                []Identifier    `json:"identifier,omitempty"`
+	Status                    string          `json:"status"`
+	MedicationCodeableConcept CodeableConcept `json:"medicationCodeableConcept"`
+	Subject                   Reference       `json:"subject"`
+	AuthorizingPrescription   []Reference     `json:"authorizingPrescription,omitempty"`
+	WhenHandedOver            string          `json:"whenHandedOver,omitempty"`
+	Note                      []Annotation    `json:"note,omitempty"`
+}
+
+// FromElationMedicationOrder converts a prescribed medication to a US Core MedicationRequest, coded with the RxNorm CUIs
+// of the medication.
+func FromElationMedicationOrder(m *elation.PatientMedication) *MedicationRequest {
+	request := &MedicationRequest{
+		ResourceType:              "MedicationRequest",
+		ID:                        formatID(m.ID),
+		Meta:                      &Meta{Profile: []string{ProfileMedicationRequest}},
+		Identifier:                []Identifier{identifier(SystemMedicationOrder, m.ID)},
+		Status:                    medicationOrderStatus(m),
+		Intent:                    "order",
+		Category:                  []CodeableConcept{*concept(SystemMedicationRequestCategory, "community", "Community")},
+		MedicationCodeableConcept: patientMedicationConcept(m.Medication),
+		Subject:                   reference("Patient", m.Patient),
+		AuthoredOn:                dateTime(m.DocumentDate),
+		ReasonCode:                icd10Concepts(m.Icd10Codes),
+		Note:                      notes(m.Notes),
+		DosageInstruction:         dosages(m.Directions),
+	}
+
+	if m.PrescribingPhysician != 0 {
+		request.Requester = new(reference("Practitioner", int64(m.PrescribingPhysician)))
+	}
+
+	quantity := quantity(m.Qty, m.QtyUnits)
+	if quantity != nil || m.AuthRefills > 0 {
+		request.DispenseRequest = &MedicationRequestDispenseRequest{
+			NumberOfRepeatsAllowed: m.AuthRefills,
+			Quantity:               quantity,
+		}
+	}
+
+	return request
+}
+
+// FromElationMedicationStatement converts a documented medication, one the patient reports taking rather than one
+// prescribed in Elation, to a MedicationStatement.
+func FromElationMedicationStatement(m *elation.PatientMedication) *MedicationStatement {
+	statement := &MedicationStatement{
+		ResourceType:              "MedicationStatement",
+		ID:                        formatID(m.ID),
+		Identifier:                []Identifier{identifier(SystemMedicationOrder, m.ID)},
+		Status:                    "active",
+		Category:                  concept(SystemMedicationStatementCategory, "patientspecified", "Patient Specified"),
+		MedicationCodeableConcept: patientMedicationConcept(m.Medication),
+		Subject:                   reference("Patient", m.Patient),
+		EffectiveDateTime:         m.StartDate,
+		DateAsserted:              dateTime(m.DocumentDate),
+		ReasonCode:                icd10Concepts(m.Icd10Codes),
+		Note:                      notes(m.Notes),
+		Dosage:                    dosages(m.Directions),
+	}
+
+	switch status := medicationOrderStatus(m); status {
+	case "stopped", "entered-in-error":
+		statement.Status = status
+	}
+
+	return statement
+}
+
+// FromElationDiscontinuedMedication converts a discontinued medication to a stopped MedicationStatement, with the
+// discontinue reason as its status reason.
+func FromElationDiscontinuedMedication(d *elation.DiscontinuedMedication) *MedicationStatement {
+	statement := &MedicationStatement{
+		ResourceType: "MedicationStatement",
+		ID:           formatID(d.ID),
+		Identifier:   []Identifier{identifier(SystemDiscontinuedMedication, d.ID)},
+		Status:       "stopped",
+		Subject:      reference("Patient", d.Patient),
+		DateAsserted: dateTime(d.DocumentDate),
+	}
+
+	if d.MedOrder != 0 {
+		statement.Identifier = append(statement.Identifier, identifier(SystemMedicationOrder, d.MedOrder))
+	}
+
+	if d.Medication != nil {
+		statement.MedicationCodeableConcept = medicationConcept(d.Medication.Name, d.Medication.RxnormCuis, d.Medication.NDCs)
+	}
+
+	if d.DiscontinueDate != "" {
+		statement.EffectivePeriod = &Period{End: d.DiscontinueDate}
+	}
+
+	if d.Reason != "" {
+		statement.StatusReason = []CodeableConcept{{Text: d.Reason}}
+	}
+
+	if d.DeletedDate != nil {
+		statement.Status = "entered-in-error"
+	}
+
+	return statement
+}
+
+// FromElationPrescriptionFill converts a fill to a US Core MedicationDispense. Fills do not describe the medication, so
+// it is taken from order, the medication order that was filled, which may be nil.
+func FromElationPrescriptionFill(f *elation.PrescriptionFill, order *elation.PatientMedication) *MedicationDispense {
+	dispense := &MedicationDispense{
+		ResourceType: "MedicationDispense",
+		ID:           formatID(f.ID),
+		Meta:         &Meta{Profile: []string{ProfileMedicationDispense}},
+		Identifier:   []Identifier{identifier(SystemPrescriptionFill, f.ID)},
+		Status:       fillStatus(f.FillStatus),
+		Subject:      reference("Patient", f.Patient),
+		Note:         notes(f.NoteFromPharmacy),
+	}
+
+	if f.MedicationOrder != 0 {
+		dispense.AuthorizingPrescription = []Reference{reference("MedicationRequest", f.MedicationOrder)}
+	}
+
+	if f.FillDate != nil && dispense.Status == "completed" {
+		dispense.WhenHandedOver = f.FillDate.String()
+	}
+
+	if order != nil {
+		dispense.MedicationCodeableConcept = patientMedicationConcept(order.Medication)
+	}
+
+	return dispense
+}
+
+func medicationOrderStatus(m *elation.PatientMedication) string {
+	switch {
+	case m.DeletedDate != nil:
+		return "entered-in-error"
+	case m.Thread != nil && m.Thread.DcDate != "":
+		return "stopped"
+	default:
+		return "active"
+	}
+}
+
+func fillStatus(status string) string {
+	switch strings.ToLower(status) {
+	case "filled", "dispensed", "completed":
+		return "completed"
+	case "partially_filled", "partial":
+		return "in-progress"
+	case "not_filled", "rejected":
+		return "declined"
+	case "returned_to_stock", "cancelled":
+		return "cancelled"
+	default:
+		return "unknown"
+	}
+}
+
+func patientMedicationConcept(m *elation.Medication) CodeableConcept {
+	if m == nil {
+		return CodeableConcept{}
+	}
+
+	return medicationConcept(m.Name, m.RxnormCuis, nil)
+}
+
+func medicationConcept(name string, rxnormCUIs []string, ndcs []string) CodeableConcept {
+	c := CodeableConcept{Text: name}
+
+	for _, cui := range rxnormCUIs {
+		c.Coding = append(c.Coding, Coding{System: SystemRxNorm, Code: cui})
+	}
+
+	for _, ndc := range ndcs {
+		c.Coding = append(c.Coding, Coding{System: SystemNDC, Code: ndc})
+	}
+
+	return c
+}
+
+func icd10Concepts(codes []*elation.PatientMedicationICD10Code) []CodeableConcept {
+	var concepts []CodeableConcept
+	for _, code := range codes {
+		concepts = append(concepts, CodeableConcept{
+			Coding: []Coding{{System: SystemICD10CM, Code: code.Code, Display: code.Description}},
+		})
+	}
+
+	return concepts
+}
+
+// quantity returns a Quantity of value, or nil if value is not a number.
+func quantity(value string, unit string) *Quantity {
+	value = strings.TrimSpace(value)
+	if _, err := strconv.ParseFloat(value, 64); err != nil {
+		return nil
+	}
+
+	return &Quantity{Value: json.Number(value), Unit: unit}
+}
+
+func notes(text string) []Annotation {
+	if text == "" {
+		return nil
+	}
+
+	return []Annotation{{Text: text}}
+}
+
+func dosages(text string) []Dosage {
+	if text == "" {
+		return nil
+	}
+
+	return []Dosage{{Text: text}}
+}
@@ -0,0 +1,210 @@
+package fhir
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/authorhealth/go-elation"
+	"github.com/stretchr/testify/assert"
+)
+
+func testMedicationOrder() *elation.PatientMedication {
+	return &elation.PatientMedication{
+		ID:      1,
+		Patient: 2,
+		Medication: &elation.Medication{
+			Name:       "Lisinopril 10 mg tablet",
+			RxnormCuis: []string{"314076"},
+		},
+		Qty:                  "30",
+		QtyUnits:             "tablet",
+		AuthRefills:          2,
+		PrescribingPhysician: 3,
+		Directions:           "Take 1 tablet by mouth daily",
+		StartDate:            "2024-01-01",
+		DocumentDate:         testTime,
+		Icd10Codes:           []*elation.PatientMedicationICD10Code{{Code: "I10", Description: "Essential hypertension"}},
+	}
+}
+
+func TestFromElationMedicationOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := json.Marshal(FromElationMedicationOrder(testMedicationOrder()))
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"resourceType": "MedicationRequest",
+		"id": "1",
+		"meta": {"profile": ["http://hl7.org/fhir/us/core/StructureDefinition/us-core-medicationrequest"]},
+		"identifier": [{"system": "https://elationhealth.com/fhir/medication-order", "value": "1"}],
+		"status": "active",
+		"intent": "order",
+		"category": [{"coding": [{"system": "http://terminology.hl7.org/CodeSystem/medicationrequest-category", "code": "community", "display": "Community"}]}],
+		"medicationCodeableConcept": {
+			"coding": [{"system": "http://www.nlm.nih.gov/research/umls/rxnorm", "code": "314076"}],
+			"text": "Lisinopril 10 mg tablet"
+		},
+		"subject": {"reference": "Patient/2"},
+		"authoredOn": "2024-01-02T03:04:05Z",
+		"requester": {"reference": "Practitioner/3"},
+		"reasonCode": [{"coding": [{"system": "http://hl7.org/fhir/sid/icd-10-cm", "code": "I10", "display": "Essential hypertension"}]}],
+		"dosageInstruction": [{"text": "Take 1 tablet by mouth daily"}],
+		"dispenseRequest": {"numberOfRepeatsAllowed": 2, "quantity": {"value": 30, "unit": "tablet"}}
+	}`, string(b))
+}
+
+func TestFromElationMedicationOrder_status(t *testing.T) {
+	testCases := map[string]struct {
+		order                   func(m *elation.PatientMedication)
+		expectedStatus          string
+		expectedStatementStatus string
+	}{
+		"active": {
+			order:                   func(m *elation.PatientMedication) {},
+			expectedStatus:          "active",
+			expectedStatementStatus: "active",
+		},
+		"discontinued": {
+			order: func(m *elation.PatientMedication) {
+				m.Thread = &elation.PatientMedicationThread{DcDate: "2024-02-01"}
+			},
+			expectedStatus:          "stopped",
+			expectedStatementStatus: "stopped",
+		},
+		"deleted": {
+			order: func(m *elation.PatientMedication) {
+				m.DeletedDate = &testTime
+			},
+			expectedStatus:          "entered-in-error",
+			expectedStatementStatus: "entered-in-error",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			order := testMedicationOrder()
+			testCase.order(order)
+
+			assert.Equal(testCase.expectedStatus, FromElationMedicationOrder(order).Status)
+			assert.Equal(testCase.expectedStatementStatus, FromElationMedicationStatement(order).Status)
+		})
+	}
+}
+
+func TestFromElationMedicationOrder_quantity(t *testing.T) {
+	assert := assert.New(t)
+
+	order := testMedicationOrder()
+	order.Qty = "one box"
+	order.AuthRefills = 0
+	order.PrescribingPhysician = 0
+
+	request := roundTrip(t, FromElationMedicationOrder(order))
+	assert.Nil(request.DispenseRequest)
+	assert.Nil(request.Requester)
+}
+
+func TestFromElationMedicationStatement(t *testing.T) {
+	assert := assert.New(t)
+
+	order := testMedicationOrder()
+	order.IsDocMed = true
+
+	statement := roundTrip(t, FromElationMedicationStatement(order))
+	assert.Equal("patientspecified", statement.Category.code(SystemMedicationStatementCategory))
+	assert.Equal("314076", statement.MedicationCodeableConcept.code(SystemRxNorm))
+	assert.Equal("2024-01-01", statement.EffectiveDateTime)
+	assert.Equal("2024-01-02T03:04:05Z", statement.DateAsserted)
+	assert.Equal([]Dosage{{Text: "Take 1 tablet by mouth daily"}}, statement.Dosage)
+}
+
+func TestFromElationDiscontinuedMedication(t *testing.T) {
+	assert := assert.New(t)
+
+	statement := FromElationDiscontinuedMedication(&elation.DiscontinuedMedication{
+		ID:              1,
+		MedOrder:        2,
+		DiscontinueDate: "2024-02-01",
+		Reason:          "Side effects",
+		Medication: &elation.DiscontinuedMedicationMedication{
+			Name:       "Lisinopril 10 mg tablet",
+			RxnormCuis: []string{"314076"},
+			NDCs:       []string{"00093-7339-01"},
+		},
+		DocumentDate: testTime,
+		Patient:      3,
+	})
+
+	b, err := json.Marshal(statement)
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"resourceType": "MedicationStatement",
+		"id": "1",
+		"identifier": [
+			{"system": "https://elationhealth.com/fhir/discontinued-medication", "value": "1"},
+			{"system": "https://elationhealth.com/fhir/medication-order", "value": "2"}
+		],
+		"status": "stopped",
+		"statusReason": [{"text": "Side effects"}],
+		"medicationCodeableConcept": {
+			"coding": [
+				{"system": "http://www.nlm.nih.gov/research/umls/rxnorm", "code": "314076"},
+				{"system": "http://hl7.org/fhir/sid/ndc", "code": "00093-7339-01"}
+			],
+			"text": "Lisinopril 10 mg tablet"
+		},
+		"subject": {"reference": "Patient/3"},
+		"effectivePeriod": {"end": "2024-02-01"},
+		"dateAsserted": "2024-01-02T03:04:05Z"
+	}`, string(b))
+}
+
+func TestFromElationPrescriptionFill(t *testing.T) {
+	testCases := map[string]struct {
+		fill                   *elation.PrescriptionFill
+		order                  *elation.PatientMedication
+		expectedStatus         string
+		expectedWhenHandedOver string
+		expectedRxNorm         string
+	}{
+		"filled": {
+			fill: &elation.PrescriptionFill{
+				ID:              1,
+				MedicationOrder: 1,
+				FillStatus:      "filled",
+				FillDate:        &civil.Date{Year: 2024, Month: time.January, Day: 3},
+				Patient:         2,
+			},
+			order:                  testMedicationOrder(),
+			expectedStatus:         "completed",
+			expectedWhenHandedOver: "2024-01-03",
+			expectedRxNorm:         "314076",
+		},
+		"partially filled": {
+			fill:           &elation.PrescriptionFill{ID: 1, FillStatus: "partially_filled", Patient: 2},
+			expectedStatus: "in-progress",
+		},
+		"not filled": {
+			fill:           &elation.PrescriptionFill{ID: 1, FillStatus: "not_filled", NoteFromPharmacy: "Out of stock", Patient: 2},
+			expectedStatus: "declined",
+		},
+		"unknown": {
+			fill:           &elation.PrescriptionFill{ID: 1, FillStatus: "other", Patient: 2},
+			expectedStatus: "unknown",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			dispense := roundTrip(t, FromElationPrescriptionFill(testCase.fill, testCase.order))
+			assert.Equal(testCase.expectedStatus, dispense.Status)
+			assert.Equal(testCase.expectedWhenHandedOver, dispense.WhenHandedOver)
+			assert.Equal(testCase.expectedRxNorm, dispense.MedicationCodeableConcept.code(SystemRxNorm))
+			assert.Equal(Reference{Reference: "Patient/2"}, dispense.Subject)
+		})
+	}
+}
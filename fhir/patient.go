@@ -0,0 +1,564 @@
+package fhir
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/authorhealth/go-elation"
+)
+
+type Patient struct {
+	ResourceType     string                 `json:"resourceType"`
+	ID               string                 `json:"id,omitempty"`
+	Meta             *Meta                  `json:"meta,omitempty"`
+	Extension        []Extension            `json:"extension,omitempty"`
+	Identifier       []Identifier           `json:"identifier,omitempty"`
+	Active           *bool                  `json:"active,omitempty"`
+	Name             []HumanName            `json:"name,omitempty"`
+	Telecom          []ContactPoint         `json:"telecom,omitempty"`
+	Gender           string                 `json:"gender,omitempty"`
+	BirthDate        string                 `json:"birthDate,omitempty"`
+	DeceasedDateTime string                 `json:"deceasedDateTime,omitempty"`
+	Address          []Address              `json:"address,omitempty"`
+	Communication    []PatientCommunication `json:"communication,omitempty"`
+}
+
+type PatientCommunication struct {
+	Language  CodeableConcept `json:"language"`
+	Preferred bool            `json:"preferred,omitempty"`
+}
+
+type RelatedPerson struct {
+	ResourceType string            `json:"resourceType"`
+	ID           string            `json:"id,omitempty"`
+	Meta         *Meta             `json:"meta,omitempty"`
+	Identifier   []Identifier      `json:"identifier,omitempty"`
+	Active       *bool             `json:"active,omitempty"`
+	Patient      Reference         `json:"patient"`
+	Relationship []CodeableConcept `json:"relationship,omitempty"`
+	Name         []HumanName       `json:"name,omitempty"`
+	Telecom      []ContactPoint    `json:"telecom,omitempty"`
+	Address      []Address         `json:"address,omitempty"`
+}
+
+// Codes of the SystemRelatedPersonRole system.
+const (
+	RelatedPersonRoleGuarantor        = "guarantor"
+	RelatedPersonRoleEmergencyContact = "emergency-contact"
+)
+
+// races are the OMB race categories of the Elation race values.
+var races = map[string]Coding{
+	"American Indian or Alaska Native":          {SystemCDCRace, "1002-5", "American Indian or Alaska Native"},
+	"Asian":                                     {SystemCDCRace, "2028-9", "Asian"},
+	"Black or African American":                 {SystemCDCRace, "2054-5", "Black or African American"},
+	"Native Hawaiian or Other Pacific Islander": {SystemCDCRace, "2076-8", "Native Hawaiian or Other Pacific Islander"},
+	"White":               {SystemCDCRace, "2106-3", "White"},
+	"Declined to specify": {SystemNullFlavor, "ASKU", "Asked but no answer"},
+}
+
+// ethnicities are the OMB ethnicity categories of the Elation ethnicity values.
+var ethnicities = map[string]Coding{
+	"Hispanic or Latino":     {SystemCDCRace, "2135-2", "Hispanic or Latino"},
+	"Not Hispanic or Latino": {SystemCDCRace, "2186-5", "Not Hispanic or Latino"},
+	"Declined to specify":    {SystemNullFlavor, "ASKU", "Asked but no answer"},
+}
+
+// languages are the BCP 47 codes of common Elation preferred languages. Other languages are only mapped as text.
+var languages = map[string]string{
+	"english":    "en",
+	"spanish":    "es",
+	"chinese":    "zh",
+	"mandarin":   "zh",
+	"cantonese":  "yue",
+	"vietnamese": "vi",
+	"tagalog":    "tl",
+	"korean":     "ko",
+	"russian":    "ru",
+	"arabic":     "ar",
+	"french":     "fr",
+	"portuguese": "pt",
+	"german":     "de",
+	"japanese":   "ja",
+	"hindi":      "hi",
+}
+
+// relationships are the v3 RoleCode codes of common Elation relationship values.
+var relationships = map[string]string{
+	"self":             "ONESELF",
+	"spouse":           "SPS",
+	"domestic partner": "DOMPART",
+	"child":            "CHILD",
+	"parent":           "PRN",
+	"mother":           "MTH",
+	"father":           "FTH",
+	"sibling":          "SIB",
+	"grandparent":      "GRPRN",
+	"guardian":         "GUARD",
+	"friend":           "FRND",
+}
+
+// FromElationPatient converts p to a US Core Patient. The guarantor and emergency contact are converted by
+// FromElationGuarantor and FromElationEmergencyContact.
+//
+// Deleted phones and emails are omitted. Phone types other than Home, Mobile, Work and Fax are mapped without a use,
+// and are converted back as Main.
+func FromElationPatient(p *elation.Patient) *Patient {
+	patient := &Patient{
+		ResourceType: "Patient",
+		ID:           formatID(p.ID),
+		Meta:         &Meta{Profile: []string{ProfilePatient}},
+		Identifier:   []Identifier{identifier(SystemPatient, p.ID)},
+		BirthDate:    p.DOB,
+		Gender:       gender(p.Sex),
+	}
+
+	if p.SSN != "" {
+		patient.Identifier = append(patient.Identifier, Identifier{
+			Type:   &CodeableConcept{Coding: []Coding{{System: SystemIdentifierType, Code: "SS"}}},
+			System: SystemSSN,
+			Value:  p.SSN,
+		})
+	}
+
+	if race, ok := ombExtension(ExtensionRace, p.Race, races); ok {
+		patient.Extension = append(patient.Extension, race)
+	}
+
+	if ethnicity, ok := ombExtension(ExtensionEthnicity, p.Ethnicity, ethnicities); ok {
+		patient.Extension = append(patient.Extension, ethnicity)
+	}
+
+	if code := birthSex(p.Sex); code != "" {
+		patient.Extension = append(patient.Extension, Extension{URL: ExtensionBirthSex, ValueCode: code})
+	}
+
+	patient.Name = append(patient.Name, HumanName{
+		Use:    "official",
+		Family: p.LastName,
+		Given:  nonEmpty(p.FirstName, p.MiddleName),
+	})
+
+	if p.ActualName != "" {
+		patient.Name = append(patient.Name, HumanName{Use: "usual", Given: []string{p.ActualName}})
+	}
+
+	if p.PreviousFirstName != "" || p.PreviousLastName != "" {
+		patient.Name = append(patient.Name, HumanName{
+			Use:    "old",
+			Family: p.PreviousLastName,
+			Given:  nonEmpty(p.PreviousFirstName),
+		})
+	}
+
+	for _, phone := range p.Phones {
+		if phone.DeletedDate == nil && phone.Phone != "" {
+			patient.Telecom = append(patient.Telecom, phoneContactPoint(phone.Phone, phone.PhoneType))
+		}
+	}
+
+	for _, email := range p.Emails {
+		if email.DeletedDate == nil && email.Email != "" {
+			patient.Telecom = append(patient.Telecom, ContactPoint{System: "email", Value: email.Email})
+		}
+	}
+
+	if p.Address != nil {
+		patient.Address = []Address{{
+			Use:        "home",
+			Line:       nonEmpty(p.Address.AddressLine1, p.Address.AddressLine2),
+			City:       p.Address.City,
+			State:      p.Address.State,
+			PostalCode: p.Address.Zip,
+		}}
+	}
+
+	if p.PreferredLanguage != "" {
+		language := CodeableConcept{Text: p.PreferredLanguage}
+		if code, ok := languages[strings.ToLower(p.PreferredLanguage)]; ok {
+			language.Coding = []Coding{{System: SystemLanguage, Code: code}}
+		}
+
+		patient.Communication = []PatientCommunication{{Language: language, Preferred: true}}
+	}
+
+	if p.PatientStatus != nil {
+		active := p.PatientStatus.Status == "active"
+		patient.Active = &active
+		patient.DeceasedDateTime = p.PatientStatus.DeceasedDate
+	}
+
+	return patient
+}
+
+// ToElationPatient converts a Patient created by FromElationPatient back to an elation.Patient.
+func ToElationPatient(patient *Patient) (*elation.Patient, error) {
+	if patient.ResourceType != "Patient" {
+		return nil, fmt.Errorf("%w %q", ErrResourceType, patient.ResourceType)
+	}
+
+	p := &elation.Patient{
+		ID:        identifierID(patient.Identifier, SystemPatient),
+		SSN:       identifierValue(patient.Identifier, SystemSSN),
+		DOB:       patient.BirthDate,
+		Sex:       sex(patient.Gender),
+		Race:      ombText(patient.Extension, ExtensionRace),
+		Ethnicity: ombText(patient.Extension, ExtensionEthnicity),
+	}
+
+	if p.ID == 0 && patient.ID != "" {
+		id, err := strconv.ParseInt(patient.ID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsing ID: %w", err)
+		}
+
+		p.ID = id
+	}
+
+	for _, name := range patient.Name {
+		switch name.Use {
+		case "official", "":
+			p.LastName = name.Family
+			if len(name.Given) > 0 {
+				p.FirstName = name.Given[0]
+			}
+			if len(name.Given) > 1 {
+				p.MiddleName = strings.Join(name.Given[1:], " ")
+			}
+		case "usual":
+			p.ActualName = strings.Join(name.Given, " ")
+		case "old":
+			p.PreviousLastName = name.Family
+			p.PreviousFirstName = strings.Join(name.Given, " ")
+		}
+	}
+
+	for _, telecom := range patient.Telecom {
+		switch telecom.System {
+		case "phone", "fax":
+			p.Phones = append(p.Phones, &elation.PatientPhone{Phone: telecom.Value, PhoneType: phoneType(telecom)})
+		case "email":
+			p.Emails = append(p.Emails, &elation.PatientEmail{Email: telecom.Value})
+		}
+	}
+
+	if len(patient.Address) > 0 {
+		address := patient.Address[0]
+		p.Address = &elation.PatientAddress{
+			City:  address.City,
+			State: address.State,
+			Zip:   address.PostalCode,
+		}
+
+		if len(address.Line) > 0 {
+			p.Address.AddressLine1 = address.Line[0]
+		}
+		if len(address.Line) > 1 {
+			p.Address.AddressLine2 = strings.Join(address.Line[1:], " ")
+		}
+	}
+
+	for _, communication := range patient.Communication {
+		if communication.Preferred || len(patient.Communication) == 1 {
+			p.PreferredLanguage = communication.Language.Text
+			break
+		}
+	}
+
+	if patient.Active != nil {
+		p.PatientStatus = &elation.PatientStatus{
+			Status:       "inactive",
+			DeceasedDate: patient.DeceasedDateTime,
+		}
+
+		switch {
+		case *patient.Active:
+			p.PatientStatus.Status = "active"
+		case patient.DeceasedDateTime != "":
+			p.PatientStatus.Status = "deceased"
+		}
+	}
+
+	return p, nil
+}
+
+// FromElationGuarantor converts the guarantor of p to a RelatedPerson, or returns nil if p has none.
+func FromElationGuarantor(p *elation.Patient) *RelatedPerson {
+	g := p.Guarantor
+	if g == nil {
+		return nil
+	}
+
+	person := newRelatedPerson(p.ID, RelatedPersonRoleGuarantor, g.Relationship)
+	person.ID = formatID(g.ID)
+	person.Identifier = []Identifier{identifier(SystemGuarantor, g.ID)}
+	person.Name = []HumanName{{Family: g.LastName, Given: nonEmpty(g.FirstName, g.MiddleName)}}
+
+	if g.Phone != "" {
+		person.Telecom = []ContactPoint{{System: "phone", Value: g.Phone}}
+	}
+
+	if g.Address != "" || g.City != "" || g.State != "" || g.Zip != "" {
+		person.Address = []Address{{Line: nonEmpty(g.Address), City: g.City, State: g.State, PostalCode: g.Zip}}
+	}
+
+	return person
+}
+
+// ToElationGuarantor converts a RelatedPerson created by FromElationGuarantor back to an elation.PatientGuarantor.
+func ToElationGuarantor(person *RelatedPerson) (*elation.PatientGuarantor, error) {
+	if person.ResourceType != "RelatedPerson" {
+		return nil, fmt.Errorf("%w %q", ErrResourceType, person.ResourceType)
+	}
+
+	g := &elation.PatientGuarantor{
+		ID:           identifierID(person.Identifier, SystemGuarantor),
+		Relationship: relationshipText(person.Relationship),
+	}
+
+	g.FirstName, g.MiddleName, g.LastName = personName(person.Name)
+
+	if len(person.Telecom) > 0 {
+		g.Phone = person.Telecom[0].Value
+	}
+
+	if len(person.Address) > 0 {
+		address := person.Address[0]
+		g.Address = strings.Join(address.Line, " ")
+		g.City = address.City
+		g.State = address.State
+		g.Zip = address.PostalCode
+	}
+
+	return g, nil
+}
+
+// FromElationEmergencyContact converts the emergency contact of p to a RelatedPerson, or returns nil if p has none.
+// Emergency contacts have no ID of their own, so the RelatedPerson's ID is derived from the patient's.
+func FromElationEmergencyContact(p *elation.Patient) *RelatedPerson {
+	c := p.EmergencyContact
+	if c == nil {
+		return nil
+	}
+
+	person := newRelatedPerson(p.ID, RelatedPersonRoleEmergencyContact, c.Relationship)
+	person.ID = formatID(p.ID) + "-emergency-contact"
+	person.Relationship[0].Coding = append(person.Relationship[0].Coding, Coding{System: SystemContactRole, Code: "C", Display: "Emergency Contact"})
+	person.Name = []HumanName{{Family: c.LastName, Given: nonEmpty(c.FirstName)}}
+
+	if c.Phone != "" {
+		person.Telecom = []ContactPoint{{System: "phone", Value: c.Phone}}
+	}
+
+	if c.AddressLine1 != "" || c.City != "" || c.State != "" || c.Zip != "" {
+		person.Address = []Address{{
+			Line:       nonEmpty(c.AddressLine1, c.AddressLine2),
+			City:       c.City,
+			State:      c.State,
+			PostalCode: c.Zip,
+		}}
+	}
+
+	return person
+}
+
+// ToElationEmergencyContact converts a RelatedPerson created by FromElationEmergencyContact back to an
+// elation.PatientContact.
+func ToElationEmergencyContact(person *RelatedPerson) (*elation.PatientContact, error) {
+	if person.ResourceType != "RelatedPerson" {
+		return nil, fmt.Errorf("%w %q", ErrResourceType, person.ResourceType)
+	}
+
+	c := &elation.PatientContact{
+		Relationship: relationshipText(person.Relationship),
+	}
+
+	var middleName string
+	c.FirstName, middleName, c.LastName = personName(person.Name)
+	c.FirstName = strings.Join(nonEmpty(c.FirstName, middleName), " ")
+
+	if len(person.Telecom) > 0 {
+		c.Phone = person.Telecom[0].Value
+	}
+
+	if len(person.Address) > 0 {
+		address := person.Address[0]
+		if len(address.Line) > 0 {
+			c.AddressLine1 = address.Line[0]
+		}
+		if len(address.Line) > 1 {
+			c.AddressLine2 = strings.Join(address.Line[1:], " ")
+		}
+		c.City = address.City
+		c.State = address.State
+		c.Zip = address.PostalCode
+	}
+
+	return c, nil
+}
+
+// RelatedPersons returns the guarantor and emergency contact of p that it has.
+func RelatedPersons(p *elation.Patient) []*RelatedPerson {
+	var persons []*RelatedPerson
+	for _, person := range []*RelatedPerson{FromElationGuarantor(p), FromElationEmergencyContact(p)} {
+		if person != nil {
+			persons = append(persons, person)
+		}
+	}
+
+	return persons
+}
+
+// newRelatedPerson returns a RelatedPerson of patientID whose first relationship is its role and whose second is the
+// Elation relationship.
+func newRelatedPerson(patientID int64, role string, relationship string) *RelatedPerson {
+	person := &RelatedPerson{
+		ResourceType: "RelatedPerson",
+		Meta:         &Meta{Profile: []string{ProfileRelatedPerson}},
+		Patient:      reference("Patient", patientID),
+		Relationship: []CodeableConcept{{Coding: []Coding{{System: SystemRelatedPersonRole, Code: role}}}},
+	}
+
+	if relationship != "" {
+		concept := CodeableConcept{Text: relationship}
+		if code, ok := relationships[strings.ToLower(relationship)]; ok {
+			concept.Coding = []Coding{{System: SystemRoleCode, Code: code}}
+		}
+
+		person.Relationship = append(person.Relationship, concept)
+	}
+
+	return person
+}
+
+// relationshipText returns the Elation relationship of a RelatedPerson created by newRelatedPerson.
+func relationshipText(concepts []CodeableConcept) string {
+	for _, concept := range concepts {
+		if concept.code(SystemRelatedPersonRole) == "" && concept.Text != "" {
+			return concept.Text
+		}
+	}
+
+	return ""
+}
+
+func personName(names []HumanName) (first string, middle string, last string) {
+	if len(names) == 0 {
+		return "", "", ""
+	}
+
+	name := names[0]
+	if len(name.Given) > 0 {
+		first = name.Given[0]
+	}
+	if len(name.Given) > 1 {
+		middle = strings.Join(name.Given[1:], " ")
+	}
+
+	return first, middle, name.Family
+}
+
+// ombExtension returns a US Core race or ethnicity extension for value, with its OMB category if it has one.
+func ombExtension(url string, value string, categories map[string]Coding) (Extension, bool) {
+	if value == "" {
+		return Extension{}, false
+	}
+
+	ext := Extension{URL: url}
+	if coding, ok := categories[value]; ok {
+		ext.Extension = append(ext.Extension, Extension{URL: "ombCategory", ValueCoding: &coding})
+	}
+
+	ext.Extension = append(ext.Extension, Extension{URL: "text", ValueString: value})
+
+	return ext, true
+}
+
+func ombText(extensions []Extension, url string) string {
+	ext := extension(extensions, url)
+	if ext == nil {
+		return ""
+	}
+
+	if text := extension(ext.Extension, "text"); text != nil {
+		return text.ValueString
+	}
+
+	return ""
+}
+
+func gender(sex string) string {
+	switch strings.ToLower(sex) {
+	case "male":
+		return "male"
+	case "female":
+		return "female"
+	case "other":
+		return "other"
+	case "":
+		return ""
+	default:
+		return "unknown"
+	}
+}
+
+func sex(gender string) string {
+	switch gender {
+	case "male":
+		return "Male"
+	case "female":
+		return "Female"
+	case "other":
+		return "Other"
+	case "unknown":
+		return "Unknown"
+	default:
+		return ""
+	}
+}
+
+func birthSex(sex string) string {
+	switch strings.ToLower(sex) {
+	case "male":
+		return "M"
+	case "female":
+		return "F"
+	case "":
+		return ""
+	default:
+		return "UNK"
+	}
+}
+
+func phoneContactPoint(phone string, phoneType string) ContactPoint {
+	switch strings.ToLower(phoneType) {
+	case "home":
+		return ContactPoint{System: "phone", Value: phone, Use: "home"}
+	case "mobile":
+		return ContactPoint{System: "phone", Value: phone, Use: "mobile"}
+	case "work":
+		return ContactPoint{System: "phone", Value: phone, Use: "work"}
+	case "fax":
+		return ContactPoint{System: "fax", Value: phone}
+	default:
+		return ContactPoint{System: "phone", Value: phone}
+	}
+}
+
+func phoneType(telecom ContactPoint) string {
+	if telecom.System == "fax" {
+		return "Fax"
+	}
+
+	switch telecom.Use {
+	case "home":
+		return "Home"
+	case "mobile":
+		return "Mobile"
+	case "work":
+		return "Work"
+	default:
+		return "Main"
+	}
+}
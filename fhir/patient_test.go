@@ -0,0 +1,187 @@
+package fhir
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/authorhealth/go-elation"
+	"github.com/stretchr/testify/assert"
+)
+
+// roundTrip marshals and unmarshals v, as a FHIR server would store it.
+func roundTrip[T any](t *testing.T, v *T) *T {
+	b, err := json.Marshal(v)
+	assert.NoError(t, err)
+
+	out := new(T)
+	assert.NoError(t, json.Unmarshal(b, out))
+
+	return out
+}
+
+func TestPatient(t *testing.T) {
+	testCases := map[string]struct {
+		patient *elation.Patient
+	}{
+		"full": {
+			patient: &elation.Patient{
+				ID:                1,
+				FirstName:         "Jane",
+				MiddleName:        "Q",
+				LastName:          "Doe",
+				ActualName:        "Janie",
+				PreviousLastName:  "Smith",
+				Sex:               "Female",
+				DOB:               "1980-01-02",
+				SSN:               "123-45-6789",
+				Race:              "Asian",
+				Ethnicity:         "Not Hispanic or Latino",
+				PreferredLanguage: "Spanish",
+				Address: &elation.PatientAddress{
+					AddressLine1: "1 Main St",
+					AddressLine2: "Apt 2",
+					City:         "Springfield",
+					State:        "IL",
+					Zip:          "62701",
+				},
+				Phones: []*elation.PatientPhone{
+					{Phone: "555-0100", PhoneType: "Mobile"},
+					{Phone: "555-0101", PhoneType: "Fax"},
+					{Phone: "555-0102", PhoneType: "Main"},
+				},
+				Emails: []*elation.PatientEmail{
+					{Email: "jane@example.com"},
+				},
+				PatientStatus: &elation.PatientStatus{Status: "active"},
+			},
+		},
+		"minimal": {
+			patient: &elation.Patient{
+				ID:        2,
+				FirstName: "John",
+				LastName:  "Doe",
+				Sex:       "Unknown",
+				DOB:       "1981-01-02",
+			},
+		},
+		"deceased": {
+			patient: &elation.Patient{
+				ID:                3,
+				FirstName:         "Joan",
+				LastName:          "Doe",
+				Race:              "Declined to specify",
+				PreferredLanguage: "Klingon",
+				PatientStatus:     &elation.PatientStatus{Status: "deceased", DeceasedDate: "2020-01-01"},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			patient := roundTrip(t, FromElationPatient(testCase.patient))
+
+			actual, err := ToElationPatient(patient)
+			assert.NoError(err)
+			assert.Equal(testCase.patient, actual)
+		})
+	}
+}
+
+func TestFromElationPatient(t *testing.T) {
+	assert := assert.New(t)
+
+	patient := FromElationPatient(&elation.Patient{
+		ID:                1,
+		Sex:               "Female",
+		Race:              "White",
+		Ethnicity:         "Hispanic or Latino",
+		PreferredLanguage: "English",
+		Phones:            []*elation.PatientPhone{{Phone: "555-0100", PhoneType: "Home", DeletedDate: new(testTime)}},
+	})
+
+	b, err := json.Marshal(patient)
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"resourceType": "Patient",
+		"id": "1",
+		"meta": {"profile": ["http://hl7.org/fhir/us/core/StructureDefinition/us-core-patient"]},
+		"extension": [
+			{
+				"url": "http://hl7.org/fhir/us/core/StructureDefinition/us-core-race",
+				"extension": [
+					{"url": "ombCategory", "valueCoding": {"system": "urn:oid:2.16.840.1.113883.6.238", "code": "2106-3", "display": "White"}},
+					{"url": "text", "valueString": "White"}
+				]
+			},
+			{
+				"url": "http://hl7.org/fhir/us/core/StructureDefinition/us-core-ethnicity",
+				"extension": [
+					{"url": "ombCategory", "valueCoding": {"system": "urn:oid:2.16.840.1.113883.6.238", "code": "2135-2", "display": "Hispanic or Latino"}},
+					{"url": "text", "valueString": "Hispanic or Latino"}
+				]
+			},
+			{"url": "http://hl7.org/fhir/us/core/StructureDefinition/us-core-birthsex", "valueCode": "F"}
+		],
+		"identifier": [{"system": "https://elationhealth.com/fhir/patient", "value": "1"}],
+		"name": [{"use": "official"}],
+		"gender": "female",
+		"communication": [{"language": {"coding": [{"system": "urn:ietf:bcp:47", "code": "en"}], "text": "English"}, "preferred": true}]
+	}`, string(b))
+}
+
+func TestRelatedPersons(t *testing.T) {
+	assert := assert.New(t)
+
+	patient := &elation.Patient{
+		ID: 1,
+		Guarantor: &elation.PatientGuarantor{
+			ID:           10,
+			FirstName:    "John",
+			MiddleName:   "Q",
+			LastName:     "Doe",
+			Relationship: "Spouse",
+			Phone:        "555-0100",
+			Address:      "1 Main St",
+			City:         "Springfield",
+			State:        "IL",
+			Zip:          "62701",
+		},
+		EmergencyContact: &elation.PatientContact{
+			FirstName:    "Mary",
+			LastName:     "Doe",
+			Relationship: "Sister",
+			Phone:        "555-0101",
+			AddressLine1: "2 Main St",
+			City:         "Springfield",
+		},
+	}
+
+	persons := RelatedPersons(patient)
+	if !assert.Len(persons, 2) {
+		return
+	}
+
+	guarantor := roundTrip(t, persons[0])
+	assert.Equal("10", guarantor.ID)
+	assert.Equal(Reference{Reference: "Patient/1"}, guarantor.Patient)
+	assert.Equal("SPS", guarantor.Relationship[1].code(SystemRoleCode))
+
+	actualGuarantor, err := ToElationGuarantor(guarantor)
+	assert.NoError(err)
+	assert.Equal(patient.Guarantor, actualGuarantor)
+
+	contact := roundTrip(t, persons[1])
+	assert.Equal("1-emergency-contact", contact.ID)
+	assert.Equal("C", contact.Relationship[0].code(SystemContactRole))
+	assert.Empty(contact.Relationship[1].Coding)
+
+	actualContact, err := ToElationEmergencyContact(contact)
+	assert.NoError(err)
+	assert.Equal(patient.EmergencyContact, actualContact)
+
+	assert.Empty(RelatedPersons(&elation.Patient{ID: 1}))
+
+	_, err = ToElationGuarantor(&RelatedPerson{ResourceType: "Patient"})
+	assert.ErrorIs(err, ErrResourceType)
+}
@@ -0,0 +1,94 @@
+package fhir
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+)
+
+type Schedule struct {
+	ResourceType    string            `json:"resourceType"`
+	ID              string            `json:"id,omitempty"`
+	Identifier      []Identifier      `json:"identifier,omitempty"`
+	Active          *bool             `json:"active,omitempty"`
+	ServiceType     []CodeableConcept `json:"serviceType,omitempty"`
+	Actor           []Reference       `json:"actor"`
+	PlanningHorizon *Period           `json:"planningHorizon,omitempty"`
+	Comment         string            `json:"comment,omitempty"`
+}
+
+type Slot struct {
+	ResourceType string    `json:"resourceType"`
+	ID           string    `json:"id,omitempty"`
+	Schedule     Reference `json:"schedule"`
+	Status       string    `json:"status"`
+	Start        string    `json:"start"`
+	End          string    `json:"end"`
+	Comment      string    `json:"comment,omitempty"`
+}
+
+// FromElationRecurringEventGroup converts each schedule of g to a Schedule of its physician, planned over the series of
+// the schedule.
+func FromElationRecurringEventGroup(g *elation.RecurringEventGroup) []*Schedule {
+	var schedules []*Schedule
+	for _, s := range g.Schedules {
+		schedule := &Schedule{
+			ResourceType: "Schedule",
+			ID:           formatID(s.ID),
+			Identifier: []Identifier{
+				identifier(SystemRecurringEventSchedule, s.ID),
+				identifier(SystemRecurringEventGroup, g.ID),
+			},
+			Active:  new(g.DeletedDate == nil),
+			Actor:   []Reference{reference("Practitioner", s.Physician)},
+			Comment: s.Description,
+		}
+
+		if g.Reason != "" {
+			schedule.ServiceType = []CodeableConcept{{Text: g.Reason}}
+		}
+
+		if s.SeriesStart != "" || s.SeriesStop != "" {
+			schedule.PlanningHorizon = &Period{Start: s.SeriesStart, End: s.SeriesStop}
+		}
+
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules
+}
+
+// Slots expands the schedules of g into a Slot for every occurrence that starts in [from, to), with event times in
+// loc, the timezone of the practice. Slots of appointment slot groups are free, and the others are busy.
+func Slots(g *elation.RecurringEventGroup, from time.Time, to time.Time, loc *time.Location) ([]*Slot, error) {
+	status := "busy"
+	switch g.TimeSlotType {
+	case elation.AppointmentTimeSlotTypeAppointmentSlot:
+		status = "free"
+	case elation.AppointmentTimeSlotTypeEvent:
+		status = "busy-unavailable"
+	}
+
+	var slots []*Slot
+	for _, s := range g.Schedules {
+		starts, err := s.Occurrences(from, to, loc)
+		if err != nil {
+			return nil, fmt.Errorf("expanding schedule %d: %w", s.ID, err)
+		}
+
+		for _, start := range starts {
+			slots = append(slots, &Slot{
+				ResourceType: "Slot",
+				ID:           fmt.Sprintf("%d-%s", s.ID, start.UTC().Format("20060102T150405Z")),
+				Schedule:     reference("Schedule", s.ID),
+				Status:       status,
+				Start:        dateTime(start),
+				End:          dateTime(start.Add(time.Duration(s.Duration) * time.Minute)),
+				Comment:      s.Description,
+			})
+		}
+	}
+
+	return slots, nil
+}
@@ -0,0 +1,140 @@
+package fhir
+
+import (
+	"testing"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRecurringEventGroup() *elation.RecurringEventGroup {
+	return &elation.RecurringEventGroup{
+		ID:           1,
+		Practice:     2,
+		Reason:       "Lunch",
+		TimeSlotType: elation.AppointmentTimeSlotTypeEvent,
+		Schedules: []*elation.RecurringEventGroupSchedule{
+			{
+				ID:          3,
+				SeriesStart: "2024-03-01",
+				SeriesStop:  "2024-03-31",
+				EventTime:   "12:00:00",
+				Physician:   4,
+				Duration:    60,
+				Repeats:     "Weekly",
+				DOWMonday:   true,
+				DOWFriday:   true,
+				Description: "Lunch break",
+			},
+		},
+	}
+}
+
+func TestFromElationRecurringEventGroup(t *testing.T) {
+	assert := assert.New(t)
+
+	schedules := FromElationRecurringEventGroup(testRecurringEventGroup())
+	if !assert.Len(schedules, 1) {
+		return
+	}
+
+	schedule := roundTrip(t, schedules[0])
+	assert.Equal("3", schedule.ID)
+	assert.Equal(int64(1), identifierID(schedule.Identifier, SystemRecurringEventGroup))
+	assert.Equal([]Reference{{Reference: "Practitioner/4"}}, schedule.Actor)
+	assert.Equal(&Period{Start: "2024-03-01", End: "2024-03-31"}, schedule.PlanningHorizon)
+	assert.Equal([]CodeableConcept{{Text: "Lunch"}}, schedule.ServiceType)
+	assert.True(*schedule.Active)
+}
+
+func TestSlots(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	testCases := map[string]struct {
+		group          func(g *elation.RecurringEventGroup)
+		from           time.Time
+		to             time.Time
+		expectedStarts []string
+		expectedStatus string
+	}{
+		"weekly across DST": {
+			group: func(g *elation.RecurringEventGroup) {},
+			from:  time.Date(2024, time.March, 8, 0, 0, 0, 0, loc),
+			to:    time.Date(2024, time.March, 12, 0, 0, 0, 0, loc),
+			expectedStarts: []string{
+				"2024-03-08T12:00:00-05:00",
+				"2024-03-11T12:00:00-04:00",
+			},
+			expectedStatus: "busy-unavailable",
+		},
+		"series stop": {
+			group: func(g *elation.RecurringEventGroup) {},
+			from:  time.Date(2024, time.March, 28, 0, 0, 0, 0, loc),
+			to:    time.Date(2024, time.April, 10, 0, 0, 0, 0, loc),
+			expectedStarts: []string{
+				"2024-03-29T12:00:00-04:00",
+			},
+			expectedStatus: "busy-unavailable",
+		},
+		"daily appointment slots": {
+			group: func(g *elation.RecurringEventGroup) {
+				g.TimeSlotType = elation.AppointmentTimeSlotTypeAppointmentSlot
+				g.Schedules[0].Repeats = "Daily"
+				g.Schedules[0].DOWMonday = false
+				g.Schedules[0].DOWFriday = false
+			},
+			from: time.Date(2024, time.March, 1, 12, 0, 0, 0, loc),
+			to:   time.Date(2024, time.March, 3, 12, 0, 0, 0, loc),
+			expectedStarts: []string{
+				"2024-03-01T12:00:00-05:00",
+				"2024-03-02T12:00:00-05:00",
+			},
+			expectedStatus: "free",
+		},
+		"once": {
+			group: func(g *elation.RecurringEventGroup) {
+				g.Schedules[0].Repeats = ""
+			},
+			from: time.Date(2024, time.February, 1, 0, 0, 0, 0, loc),
+			to:   time.Date(2024, time.April, 1, 0, 0, 0, 0, loc),
+			expectedStarts: []string{
+				"2024-03-01T12:00:00-05:00",
+			},
+			expectedStatus: "busy-unavailable",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			group := testRecurringEventGroup()
+			testCase.group(group)
+
+			slots, err := Slots(group, testCase.from, testCase.to, loc)
+			assert.NoError(err)
+
+			var starts []string
+			for _, slot := range slots {
+				starts = append(starts, slot.Start)
+				assert.Equal(testCase.expectedStatus, slot.Status)
+				assert.Equal(Reference{Reference: "Schedule/3"}, slot.Schedule)
+			}
+
+			assert.Equal(testCase.expectedStarts, starts)
+		})
+	}
+}
+
+func TestSlots_error(t *testing.T) {
+	assert := assert.New(t)
+
+	group := testRecurringEventGroup()
+	group.Schedules[0].EventTime = "noon"
+
+	_, err := Slots(group, testTime, testTime.AddDate(0, 0, 7), time.UTC)
+	assert.ErrorContains(err, "expanding schedule 3: parsing event time")
+}
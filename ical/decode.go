@@ -0,0 +1,361 @@
+package ical
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+)
+
+var ErrUnsupportedRecurrence = errors.New("unsupported recurrence")
+
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+type component struct {
+	name       string
+	properties []*property
+	components []*component
+}
+
+func (c *component) property(name string) *property {
+	for _, p := range c.properties {
+		if p.name == name {
+			return p
+		}
+	}
+
+	return nil
+}
+
+func (c *component) value(name string) string {
+	if p := c.property(name); p != nil {
+		return p.value
+	}
+
+	return ""
+}
+
+// Decode reads a VCALENDAR from r. TZIDs must be IANA timezone names; the rules of VTIMEZONE components are not
+// interpreted. The Location of the calendar is the timezone of its first VTIMEZONE.
+func Decode(r io.Reader) (*Calendar, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading calendar: %w", err)
+	}
+
+	root, err := parse(string(b))
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &Calendar{}
+
+	for _, c := range root.components {
+		switch c.name {
+		case "VTIMEZONE":
+			if calendar.Location != nil {
+				continue
+			}
+
+			loc, err := time.LoadLocation(c.value("TZID"))
+			if err != nil {
+				return nil, fmt.Errorf("loading timezone: %w", err)
+			}
+
+			calendar.Location = loc
+		case "VEVENT":
+			event, err := decodeEvent(c)
+			if err != nil {
+				return nil, fmt.Errorf("decoding event %q: %w", c.value("UID"), err)
+			}
+
+			calendar.Events = append(calendar.Events, event)
+		}
+	}
+
+	return calendar, nil
+}
+
+// parse parses the content lines of s into the VCALENDAR component.
+func parse(s string) (*component, error) {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+
+	// Unfold lines that continue on the next line.
+	s = strings.ReplaceAll(s, "\n ", "")
+	s = strings.ReplaceAll(s, "\n\t", "")
+
+	var stack []*component
+	var root *component
+
+	for n, line := range strings.Split(s, "\n") {
+		if line == "" {
+			continue
+		}
+
+		p, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+
+		switch p.name {
+		case "BEGIN":
+			c := &component{name: strings.ToUpper(p.value)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.components = append(parent.components, c)
+			} else if c.name == "VCALENDAR" && root == nil {
+				root = c
+			}
+
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].name != strings.ToUpper(p.value) {
+				return nil, fmt.Errorf("line %d: unexpected END:%s", n+1, p.value)
+			}
+
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("line %d: property %s outside of a component", n+1, p.name)
+			}
+
+			c := stack[len(stack)-1]
+			c.properties = append(c.properties, p)
+		}
+	}
+
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("missing END:%s", stack[len(stack)-1].name)
+	}
+
+	if root == nil {
+		return nil, errors.New("missing VCALENDAR")
+	}
+
+	return root, nil
+}
+
+// parseLine parses a content line such as DTSTART;TZID=America/New_York:20240101T090000.
+func parseLine(line string) (*property, error) {
+	quoted := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+
+	if colon < 0 {
+		return nil, fmt.Errorf("missing value in %q", line)
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	p := &property{
+		name:   strings.ToUpper(parts[0]),
+		params: map[string]string{},
+		value:  line[colon+1:],
+	}
+
+	for _, param := range parts[1:] {
+		key, value, _ := strings.Cut(param, "=")
+		p.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+
+	return p, nil
+}
+
+func decodeEvent(c *component) (*Event, error) {
+	event := &Event{
+		UID:          c.value("UID"),
+		Summary:      unescapeText(c.value("SUMMARY")),
+		Description:  unescapeText(c.value("DESCRIPTION")),
+		Location:     unescapeText(c.value("LOCATION")),
+		Status:       c.value("STATUS"),
+		TimeSlotType: elation.TimeSlotType(c.value(propertyTimeSlotType)),
+	}
+
+	var err error
+
+	if stamp := c.property("DTSTAMP"); stamp != nil {
+		if event.Stamp, err = parseTime(stamp); err != nil {
+			return nil, fmt.Errorf("parsing DTSTAMP: %w", err)
+		}
+	}
+
+	start := c.property("DTSTART")
+	if start == nil {
+		return nil, errors.New("missing DTSTART")
+	}
+
+	if event.Start, err = parseTime(start); err != nil {
+		return nil, fmt.Errorf("parsing DTSTART: %w", err)
+	}
+
+	if duration := c.value("DURATION"); duration != "" {
+		if event.Duration, err = parseDuration(duration); err != nil {
+			return nil, fmt.Errorf("parsing DURATION: %w", err)
+		}
+	} else if end := c.property("DTEND"); end != nil {
+		endTime, err := parseTime(end)
+		if err != nil {
+			return nil, fmt.Errorf("parsing DTEND: %w", err)
+		}
+
+		event.Duration = endTime.Sub(event.Start)
+	}
+
+	if physician := c.value(propertyPhysician); physician != "" {
+		if event.Physician, err = strconv.ParseInt(physician, 10, 64); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", propertyPhysician, err)
+		}
+	}
+
+	if rrule := c.value("RRULE"); rrule != "" {
+		if event.Recurrence, err = parseRRule(rrule, event.Start.Location()); err != nil {
+			return nil, fmt.Errorf("parsing RRULE: %w", err)
+		}
+	}
+
+	return event, nil
+}
+
+// parseTime parses a DATE-TIME in UTC, in the timezone of its TZID, or floating, which is read as UTC. DATE values are
+// midnight.
+func parseTime(p *property) (time.Time, error) {
+	loc := time.UTC
+	if tzid := p.params["TZID"]; tzid != "" {
+		var err error
+		if loc, err = time.LoadLocation(tzid); err != nil {
+			return time.Time{}, fmt.Errorf("loading timezone: %w", err)
+		}
+	}
+
+	return parseTimeIn(p.value, loc)
+}
+
+func parseTimeIn(value string, loc *time.Location) (time.Time, error) {
+	switch {
+	case strings.HasSuffix(value, "Z"):
+		return time.Parse(dateTimeFormat+"Z", value)
+	case len(value) == len(dateFormat):
+		return time.ParseInLocation(dateFormat, value, loc)
+	default:
+		return time.ParseInLocation(dateTimeFormat, value, loc)
+	}
+}
+
+var durationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration parses a DURATION such as PT1H30M.
+func parseDuration(s string) (time.Duration, error) {
+	m := durationPattern.FindStringSubmatch(s)
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	var d time.Duration
+	for i, unit := range []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if m[i+2] == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(m[i+2])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+
+		d += time.Duration(n) * unit
+	}
+
+	if m[1] == "-" {
+		d = -d
+	}
+
+	return d, nil
+}
+
+// parseRRule parses an RRULE with an interval of 1 that repeats daily or weekly, optionally on days of the week, or
+// monthly or yearly on the day of the start, which are the recurrences that recurring event groups support. Others
+// return ErrUnsupportedRecurrence. A floating UNTIL is in loc.
+func parseRRule(s string, loc *time.Location) (*Recurrence, error) {
+	r := &Recurrence{}
+
+	for part := range strings.SplitSeq(s, ";") {
+		key, value, _ := strings.Cut(part, "=")
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.Frequency = strings.ToUpper(value)
+			if !slices.Contains([]string{FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly}, r.Frequency) {
+				return nil, fmt.Errorf("%w: FREQ=%s", ErrUnsupportedRecurrence, value)
+			}
+		case "INTERVAL":
+			if value != "1" {
+				return nil, fmt.Errorf("%w: INTERVAL=%s", ErrUnsupportedRecurrence, value)
+			}
+		case "UNTIL":
+			until, err := parseTimeIn(value, loc)
+			if err != nil {
+				return nil, fmt.Errorf("parsing UNTIL: %w", err)
+			}
+
+			r.Until = until
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil || count <= 0 {
+				return nil, fmt.Errorf("invalid COUNT %q", value)
+			}
+
+			r.Count = count
+		case "BYDAY":
+			for code := range strings.SplitSeq(value, ",") {
+				day, ok := parseWeekday(code)
+				if !ok {
+					return nil, fmt.Errorf("%w: BYDAY=%s", ErrUnsupportedRecurrence, value)
+				}
+
+				r.Weekdays = append(r.Weekdays, day)
+			}
+		case "WKST":
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedRecurrence, key)
+		}
+	}
+
+	if r.Frequency == "" {
+		return nil, errors.New("missing FREQ")
+	}
+
+	if len(r.Weekdays) > 0 && (r.Frequency == FrequencyMonthly || r.Frequency == FrequencyYearly) {
+		return nil, fmt.Errorf("%w: BYDAY with FREQ=%s", ErrUnsupportedRecurrence, r.Frequency)
+	}
+
+	return r, nil
+}
+
+func parseWeekday(code string) (time.Weekday, bool) {
+	for day, c := range weekdayCodes {
+		if strings.EqualFold(code, c) {
+			return day, true
+		}
+	}
+
+	return 0, false
+}
+
+func unescapeText(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(s)
+}
@@ -0,0 +1,160 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	assert := assert.New(t)
+
+	loc, err := time.LoadLocation("America/New_York")
+	if !assert.NoError(err) {
+		return
+	}
+
+	input := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Example//EN",
+		"BEGIN:VTIMEZONE",
+		"TZID:America/New_York",
+		"BEGIN:STANDARD",
+		"DTSTART:19701101T020000",
+		"TZOFFSETFROM:-0400",
+		"TZOFFSETTO:-0500",
+		"END:STANDARD",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:1@example.com",
+		"DTSTAMP:20240201T120000Z",
+		"DTSTART;TZID=America/New_York:20240304T090000",
+		"DTEND;TZID=America/New_York:20240304T093000",
+		"RRULE:FREQ=WEEKLY;WKST=SU;UNTIL=20240329T130000Z;BYDAY=MO,WE",
+		`SUMMARY:Office hours\, morning`,
+		"DESCRIPTION:A description that is folded",
+		"  onto the next line",
+		"X-ELATION-PHYSICIAN:4",
+		"X-ELATION-TIME-SLOT-TYPE:appointment_slot",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	calendar, err := Decode(strings.NewReader(input))
+	if !assert.NoError(err) || !assert.Len(calendar.Events, 1) {
+		return
+	}
+
+	assert.Equal(loc, calendar.Location)
+
+	event := calendar.Events[0]
+	assert.Equal("1@example.com", event.UID)
+	assert.True(time.Date(2024, time.February, 1, 12, 0, 0, 0, time.UTC).Equal(event.Stamp))
+	assert.Equal(time.Date(2024, time.March, 4, 9, 0, 0, 0, loc), event.Start)
+	assert.Equal(30*time.Minute, event.Duration)
+	assert.Equal("Office hours, morning", event.Summary)
+	assert.Equal("A description that is folded onto the next line", event.Description)
+	assert.Equal(int64(4), event.Physician)
+	assert.Equal(elation.AppointmentTimeSlotTypeAppointmentSlot, event.TimeSlotType)
+
+	if assert.NotNil(event.Recurrence) {
+		assert.Equal(FrequencyWeekly, event.Recurrence.Frequency)
+		assert.Equal([]time.Weekday{time.Monday, time.Wednesday}, event.Recurrence.Weekdays)
+		assert.True(time.Date(2024, time.March, 29, 9, 0, 0, 0, loc).Equal(event.Recurrence.Until))
+	}
+}
+
+func TestDecode_errors(t *testing.T) {
+	event := func(lines ...string) string {
+		return strings.Join(append(append([]string{"BEGIN:VCALENDAR", "BEGIN:VEVENT", "UID:1"}, lines...), "END:VEVENT", "END:VCALENDAR"), "\r\n")
+	}
+
+	testCases := map[string]struct {
+		input       string
+		expectedErr error
+	}{
+		"missing VCALENDAR": {
+			input: "BEGIN:VEVENT\r\nEND:VEVENT",
+		},
+		"missing END": {
+			input: "BEGIN:VCALENDAR\r\nBEGIN:VEVENT",
+		},
+		"missing DTSTART": {
+			input: event("SUMMARY:Lunch"),
+		},
+		"invalid duration": {
+			input: event("DTSTART:20240301T120000Z", "DURATION:P1X"),
+		},
+		"hourly": {
+			input:       event("DTSTART:20240301T120000Z", "RRULE:FREQ=HOURLY"),
+			expectedErr: ErrUnsupportedRecurrence,
+		},
+		"monthly by weekday": {
+			input:       event("DTSTART:20240301T120000Z", "RRULE:FREQ=MONTHLY;BYDAY=MO"),
+			expectedErr: ErrUnsupportedRecurrence,
+		},
+		"interval": {
+			input:       event("DTSTART:20240301T120000Z", "RRULE:FREQ=WEEKLY;INTERVAL=2"),
+			expectedErr: ErrUnsupportedRecurrence,
+		},
+		"ordinal weekday": {
+			input:       event("DTSTART:20240301T120000Z", "RRULE:FREQ=WEEKLY;BYDAY=1MO"),
+			expectedErr: ErrUnsupportedRecurrence,
+		},
+		"by month": {
+			input:       event("DTSTART:20240301T120000Z", "RRULE:FREQ=DAILY;BYMONTH=3"),
+			expectedErr: ErrUnsupportedRecurrence,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			_, err := Decode(strings.NewReader(testCase.input))
+			assert.Error(err)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(err, testCase.expectedErr)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		expected time.Duration
+	}{
+		"minutes": {
+			input:    "PT30M",
+			expected: 30 * time.Minute,
+		},
+		"days and hours": {
+			input:    "P1DT2H",
+			expected: 26 * time.Hour,
+		},
+		"weeks": {
+			input:    "P1W",
+			expected: 7 * 24 * time.Hour,
+		},
+		"negative": {
+			input:    "-PT15M",
+			expected: -15 * time.Minute,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			duration, err := parseDuration(testCase.input)
+			assert.NoError(err)
+			assert.Equal(testCase.expected, duration)
+		})
+	}
+}
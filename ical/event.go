@@ -0,0 +1,266 @@
+package ical
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/authorhealth/go-elation"
+)
+
+var ErrNoRecurringEvents = errors.New("no recurring events")
+
+// FromElationAppointment converts a to an Event. Cancelled and deleted appointments are CANCELLED.
+func FromElationAppointment(a *elation.Appointment) *Event {
+	event := &Event{
+		UID:          "appointment-" + strconv.FormatInt(a.ID, 10) + "@" + uidDomain,
+		Stamp:        a.LastModifiedDate,
+		Start:        a.ScheduledDate,
+		Duration:     time.Duration(a.Duration) * time.Minute,
+		Summary:      a.Reason,
+		Description:  a.Description,
+		Status:       "CONFIRMED",
+		Physician:    a.Physician,
+		TimeSlotType: elation.TimeSlotType(a.TimeSlotType),
+	}
+
+	if event.Stamp.IsZero() {
+		event.Stamp = a.CreatedDate
+	}
+
+	if a.ServiceLocation != nil {
+		event.Location = a.ServiceLocation.Name
+	}
+
+	if a.DeletedDate != nil || a.CurrentStatus() == elation.AppointmentStatusCancelled {
+		event.Status = "CANCELLED"
+	}
+
+	return event
+}
+
+// FromElationRecurringEventGroup converts each schedule of g to an Event with event times in loc, the timezone of the
+// practice. Daily and weekly schedules recur on the days of the week that are set, or every day if none are, and
+// monthly and yearly schedules on the day of the series start, until the end of the series. The event starts on the
+// first occurrence of the series.
+func FromElationRecurringEventGroup(g *elation.RecurringEventGroup, loc *time.Location) ([]*Event, error) {
+	var events []*Event
+	for _, s := range g.Schedules {
+		event, err := fromElationSchedule(g, s, loc)
+		if err != nil {
+			return nil, fmt.Errorf("converting schedule %d: %w", s.ID, err)
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func fromElationSchedule(g *elation.RecurringEventGroup, s *elation.RecurringEventGroupSchedule, loc *time.Location) (*Event, error) {
+	seriesStart, err := civil.ParseDate(s.SeriesStart)
+	if err != nil {
+		return nil, fmt.Errorf("parsing series start: %w", err)
+	}
+
+	eventTime, err := civil.ParseTime(s.EventTime)
+	if err != nil {
+		return nil, fmt.Errorf("parsing event time: %w", err)
+	}
+
+	event := &Event{
+		UID:          "recurring-event-schedule-" + strconv.FormatInt(s.ID, 10) + "@" + uidDomain,
+		Stamp:        s.CreatedDate,
+		Duration:     time.Duration(s.Duration) * time.Minute,
+		Summary:      g.Reason,
+		Description:  s.Description,
+		Physician:    s.Physician,
+		TimeSlotType: g.TimeSlotType,
+	}
+
+	if event.Stamp.IsZero() {
+		event.Stamp = g.CreatedDate
+	}
+
+	if g.DeletedDate != nil {
+		event.Status = "CANCELLED"
+	}
+
+	switch {
+	case strings.EqualFold(s.Repeats, elation.RecurringEventRepeatsDaily):
+		event.Recurrence = &Recurrence{Frequency: FrequencyDaily}
+	case strings.EqualFold(s.Repeats, elation.RecurringEventRepeatsWeekly):
+		event.Recurrence = &Recurrence{Frequency: FrequencyWeekly}
+	case strings.EqualFold(s.Repeats, elation.RecurringEventRepeatsMonthly):
+		event.Recurrence = &Recurrence{Frequency: FrequencyMonthly}
+	case strings.EqualFold(s.Repeats, elation.RecurringEventRepeatsYearly):
+		event.Recurrence = &Recurrence{Frequency: FrequencyYearly}
+	}
+
+	if event.Recurrence != nil && (event.Recurrence.Frequency == FrequencyDaily || event.Recurrence.Frequency == FrequencyWeekly) {
+		for i, ok := range []bool{s.DOWMonday, s.DOWTuesday, s.DOWWednesday, s.DOWThursday, s.DOWFriday, s.DOWSaturday, s.DOWSunday} {
+			if ok {
+				event.Recurrence.Weekdays = append(event.Recurrence.Weekdays, time.Weekday((i+1)%7))
+			}
+		}
+
+		// A weekly rule without days repeats only on the weekday of DTSTART, but Elation repeats every day.
+		if len(event.Recurrence.Weekdays) == 0 && event.Recurrence.Frequency == FrequencyWeekly {
+			event.Recurrence.Weekdays = []time.Weekday{
+				time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday,
+			}
+		}
+
+		// DTSTART is the first occurrence, so it has to be on one of the days.
+		if len(event.Recurrence.Weekdays) > 0 {
+			for !slices.Contains(event.Recurrence.Weekdays, seriesStart.Weekday()) {
+				seriesStart = seriesStart.AddDays(1)
+			}
+		}
+	}
+
+	if event.Recurrence != nil {
+		if s.SeriesStop != "" {
+			seriesStop, err := civil.ParseDate(s.SeriesStop)
+			if err != nil {
+				return nil, fmt.Errorf("parsing series stop: %w", err)
+			}
+
+			event.Recurrence.Until = localTime(seriesStop, eventTime, loc)
+		}
+	}
+
+	event.Start = localTime(seriesStart, eventTime, loc)
+
+	return event, nil
+}
+
+// ToElationRecurringEventGroupCreate converts the recurring events of c to the schedules of a recurring event group of
+// practice. The reason and time slot type of the group are those of the first recurring event, and events without a
+// time slot type are events. Events that do not recur are skipped; ErrNoRecurringEvents is returned if none do.
+//
+// Elation reads schedule times in loc, the timezone of the practice, so event times are converted to it. The days of
+// the week of a recurrence move with the date of its start.
+func ToElationRecurringEventGroupCreate(c *Calendar, practice int64, loc *time.Location) (*elation.RecurringEventGroupCreate, error) {
+	var create *elation.RecurringEventGroupCreate
+
+	for _, event := range c.Events {
+		r := event.Recurrence
+		if r == nil {
+			continue
+		}
+
+		if create == nil {
+			create = &elation.RecurringEventGroupCreate{
+				Practice:     practice,
+				Reason:       event.Summary,
+				TimeSlotType: event.TimeSlotType,
+			}
+
+			if create.TimeSlotType == "" {
+				create.TimeSlotType = elation.AppointmentTimeSlotTypeEvent
+			}
+		}
+
+		eventStart := event.Start.In(loc)
+
+		schedule := &elation.RecurringEventGroupSchedule{
+			SeriesStart: eventStart.Format(time.DateOnly),
+			EventTime:   eventStart.Format(time.TimeOnly),
+			Physician:   event.Physician,
+			Duration:    int(event.Duration / time.Minute),
+			Description: event.Description,
+		}
+
+		start := civil.DateOf(eventStart)
+
+		// BYDAY is in the timezone of DTSTART, which can be on another date in loc.
+		shift := start.DaysSince(civil.DateOf(event.Start))
+		weekdays := make([]time.Weekday, len(r.Weekdays))
+		for i, day := range r.Weekdays {
+			weekdays[i] = time.Weekday(((int(day)+shift)%7 + 7) % 7)
+		}
+
+		// occurs reports whether the recurrence repeats on a date after the start.
+		occurs := func(date civil.Date) bool {
+			return len(weekdays) == 0 || slices.Contains(weekdays, date.Weekday())
+		}
+
+		switch r.Frequency {
+		case FrequencyDaily:
+			schedule.Repeats = elation.RecurringEventRepeatsDaily
+		case FrequencyWeekly:
+			schedule.Repeats = elation.RecurringEventRepeatsWeekly
+			if len(weekdays) == 0 {
+				weekdays = []time.Weekday{eventStart.Weekday()}
+			}
+		case FrequencyMonthly:
+			schedule.Repeats = elation.RecurringEventRepeatsMonthly
+			occurs = func(date civil.Date) bool {
+				return date.Day == start.Day
+			}
+		case FrequencyYearly:
+			schedule.Repeats = elation.RecurringEventRepeatsYearly
+			occurs = func(date civil.Date) bool {
+				return date.Month == start.Month && date.Day == start.Day
+			}
+		}
+
+		for _, day := range weekdays {
+			switch day {
+			case time.Sunday:
+				schedule.DOWSunday = true
+			case time.Monday:
+				schedule.DOWMonday = true
+			case time.Tuesday:
+				schedule.DOWTuesday = true
+			case time.Wednesday:
+				schedule.DOWWednesday = true
+			case time.Thursday:
+				schedule.DOWThursday = true
+			case time.Friday:
+				schedule.DOWFriday = true
+			case time.Saturday:
+				schedule.DOWSaturday = true
+			}
+		}
+
+		switch {
+		case !r.Until.IsZero():
+			schedule.SeriesStop = r.Until.In(loc).Format(time.DateOnly)
+		case r.Count > 0:
+			schedule.SeriesStop = lastOccurrence(start, occurs, r.Count).String()
+		}
+
+		create.Schedules = append(create.Schedules, schedule)
+	}
+
+	if create == nil {
+		return nil, ErrNoRecurringEvents
+	}
+
+	return create, nil
+}
+
+// lastOccurrence returns the date of the count-th occurrence of a recurrence that starts on start and repeats on the
+// dates that occurs reports.
+func lastOccurrence(start civil.Date, occurs func(date civil.Date) bool, count int) civil.Date {
+	date := start
+	for n := 0; ; date = date.AddDays(1) {
+		if date == start || occurs(date) {
+			n++
+		}
+
+		if n == count {
+			return date
+		}
+	}
+}
+
+func localTime(date civil.Date, t civil.Time, loc *time.Location) time.Time {
+	return time.Date(date.Year, date.Month, date.Day, t.Hour, t.Minute, t.Second, 0, loc)
+}
@@ -0,0 +1,366 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRecurringEventGroup() *elation.RecurringEventGroup {
+	return &elation.RecurringEventGroup{
+		ID:           1,
+		Practice:     2,
+		Reason:       "Office hours",
+		TimeSlotType: elation.AppointmentTimeSlotTypeAppointmentSlot,
+		Schedules: []*elation.RecurringEventGroupSchedule{
+			{
+				ID:           3,
+				SeriesStart:  "2024-03-01",
+				SeriesStop:   "2024-03-31",
+				EventTime:    "09:00:00",
+				Physician:    4,
+				Duration:     240,
+				Repeats:      "Weekly",
+				DOWMonday:    true,
+				DOWWednesday: true,
+				Description:  "Mornings",
+			},
+			{
+				ID:          5,
+				SeriesStart: "2024-03-01",
+				EventTime:   "13:00:00",
+				Physician:   4,
+				Duration:    60,
+				Repeats:     "Daily",
+			},
+		},
+	}
+}
+
+func TestFromElationAppointment(t *testing.T) {
+	created := time.Date(2024, time.February, 1, 12, 0, 0, 0, time.UTC)
+	scheduled := time.Date(2024, time.March, 4, 14, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		appointment    *elation.Appointment
+		expectedStatus string
+	}{
+		"scheduled": {
+			appointment: &elation.Appointment{
+				Status: &elation.AppointmentStatus{Status: "Scheduled"},
+			},
+			expectedStatus: "CONFIRMED",
+		},
+		"cancelled": {
+			appointment: &elation.Appointment{
+				Status: &elation.AppointmentStatus{Status: "Cancelled"},
+			},
+			expectedStatus: "CANCELLED",
+		},
+		"deleted": {
+			appointment: &elation.Appointment{
+				DeletedDate: &created,
+			},
+			expectedStatus: "CANCELLED",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			a := testCase.appointment
+			a.ID = 10
+			a.CreatedDate = created
+			a.ScheduledDate = scheduled
+			a.Duration = 30
+			a.Reason = "Follow-up"
+			a.Physician = 4
+			a.TimeSlotType = string(elation.AppointmentTimeSlotTypeAppointment)
+			a.ServiceLocation = &elation.AppointmentServiceLocation{Name: "Main Office"}
+
+			event := FromElationAppointment(a)
+			assert.Equal("appointment-10@elationhealth.com", event.UID)
+			assert.Equal(created, event.Stamp)
+			assert.Equal(scheduled, event.Start)
+			assert.Equal(30*time.Minute, event.Duration)
+			assert.Equal("Follow-up", event.Summary)
+			assert.Equal("Main Office", event.Location)
+			assert.Equal(int64(4), event.Physician)
+			assert.Equal(elation.AppointmentTimeSlotTypeAppointment, event.TimeSlotType)
+			assert.Equal(testCase.expectedStatus, event.Status)
+			assert.Nil(event.Recurrence)
+		})
+	}
+}
+
+func TestFromElationRecurringEventGroup(t *testing.T) {
+	assert := assert.New(t)
+
+	loc, err := time.LoadLocation("America/New_York")
+	if !assert.NoError(err) {
+		return
+	}
+
+	events, err := FromElationRecurringEventGroup(testRecurringEventGroup(), loc)
+	if !assert.NoError(err) || !assert.Len(events, 2) {
+		return
+	}
+
+	weekly := events[0]
+	assert.Equal("recurring-event-schedule-3@elationhealth.com", weekly.UID)
+	assert.Equal(time.Date(2024, time.March, 4, 9, 0, 0, 0, loc), weekly.Start, "first Monday or Wednesday of the series")
+	assert.Equal(4*time.Hour, weekly.Duration)
+	assert.Equal("Office hours", weekly.Summary)
+	assert.Equal("Mornings", weekly.Description)
+	assert.Equal(&Recurrence{
+		Frequency: FrequencyWeekly,
+		Weekdays:  []time.Weekday{time.Monday, time.Wednesday},
+		Until:     time.Date(2024, time.March, 31, 9, 0, 0, 0, loc),
+	}, weekly.Recurrence)
+
+	daily := events[1]
+	assert.Equal(time.Date(2024, time.March, 1, 13, 0, 0, 0, loc), daily.Start)
+	assert.Equal(&Recurrence{Frequency: FrequencyDaily}, daily.Recurrence)
+
+	group := testRecurringEventGroup()
+	group.Schedules[0].Repeats = elation.RecurringEventRepeatsMonthly
+
+	events, err = FromElationRecurringEventGroup(group, loc)
+	if assert.NoError(err) {
+		assert.Equal(time.Date(2024, time.March, 1, 9, 0, 0, 0, loc), events[0].Start, "day of the series start")
+		assert.Equal(&Recurrence{
+			Frequency: FrequencyMonthly,
+			Until:     time.Date(2024, time.March, 31, 9, 0, 0, 0, loc),
+		}, events[0].Recurrence)
+	}
+
+	_, err = FromElationRecurringEventGroup(&elation.RecurringEventGroup{
+		Schedules: []*elation.RecurringEventGroupSchedule{{ID: 1, SeriesStart: "March 1", EventTime: "09:00:00"}},
+	}, loc)
+	assert.Error(err)
+}
+
+func TestToElationRecurringEventGroupCreate(t *testing.T) {
+	assert := assert.New(t)
+
+	loc, err := time.LoadLocation("America/New_York")
+	if !assert.NoError(err) {
+		return
+	}
+
+	events, err := FromElationRecurringEventGroup(testRecurringEventGroup(), loc)
+	if !assert.NoError(err) {
+		return
+	}
+
+	var b strings.Builder
+	if !assert.NoError(Encode(&b, &Calendar{Location: loc, Events: events})) {
+		return
+	}
+
+	calendar, err := Decode(strings.NewReader(b.String()))
+	if !assert.NoError(err) {
+		return
+	}
+
+	create, err := ToElationRecurringEventGroupCreate(calendar, 2, loc)
+	if !assert.NoError(err) {
+		return
+	}
+
+	assert.Equal(&elation.RecurringEventGroupCreate{
+		Practice:     2,
+		Reason:       "Office hours",
+		TimeSlotType: elation.AppointmentTimeSlotTypeAppointmentSlot,
+		Schedules: []*elation.RecurringEventGroupSchedule{
+			{
+				SeriesStart:  "2024-03-04",
+				SeriesStop:   "2024-03-31",
+				EventTime:    "09:00:00",
+				Physician:    4,
+				Duration:     240,
+				Repeats:      "Weekly",
+				DOWMonday:    true,
+				DOWWednesday: true,
+				Description:  "Mornings",
+			},
+			{
+				SeriesStart: "2024-03-01",
+				EventTime:   "13:00:00",
+				Physician:   4,
+				Duration:    60,
+				Repeats:     "Daily",
+			},
+		},
+	}, create)
+}
+
+func TestToElationRecurringEventGroupCreate_round_trip(t *testing.T) {
+	assert := assert.New(t)
+
+	loc, err := time.LoadLocation("America/New_York")
+	if !assert.NoError(err) {
+		return
+	}
+
+	group := testRecurringEventGroup()
+	group.Schedules = append(group.Schedules, &elation.RecurringEventGroupSchedule{
+		ID:          6,
+		SeriesStart: "2024-01-01",
+		SeriesStop:  "2024-01-10",
+		EventTime:   "08:00:00",
+		Physician:   4,
+		Duration:    30,
+		Repeats:     "Weekly",
+	})
+
+	events, err := FromElationRecurringEventGroup(group, loc)
+	if !assert.NoError(err) {
+		return
+	}
+
+	var b strings.Builder
+	if !assert.NoError(Encode(&b, &Calendar{Location: loc, Events: events})) {
+		return
+	}
+
+	calendar, err := Decode(strings.NewReader(b.String()))
+	if !assert.NoError(err) {
+		return
+	}
+
+	create, err := ToElationRecurringEventGroupCreate(calendar, 2, loc)
+	if !assert.NoError(err) || !assert.Len(create.Schedules, len(group.Schedules)) {
+		return
+	}
+
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, loc)
+	to := time.Date(2024, time.April, 1, 0, 0, 0, 0, loc)
+
+	for i, schedule := range group.Schedules {
+		expected, err := schedule.Occurrences(from, to, loc)
+		if !assert.NoError(err) {
+			return
+		}
+
+		actual, err := create.Schedules[i].Occurrences(from, to, loc)
+		if !assert.NoError(err) {
+			return
+		}
+
+		assert.Equal(expected, actual, "schedule %d", schedule.ID)
+	}
+
+	expected, err := group.Schedules[2].Occurrences(from, to, loc)
+	if assert.NoError(err) {
+		assert.Len(expected, 10, "every day from January 1 to 10")
+	}
+}
+
+func TestToElationRecurringEventGroupCreate_utc(t *testing.T) {
+	assert := assert.New(t)
+
+	loc, err := time.LoadLocation("America/New_York")
+	if !assert.NoError(err) {
+		return
+	}
+
+	var b strings.Builder
+	err = Encode(&b, &Calendar{Events: []*Event{
+		{
+			UID:      "1@example.com",
+			Start:    time.Date(2024, time.March, 4, 14, 0, 0, 0, time.UTC),
+			Duration: time.Hour,
+			Recurrence: &Recurrence{
+				Frequency: FrequencyWeekly,
+				Weekdays:  []time.Weekday{time.Monday, time.Wednesday},
+				Until:     time.Date(2024, time.March, 27, 14, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			UID:      "2@example.com",
+			Start:    time.Date(2024, time.March, 5, 2, 0, 0, 0, time.UTC),
+			Duration: time.Hour,
+			Recurrence: &Recurrence{
+				Frequency: FrequencyWeekly,
+				Weekdays:  []time.Weekday{time.Tuesday},
+			},
+		},
+	}})
+	if !assert.NoError(err) || !assert.Contains(b.String(), "DTSTART:20240304T140000Z") {
+		return
+	}
+
+	calendar, err := Decode(strings.NewReader(b.String()))
+	if !assert.NoError(err) {
+		return
+	}
+
+	create, err := ToElationRecurringEventGroupCreate(calendar, 2, loc)
+	if !assert.NoError(err) {
+		return
+	}
+
+	assert.Equal([]*elation.RecurringEventGroupSchedule{
+		{
+			SeriesStart:  "2024-03-04",
+			SeriesStop:   "2024-03-27",
+			EventTime:    "09:00:00",
+			Duration:     60,
+			Repeats:      "Weekly",
+			DOWMonday:    true,
+			DOWWednesday: true,
+		},
+		{
+			SeriesStart: "2024-03-04",
+			EventTime:   "21:00:00",
+			Duration:    60,
+			Repeats:     "Weekly",
+			DOWMonday:   true,
+		},
+	}, create.Schedules, "Tuesdays at 02:00 UTC are Mondays at 21:00 in New York")
+}
+
+func TestToElationRecurringEventGroupCreate_count(t *testing.T) {
+	assert := assert.New(t)
+
+	calendar := &Calendar{
+		Events: []*Event{
+			{Start: time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)},
+			{
+				Start:    time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC),
+				Duration: 30 * time.Minute,
+				Summary:  "Standup",
+				Recurrence: &Recurrence{
+					Frequency: FrequencyWeekly,
+					Weekdays:  []time.Weekday{time.Monday, time.Friday},
+					Count:     4,
+				},
+			},
+		},
+	}
+
+	create, err := ToElationRecurringEventGroupCreate(calendar, 2, time.UTC)
+	if !assert.NoError(err) || !assert.Len(create.Schedules, 1) {
+		return
+	}
+
+	assert.Equal(elation.AppointmentTimeSlotTypeEvent, create.TimeSlotType)
+	assert.Equal("2024-03-11", create.Schedules[0].SeriesStop, "Fri 1, Mon 4, Fri 8, Mon 11")
+
+	calendar.Events[1].Start = time.Date(2024, time.January, 31, 9, 0, 0, 0, time.UTC)
+	calendar.Events[1].Recurrence = &Recurrence{Frequency: FrequencyMonthly, Count: 3}
+
+	create, err = ToElationRecurringEventGroupCreate(calendar, 2, time.UTC)
+	if assert.NoError(err) && assert.Len(create.Schedules, 1) {
+		assert.Equal(elation.RecurringEventRepeatsMonthly, create.Schedules[0].Repeats)
+		assert.Equal("2024-05-31", create.Schedules[0].SeriesStop, "Jan 31, Mar 31, May 31")
+	}
+
+	_, err = ToElationRecurringEventGroupCreate(&Calendar{Events: calendar.Events[:1]}, 2, time.UTC)
+	assert.ErrorIs(err, ErrNoRecurringEvents)
+}
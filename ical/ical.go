@@ -0,0 +1,258 @@
+// Package ical encodes Elation appointments and recurring event schedules as iCalendar (RFC 5545) objects, and decodes
+// recurring events back into recurring event groups.
+//
+// Only the properties that are mapped are modeled, so this is not a general purpose iCalendar library.
+package ical
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+)
+
+const (
+	prodID    = "-//authorhealth//go-elation//EN"
+	uidDomain = "elationhealth.com"
+
+	propertyPhysician    = "X-ELATION-PHYSICIAN"
+	propertyTimeSlotType = "X-ELATION-TIME-SLOT-TYPE"
+
+	dateTimeFormat = "20060102T150405"
+	dateFormat     = "20060102"
+)
+
+const (
+	FrequencyDaily   = "DAILY"
+	FrequencyWeekly  = "WEEKLY"
+	FrequencyMonthly = "MONTHLY"
+	FrequencyYearly  = "YEARLY"
+)
+
+// Calendar is a VCALENDAR of events in one timezone.
+type Calendar struct {
+	// Location is the timezone of the event times, which is described by a VTIMEZONE. Times are written in UTC if it is
+	// nil or UTC.
+	Location *time.Location
+
+	Events []*Event
+}
+
+// Event is a VEVENT.
+type Event struct {
+	UID         string
+	Stamp       time.Time
+	Start       time.Time
+	Duration    time.Duration
+	Summary     string
+	Description string
+	Location    string
+
+	// Status is the iCalendar status, such as CONFIRMED or CANCELLED.
+	Status string
+
+	// Recurrence is the RRULE of recurring events.
+	Recurrence *Recurrence
+
+	// Physician and TimeSlotType are written as X-ELATION- properties so that recurring events can be converted back
+	// to recurring event groups.
+	Physician    int64
+	TimeSlotType elation.TimeSlotType
+}
+
+// Recurrence is an RRULE. Monthly and yearly recurrences repeat on the day, and the month, of the event start.
+type Recurrence struct {
+	Frequency string
+	Weekdays  []time.Weekday
+
+	// Until is the last time an occurrence can start. Zero if the recurrence is unbounded or has a Count.
+	Until time.Time
+
+	// Count is the number of occurrences. Zero if the recurrence is unbounded or has an Until.
+	Count int
+}
+
+var weekdayCodes = map[time.Weekday]string{
+	time.Sunday:    "SU",
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+}
+
+// Encode writes c to w. Events without a Stamp are stamped with the current time.
+func Encode(w io.Writer, c *Calendar) error {
+	e := &encoder{w: w, loc: c.Location}
+	if e.loc == time.UTC {
+		e.loc = nil
+	}
+
+	e.line("BEGIN", "VCALENDAR")
+	e.line("VERSION", "2.0")
+	e.line("PRODID", prodID)
+	e.line("CALSCALE", "GREGORIAN")
+
+	if e.loc != nil {
+		year := time.Now().Year()
+		if len(c.Events) > 0 {
+			year = slices.MinFunc(c.Events, func(a, b *Event) int {
+				return a.Start.Compare(b.Start)
+			}).Start.In(e.loc).Year()
+		}
+
+		e.timezone(year)
+	}
+
+	for _, event := range c.Events {
+		e.event(event)
+	}
+
+	e.line("END", "VCALENDAR")
+
+	return e.err
+}
+
+type encoder struct {
+	w   io.Writer
+	loc *time.Location
+	err error
+}
+
+func (e *encoder) event(event *Event) {
+	stamp := event.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	e.line("BEGIN", "VEVENT")
+	e.line("UID", event.UID)
+	e.line("DTSTAMP", stamp.UTC().Format(dateTimeFormat+"Z"))
+	e.time("DTSTART", event.Start)
+
+	if event.Duration > 0 {
+		e.line("DURATION", formatDuration(event.Duration))
+	}
+
+	if r := event.Recurrence; r != nil {
+		e.line("RRULE", e.rrule(r))
+	}
+
+	e.text("SUMMARY", event.Summary)
+	e.text("DESCRIPTION", event.Description)
+	e.text("LOCATION", event.Location)
+
+	if event.Status != "" {
+		e.line("STATUS", event.Status)
+	}
+
+	if event.Physician != 0 {
+		e.line(propertyPhysician, strconv.FormatInt(event.Physician, 10))
+	}
+
+	if event.TimeSlotType != "" {
+		e.line(propertyTimeSlotType, string(event.TimeSlotType))
+	}
+
+	e.line("END", "VEVENT")
+}
+
+func (e *encoder) rrule(r *Recurrence) string {
+	parts := []string{"FREQ=" + r.Frequency}
+
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(dateTimeFormat+"Z"))
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+
+	if len(r.Weekdays) > 0 {
+		var days []string
+		for _, day := range r.Weekdays {
+			days = append(days, weekdayCodes[day])
+		}
+
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// time writes a date-time property in the calendar's timezone, or in UTC.
+func (e *encoder) time(name string, t time.Time) {
+	if e.loc == nil {
+		e.line(name, t.UTC().Format(dateTimeFormat+"Z"))
+		return
+	}
+
+	e.line(name+";TZID="+e.loc.String(), t.In(e.loc).Format(dateTimeFormat))
+}
+
+func (e *encoder) text(name string, value string) {
+	if value != "" {
+		e.line(name, escapeText(value))
+	}
+}
+
+// line writes a content line, folded at 75 octets.
+func (e *encoder) line(name string, value string) {
+	if e.err != nil {
+		return
+	}
+
+	line := name + ":" + value
+
+	var b strings.Builder
+	for len(line) > 75 {
+		n := 75
+		if b.Len() > 0 {
+			n = 74
+		}
+
+		// Do not split UTF-8 sequences.
+		for n > 0 && line[n]&0xC0 == 0x80 {
+			n--
+		}
+
+		b.WriteString(line[:n])
+		b.WriteString("\r\n ")
+		line = line[n:]
+	}
+
+	b.WriteString(line)
+	b.WriteString("\r\n")
+
+	_, e.err = io.WriteString(e.w, b.String())
+}
+
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+func formatDuration(d time.Duration) string {
+	var b strings.Builder
+	b.WriteString("PT")
+
+	if h := d / time.Hour; h > 0 {
+		fmt.Fprintf(&b, "%dH", h)
+		d -= h * time.Hour
+	}
+
+	if m := d / time.Minute; m > 0 {
+		fmt.Fprintf(&b, "%dM", m)
+		d -= m * time.Minute
+	}
+
+	if s := d / time.Second; s > 0 || b.Len() == 2 {
+		fmt.Fprintf(&b, "%dS", s)
+	}
+
+	return b.String()
+}
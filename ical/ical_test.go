@@ -0,0 +1,135 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/authorhealth/go-elation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	assert := assert.New(t)
+
+	loc, err := time.LoadLocation("America/New_York")
+	if !assert.NoError(err) {
+		return
+	}
+
+	calendar := &Calendar{
+		Location: loc,
+		Events: []*Event{
+			{
+				UID:          "recurring-event-schedule-3@elationhealth.com",
+				Stamp:        time.Date(2024, time.February, 1, 12, 0, 0, 0, time.UTC),
+				Start:        time.Date(2024, time.March, 1, 12, 0, 0, 0, loc),
+				Duration:     90 * time.Minute,
+				Summary:      "Lunch; with staff, maybe",
+				Description:  strings.Repeat("A long description. ", 5) + "\nSecond line",
+				Status:       "CONFIRMED",
+				Physician:    4,
+				TimeSlotType: elation.AppointmentTimeSlotTypeEvent,
+				Recurrence: &Recurrence{
+					Frequency: FrequencyWeekly,
+					Weekdays:  []time.Weekday{time.Monday, time.Friday},
+					Until:     time.Date(2024, time.March, 31, 12, 0, 0, 0, loc),
+				},
+			},
+		},
+	}
+
+	var b strings.Builder
+	if !assert.NoError(Encode(&b, calendar)) {
+		return
+	}
+
+	expected := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//authorhealth//go-elation//EN",
+		"CALSCALE:GREGORIAN",
+		"BEGIN:VTIMEZONE",
+		"TZID:America/New_York",
+		"BEGIN:DAYLIGHT",
+		"DTSTART:20240310T020000",
+		"RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=2SU",
+		"TZOFFSETFROM:-0500",
+		"TZOFFSETTO:-0400",
+		"TZNAME:EDT",
+		"END:DAYLIGHT",
+		"BEGIN:STANDARD",
+		"DTSTART:20241103T020000",
+		"RRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=1SU",
+		"TZOFFSETFROM:-0400",
+		"TZOFFSETTO:-0500",
+		"TZNAME:EST",
+		"END:STANDARD",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:recurring-event-schedule-3@elationhealth.com",
+		"DTSTAMP:20240201T120000Z",
+		"DTSTART;TZID=America/New_York:20240301T120000",
+		"DURATION:PT1H30M",
+		"RRULE:FREQ=WEEKLY;UNTIL=20240331T160000Z;BYDAY=MO,FR",
+		`SUMMARY:Lunch\; with staff\, maybe`,
+		"DESCRIPTION:A long description. A long description. A long description. A l",
+		` ong description. A long description. \nSecond line`,
+		"STATUS:CONFIRMED",
+		"X-ELATION-PHYSICIAN:4",
+		"X-ELATION-TIME-SLOT-TYPE:event",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	assert.Equal(expected, b.String())
+}
+
+func TestEncode_utc(t *testing.T) {
+	assert := assert.New(t)
+
+	calendar := &Calendar{
+		Events: []*Event{
+			{
+				UID:   "appointment-1@elationhealth.com",
+				Stamp: time.Date(2024, time.February, 1, 12, 0, 0, 0, time.UTC),
+				Start: time.Date(2024, time.March, 1, 9, 0, 0, 0, time.FixedZone("", -5*60*60)),
+			},
+		},
+	}
+
+	var b strings.Builder
+	if !assert.NoError(Encode(&b, calendar)) {
+		return
+	}
+
+	assert.NotContains(b.String(), "VTIMEZONE")
+	assert.Contains(b.String(), "\r\nDTSTART:20240301T140000Z\r\n")
+}
+
+func TestFormatDuration(t *testing.T) {
+	testCases := map[string]struct {
+		duration time.Duration
+		expected string
+	}{
+		"zero": {
+			duration: 0,
+			expected: "PT0S",
+		},
+		"minutes": {
+			duration: 30 * time.Minute,
+			expected: "PT30M",
+		},
+		"hours, minutes and seconds": {
+			duration: 2*time.Hour + 5*time.Minute + 10*time.Second,
+			expected: "PT2H5M10S",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, formatDuration(testCase.duration))
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package ical
+
+import (
+	"fmt"
+	"time"
+)
+
+type transition struct {
+	at   time.Time
+	from int
+	to   int
+	name string
+}
+
+// timezone writes a VTIMEZONE of the calendar's location with the transitions of year as yearly rules, which is how
+// calendar tools describe timezones. Locations without transitions have a single STANDARD component.
+func (e *encoder) timezone(year int) {
+	e.line("BEGIN", "VTIMEZONE")
+	e.line("TZID", e.loc.String())
+
+	transitions := zoneTransitions(e.loc, year)
+	if len(transitions) == 0 {
+		name, offset := time.Date(year, time.January, 1, 0, 0, 0, 0, e.loc).Zone()
+
+		e.line("BEGIN", "STANDARD")
+		e.line("DTSTART", "19700101T000000")
+		e.line("TZOFFSETFROM", formatOffset(offset))
+		e.line("TZOFFSETTO", formatOffset(offset))
+		e.line("TZNAME", name)
+		e.line("END", "STANDARD")
+	}
+
+	for _, t := range transitions {
+		component := "STANDARD"
+		if t.to > t.from {
+			component = "DAYLIGHT"
+		}
+
+		// Transitions are given in the local time before the transition.
+		local := t.at.In(time.FixedZone("", t.from))
+
+		e.line("BEGIN", component)
+		e.line("DTSTART", local.Format(dateTimeFormat))
+		e.line("RRULE", fmt.Sprintf("FREQ=YEARLY;BYMONTH=%d;BYDAY=%d%s", local.Month(), weekOfMonth(local), weekdayCodes[local.Weekday()]))
+		e.line("TZOFFSETFROM", formatOffset(t.from))
+		e.line("TZOFFSETTO", formatOffset(t.to))
+		e.line("TZNAME", t.name)
+		e.line("END", component)
+	}
+
+	e.line("END", "VTIMEZONE")
+}
+
+// zoneTransitions returns the offset changes of loc during year.
+func zoneTransitions(loc *time.Location, year int) []transition {
+	t := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	end := t.AddDate(1, 0, 0)
+
+	var transitions []transition
+	for {
+		_, zoneEnd := t.ZoneBounds()
+		if zoneEnd.IsZero() || !zoneEnd.Before(end) {
+			return transitions
+		}
+
+		_, from := t.Zone()
+		name, to := zoneEnd.Zone()
+
+		transitions = append(transitions, transition{at: zoneEnd, from: from, to: to, name: name})
+		t = zoneEnd
+	}
+}
+
+// weekOfMonth returns the week of the month of t, or -1 if it is in the last week.
+func weekOfMonth(t time.Time) int {
+	if t.AddDate(0, 0, 7).Month() != t.Month() {
+		return -1
+	}
+
+	return (t.Day()-1)/7 + 1
+}
+
+func formatOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+
+	return fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset/60%60)
+}
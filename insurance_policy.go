@@ -25,11 +25,11 @@ type InsurancePolicyService struct {
 	client *HTTPClient
 }
 
-type FindInsurancePoliciesResponse struct {
-	// TODO: Determine how the pagination fields here work / if they work yet
-
-	Results []*InsurancePolicy `json:"results"`
-}
+// FindInsurancePoliciesResponse has the same shape as the other Find responses so that it can be used with All. If the
+// endpoint does not return a next link, All stops after the first page.
+//
+// TODO: Determine how the pagination fields here work / if they work yet
+type FindInsurancePoliciesResponse = Response[[]*InsurancePolicy]
 
 type InsurancePolicy struct {
 	ID int64 `json:"id"`
@@ -175,6 +175,7 @@ type InsurancePolicyUpdate struct {
 
 type FindInsurancePoliciesOptions struct {
 	// TODO: Understand how pagination functions for this endpoint
+	*Pagination
 
 	ActiveOnly *bool `url:"active_only,omitempty"`
 }
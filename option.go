@@ -0,0 +1,143 @@
+package elation
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/oauth2"
+)
+
+type clientOptions struct {
+	httpClient     *http.Client
+	baseTransport  http.RoundTripper
+	baseURL        string
+	tokenURL       string
+	clientID       string
+	clientSecret   string
+	scopes         []string
+	tokenSource    oauth2.TokenSource
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	userAgent      string
+	headers        http.Header
+	retryPolicy    *RetryPolicy
+	rateLimiter    RateLimiter
+}
+
+type Option func(*clientOptions)
+
+// WithHTTPClient sets the http.Client whose settings (timeout, redirect policy, cookie jar and transport) are used as the
+// basis for the client. The passed-in client is copied and never mutated.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithBaseTransport sets the transport that requests are ultimately sent through. It takes precedence over the transport
+// of the client passed to WithHTTPClient.
+func WithBaseTransport(transport http.RoundTripper) Option {
+	return func(o *clientOptions) {
+		o.baseTransport = transport
+	}
+}
+
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithClientCredentials authenticates using the OAuth 2.0 client credentials flow.
+func WithClientCredentials(tokenURL, clientID, clientSecret string) Option {
+	return func(o *clientOptions) {
+		o.tokenURL = tokenURL
+		o.clientID = clientID
+		o.clientSecret = clientSecret
+	}
+}
+
+// WithScopes sets the scopes requested by the client credentials flow.
+func WithScopes(scopes ...string) Option {
+	return func(o *clientOptions) {
+		o.scopes = scopes
+	}
+}
+
+// WithTokenSource authenticates using the given token source instead of the client credentials flow.
+func WithTokenSource(tokenSource oauth2.TokenSource) Option {
+	return func(o *clientOptions) {
+		o.tokenSource = tokenSource
+	}
+}
+
+func WithTracerProvider(tracerProvider trace.TracerProvider) Option {
+	return func(o *clientOptions) {
+		o.tracerProvider = tracerProvider
+	}
+}
+
+func WithMeterProvider(meterProvider metric.MeterProvider) Option {
+	return func(o *clientOptions) {
+		o.meterProvider = meterProvider
+	}
+}
+
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithHeader adds a header that is sent with every request. It may be passed more than once.
+func WithHeader(key, value string) Option {
+	return func(o *clientOptions) {
+		if o.headers == nil {
+			o.headers = http.Header{}
+		}
+
+		o.headers.Add(key, value)
+	}
+}
+
+// WithRetryPolicy sets the policy used to retry failed requests. A nil policy disables retries.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithRateLimiter sets the limiter consulted before every request attempt.
+func WithRateLimiter(limiter RateLimiter) Option {
+	return func(o *clientOptions) {
+		o.rateLimiter = limiter
+	}
+}
+
+type headerTransport struct {
+	base      http.RoundTripper
+	userAgent string
+	headers   http.Header
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request it is given.
+	req = req.Clone(req.Context())
+
+	for key, values := range t.headers {
+		if req.Header.Get(key) != "" {
+			continue
+		}
+
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	if t.userAgent != "" {
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,128 @@
+package elation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+func TestNewClient_does_not_mutate_http_client(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+	}))
+	defer srv.Close()
+
+	httpClient := srv.Client()
+	transport := httpClient.Transport
+
+	client := NewClient(
+		WithHTTPClient(httpClient),
+		WithClientCredentials(srv.URL+"/token", "", ""),
+		WithBaseURL(srv.URL))
+
+	assert.Equal(transport, httpClient.Transport)
+
+	_, err := client.request(context.Background(), http.MethodGet, "/foo", nil, nil, nil)
+	assert.NoError(err)
+	assert.Equal(transport, httpClient.Transport)
+}
+
+func TestNewClient_preserves_http_client_timeout(t *testing.T) {
+	assert := assert.New(t)
+
+	client := NewClient(WithHTTPClient(&http.Client{Timeout: 5 * time.Second}))
+	assert.Equal(5*time.Second, client.httpClient.Timeout)
+}
+
+func TestNewClient_token_source(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEqual("/token", r.URL.Path)
+		assert.Equal("Bearer static-token", r.Header.Get("Authorization"))
+	}))
+	defer srv.Close()
+
+	client := NewClient(
+		WithBaseTransport(srv.Client().Transport),
+		WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "static-token"})),
+		WithBaseURL(srv.URL))
+
+	_, err := client.request(context.Background(), http.MethodGet, "/foo", nil, nil, nil)
+	assert.NoError(err)
+}
+
+func TestNewClient_scopes(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			assert.NoError(r.ParseForm())
+			assert.Equal("scope1 scope2", r.PostForm.Get("scope"))
+		}
+
+		if tokenRequest(w, r) {
+			return
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(
+		WithHTTPClient(srv.Client()),
+		WithClientCredentials(srv.URL+"/token", "client-id", "client-secret"),
+		WithScopes("scope1", "scope2"),
+		WithBaseURL(srv.URL))
+
+	_, err := client.request(context.Background(), http.MethodGet, "/foo", nil, nil, nil)
+	assert.NoError(err)
+}
+
+func TestNewClient_headers(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		assert.Equal("my-app/1.0", r.Header.Get("User-Agent"))
+		assert.Equal([]string{"a", "b"}, r.Header.Values("X-Foo"))
+		assert.Equal("application/json", r.Header.Get("Content-Type"))
+	}))
+	defer srv.Close()
+
+	client := NewClient(
+		WithHTTPClient(srv.Client()),
+		WithClientCredentials(srv.URL+"/token", "", ""),
+		WithBaseURL(srv.URL),
+		WithUserAgent("my-app/1.0"),
+		WithHeader("X-Foo", "a"),
+		WithHeader("X-Foo", "b"),
+		WithHeader("Content-Type", "text/plain"))
+
+	_, err := client.request(context.Background(), http.MethodGet, "/foo", nil, nil, nil)
+	assert.NoError(err)
+}
+
+func TestNewClient_retry_policy_and_rate_limiter(t *testing.T) {
+	assert := assert.New(t)
+
+	limiter := &ReadWriteLimiter{}
+
+	client := NewClient()
+	assert.Equal(DefaultRetryPolicy(), client.retryPolicy)
+	assert.Nil(client.rateLimiter)
+
+	client = NewClient(WithRetryPolicy(nil), WithRateLimiter(limiter))
+	assert.Nil(client.retryPolicy)
+	assert.Equal(limiter, client.rateLimiter)
+}
@@ -0,0 +1,135 @@
+package elation
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"reflect"
+)
+
+var errNoPagination = errors.New("options do not embed *Pagination")
+
+// FindFunc is the signature shared by the Find methods of the services.
+type FindFunc[T any, O any] func(ctx context.Context, opts O) (*Response[[]T], *http.Response, error)
+
+type pageOptions struct {
+	maxItems int
+}
+
+type PageOption func(*pageOptions)
+
+// WithMaxItems stops iteration after n items have been yielded.
+func WithMaxItems(n int) PageOption {
+	return func(o *pageOptions) {
+		o.maxItems = n
+	}
+}
+
+// All yields every result of find, fetching further pages by following the next link of each response. The options
+// must be a pointer to a struct that embeds *Pagination; they are copied, never modified. Iteration stops after the
+// first error, which is yielded along with the zero value of T.
+//
+//	for patient, err := range elation.All(ctx, client.Patients().Find, &elation.FindPatientsOptions{}) {
+//		...
+//	}
+//
+// Find methods that take a parent ID, such as InsurancePolicies().Find, are adapted with a closure:
+//
+//	find := func(ctx context.Context, opts *elation.FindInsurancePoliciesOptions) (*elation.FindInsurancePoliciesResponse, *http.Response, error) {
+//		return client.InsurancePolicies().Find(ctx, patientID, opts)
+//	}
+//
+//	for policy, err := range elation.All(ctx, find, &elation.FindInsurancePoliciesOptions{}) {
+//		...
+//	}
+func All[T any, O any](ctx context.Context, find FindFunc[T, O], opts O, pageOpts ...PageOption) iter.Seq2[T, error] {
+	o := &pageOptions{}
+	for _, opt := range pageOpts {
+		opt(o)
+	}
+
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		opts, pagination, err := copyWithPagination(opts)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		yielded := 0
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			res, _, err := find(ctx, opts)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, result := range res.Results {
+				if o.maxItems > 0 && yielded >= o.maxItems {
+					return
+				}
+
+				if !yield(result, nil) {
+					return
+				}
+
+				yielded++
+			}
+
+			if !res.HasNext() || len(res.Results) == 0 {
+				return
+			}
+
+			if o.maxItems > 0 && yielded >= o.maxItems {
+				return
+			}
+
+			next := res.PaginationNext()
+			if *next == *pagination {
+				return
+			}
+
+			*pagination = *next
+		}
+	}
+}
+
+// copyWithPagination makes a shallow copy of opts with a Pagination of its own so that advancing through pages leaves
+// the caller's options untouched.
+func copyWithPagination[O any](opts O) (O, *Pagination, error) {
+	var zero O
+
+	t := reflect.TypeFor[O]()
+	if t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		return zero, nil, errNoPagination
+	}
+
+	field, ok := t.Elem().FieldByName("Pagination")
+	if !ok || field.Type != reflect.TypeFor[*Pagination]() {
+		return zero, nil, errNoPagination
+	}
+
+	c := reflect.New(t.Elem())
+
+	v := reflect.ValueOf(opts)
+	if !v.IsNil() {
+		c.Elem().Set(v.Elem())
+	}
+
+	pagination := &Pagination{}
+	if p := c.Elem().FieldByIndex(field.Index); !p.IsNil() {
+		*pagination = *p.Interface().(*Pagination)
+	}
+
+	c.Elem().FieldByIndex(field.Index).Set(reflect.ValueOf(pagination))
+
+	return c.Interface().(O), pagination, nil
+}
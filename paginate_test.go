@@ -0,0 +1,295 @@
+package elation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// paginatedServer serves total patients from /patients, linking pages with either offset or cursor next links.
+func paginatedServer(t *testing.T, total int, cursor bool) (*httptest.Server, *int) {
+	assert := assert.New(t)
+
+	requests := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		requests++
+
+		limit := strToInt(r.URL.Query().Get("limit"))
+		if limit == 0 {
+			limit = defaultPaginationLimit
+		}
+
+		offset := strToInt(r.URL.Query().Get("offset"))
+		if cursor && r.URL.Query().Get("cursor") != "" {
+			offset = strToInt(r.URL.Query().Get("cursor")[len("c"):])
+		}
+
+		res := Response[[]*Patient]{
+			Count: total,
+		}
+
+		for i := offset; i < min(offset+limit, total); i++ {
+			res.Results = append(res.Results, &Patient{ID: int64(i + 1)})
+		}
+
+		if offset+limit < total {
+			if cursor {
+				res.Next = "http://" + r.Host + r.URL.Path + "?cursor=c" + strconv.Itoa(offset+limit) + "&limit=" + strconv.Itoa(limit)
+			} else {
+				res.Next = "http://" + r.Host + r.URL.Path + "?limit=" + strconv.Itoa(limit) + "&offset=" + strconv.Itoa(offset+limit)
+			}
+		}
+
+		b, err := json.Marshal(res)
+		assert.NoError(err)
+
+		w.Header().Set("Content-Type", "application/json")
+		//nolint
+		w.Write(b)
+	}))
+
+	return srv, &requests
+}
+
+func collectIDs(seq func(yield func(*Patient, error) bool)) ([]int64, error) {
+	var ids []int64
+
+	for patient, err := range seq {
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, patient.ID)
+	}
+
+	return ids, nil
+}
+
+func expectedIDs(n int) []int64 {
+	var ids []int64
+	for i := 1; i <= n; i++ {
+		ids = append(ids, int64(i))
+	}
+
+	return ids
+}
+
+func TestAll(t *testing.T) {
+	testCases := map[string]struct {
+		cursor           bool
+		total            int
+		pageOpts         []PageOption
+		expected         []int64
+		expectedRequests int
+	}{
+		"offset pagination": {
+			total:            7,
+			expected:         expectedIDs(7),
+			expectedRequests: 3,
+		},
+		"cursor pagination": {
+			cursor:           true,
+			total:            7,
+			expected:         expectedIDs(7),
+			expectedRequests: 3,
+		},
+		"exact multiple of the page size": {
+			total:            6,
+			expected:         expectedIDs(6),
+			expectedRequests: 2,
+		},
+		"no results": {
+			total:            0,
+			expectedRequests: 1,
+		},
+		"max items": {
+			total:            7,
+			pageOpts:         []PageOption{WithMaxItems(4)},
+			expected:         expectedIDs(4),
+			expectedRequests: 2,
+		},
+		"max items at a page boundary": {
+			total:            7,
+			pageOpts:         []PageOption{WithMaxItems(3)},
+			expected:         expectedIDs(3),
+			expectedRequests: 1,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			srv, requests := paginatedServer(t, testCase.total, testCase.cursor)
+			defer srv.Close()
+
+			client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+			opts := &FindPatientsOptions{
+				Pagination: &Pagination{
+					Limit: 3,
+				},
+			}
+
+			ids, err := collectIDs(All(context.Background(), client.Patients().Find, opts, testCase.pageOpts...))
+			assert.NoError(err)
+			assert.Equal(testCase.expected, ids)
+			assert.Equal(testCase.expectedRequests, *requests)
+
+			// The caller's options are left untouched.
+			assert.Equal(&Pagination{Limit: 3}, opts.Pagination)
+		})
+	}
+}
+
+func TestAll_nil_options(t *testing.T) {
+	assert := assert.New(t)
+
+	srv, _ := paginatedServer(t, 30, false)
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+	ids, err := collectIDs(All(context.Background(), client.Patients().Find, nil))
+	assert.NoError(err)
+	assert.Equal(expectedIDs(30), ids)
+}
+
+func TestAll_break(t *testing.T) {
+	assert := assert.New(t)
+
+	srv, requests := paginatedServer(t, 30, false)
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+	count := 0
+	for _, err := range All(context.Background(), client.Patients().Find, &FindPatientsOptions{}) {
+		assert.NoError(err)
+
+		count++
+		if count == 2 {
+			break
+		}
+	}
+
+	assert.Equal(2, count)
+	assert.Equal(1, *requests)
+}
+
+func TestAll_context_canceled(t *testing.T) {
+	assert := assert.New(t)
+
+	srv, requests := paginatedServer(t, 30, false)
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	count := 0
+	var lastErr error
+	for _, err := range All(ctx, client.Patients().Find, &FindPatientsOptions{Pagination: &Pagination{Limit: 5}}) {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		count++
+		cancel()
+	}
+
+	assert.ErrorIs(lastErr, context.Canceled)
+	assert.Equal(5, count)
+	assert.Equal(1, *requests)
+}
+
+func TestAll_error(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+	ids, err := collectIDs(All(context.Background(), client.Patients().Find, &FindPatientsOptions{}))
+	assert.Empty(ids)
+	assert.Error(err)
+}
+
+func TestAll_options_without_pagination(t *testing.T) {
+	assert := assert.New(t)
+
+	type options struct {
+		Foo string
+	}
+
+	find := func(ctx context.Context, opts *options) (*Response[[]*Patient], *http.Response, error) {
+		t.Fatal("find should not be called")
+		return nil, nil, nil
+	}
+
+	ids, err := collectIDs(All(context.Background(), find, &options{}))
+	assert.Empty(ids)
+	assert.ErrorIs(err, errNoPagination)
+}
+
+func TestAll_insurance_policies(t *testing.T) {
+	assert := assert.New(t)
+
+	var patientID int64 = 500
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		assert.Equal("/patients/"+strconv.FormatInt(patientID, 10)+"/policies", r.URL.Path)
+
+		res := FindInsurancePoliciesResponse{}
+		if r.URL.Query().Get("offset") == "" {
+			res.Results = []*InsurancePolicy{{ID: 1}}
+			res.Next = "http://" + r.Host + r.URL.Path + "?limit=1&offset=1"
+		} else {
+			res.Results = []*InsurancePolicy{{ID: 2}}
+		}
+
+		b, err := json.Marshal(res)
+		assert.NoError(err)
+
+		w.Header().Set("Content-Type", "application/json")
+		//nolint
+		w.Write(b)
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+	find := func(ctx context.Context, opts *FindInsurancePoliciesOptions) (*FindInsurancePoliciesResponse, *http.Response, error) {
+		return client.InsurancePolicies().Find(ctx, patientID, opts)
+	}
+
+	var ids []int64
+	for policy, err := range All(context.Background(), find, &FindInsurancePoliciesOptions{}) {
+		assert.NoError(err)
+		ids = append(ids, policy.ID)
+	}
+
+	assert.Equal([]int64{1, 2}, ids)
+}
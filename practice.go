@@ -40,6 +40,21 @@ type Practice struct {
 	Status           string                     `json:"status"`
 }
 
+// Location loads the timezone of the practice. An empty timezone is an error rather than UTC, so times are not silently
+// computed in the wrong timezone.
+func (p *Practice) Location() (*time.Location, error) {
+	if p.Timezone == "" {
+		return nil, fmt.Errorf("practice %d has no timezone", p.ID)
+	}
+
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("loading practice timezone: %w", err)
+	}
+
+	return loc, nil
+}
+
 type PracticeEmployer struct {
 	Code        string `json:"code"`
 	Name        string `json:"name"`
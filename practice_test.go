@@ -94,3 +94,38 @@ func TestPracticeService_Get(t *testing.T) {
 	assert.NotNil(res)
 	assert.NoError(err)
 }
+
+func TestPractice_Location(t *testing.T) {
+	testCases := map[string]struct {
+		timezone    string
+		expected    string
+		expectedErr string
+	}{
+		"timezone": {
+			timezone: "America/New_York",
+			expected: "America/New_York",
+		},
+		"no timezone": {
+			expectedErr: "practice 1 has no timezone",
+		},
+		"unknown timezone": {
+			timezone:    "Nowhere/Nowhere",
+			expectedErr: "loading practice timezone: unknown time zone Nowhere/Nowhere",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			loc, err := (&Practice{ID: 1, Timezone: testCase.timezone}).Location()
+			if testCase.expectedErr != "" {
+				assert.EqualError(err, testCase.expectedErr)
+				return
+			}
+
+			if assert.NoError(err) {
+				assert.Equal(testCase.expected, loc.String())
+			}
+		})
+	}
+}
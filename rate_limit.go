@@ -0,0 +1,123 @@
+package elation
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimiter is consulted before every request attempt. Implementations must be safe for concurrent use so that a
+// single limiter can be shared by several HTTPClients that use the same credentials.
+type RateLimiter interface {
+	Wait(ctx context.Context, method string) error
+}
+
+// Limiter is a single rate limit that blocks until a request is allowed.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// ReadWriteLimiter applies separate limits to reads (GET, HEAD and OPTIONS) and writes (every other method). A nil
+// limiter leaves that kind of request unlimited.
+type ReadWriteLimiter struct {
+	Read  Limiter
+	Write Limiter
+}
+
+var _ RateLimiter = (*ReadWriteLimiter)(nil)
+
+func NewReadWriteLimiter(readRate float64, readBurst int, writeRate float64, writeBurst int) *ReadWriteLimiter {
+	return &ReadWriteLimiter{
+		Read:  NewTokenBucket(readRate, readBurst),
+		Write: NewTokenBucket(writeRate, writeBurst),
+	}
+}
+
+func (l *ReadWriteLimiter) Wait(ctx context.Context, method string) error {
+	limiter := l.Write
+	if isRead(method) {
+		limiter = l.Read
+	}
+
+	if limiter == nil {
+		return nil
+	}
+
+	return limiter.Wait(ctx)
+}
+
+// TokenBucket allows requests at a steady rate per second with bursts of up to burst requests. A rate less than or
+// equal to zero disables the limit.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+var _ Limiter = (*TokenBucket)(nil)
+
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	burst = max(burst, 1)
+
+	return &TokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	if b.rate <= 0 {
+		return nil
+	}
+
+	delay := b.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	err := sleep(ctx, delay)
+	if err != nil {
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// reserve takes a token from the bucket, letting the balance go negative, and returns how long the caller must wait
+// before the token is actually available.
+func (b *TokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if !b.last.IsZero() {
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+func isRead(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,129 @@
+package elation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingLimiter struct {
+	calls atomic.Int32
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.calls.Add(1)
+	return nil
+}
+
+func TestTokenBucket_reserve(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	bucket := NewTokenBucket(2, 2)
+	bucket.now = func() time.Time { return now }
+
+	// The burst is available immediately.
+	assert.Equal(time.Duration(0), bucket.reserve())
+	assert.Equal(time.Duration(0), bucket.reserve())
+
+	// The next tokens become available at the refill rate.
+	assert.Equal(500*time.Millisecond, bucket.reserve())
+	assert.Equal(time.Second, bucket.reserve())
+
+	now = now.Add(2 * time.Second)
+	assert.Equal(time.Duration(0), bucket.reserve())
+
+	// The bucket never holds more than the burst.
+	now = now.Add(time.Hour)
+	assert.Equal(time.Duration(0), bucket.reserve())
+	assert.Equal(time.Duration(0), bucket.reserve())
+	assert.Equal(500*time.Millisecond, bucket.reserve())
+}
+
+func TestTokenBucket_Wait_context_canceled(t *testing.T) {
+	assert := assert.New(t)
+
+	bucket := NewTokenBucket(0.001, 1)
+	assert.NoError(bucket.Wait(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(bucket.Wait(ctx), context.Canceled)
+}
+
+func TestTokenBucket_Wait_unlimited(t *testing.T) {
+	assert := assert.New(t)
+
+	bucket := NewTokenBucket(0, 1)
+	for range 100 {
+		assert.NoError(bucket.Wait(context.Background()))
+	}
+}
+
+func TestReadWriteLimiter_Wait(t *testing.T) {
+	assert := assert.New(t)
+
+	read := &countingLimiter{}
+	write := &countingLimiter{}
+
+	limiter := &ReadWriteLimiter{
+		Read:  read,
+		Write: write,
+	}
+
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodOptions} {
+		assert.NoError(limiter.Wait(context.Background(), method))
+	}
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		assert.NoError(limiter.Wait(context.Background(), method))
+	}
+
+	assert.Equal(int32(3), read.calls.Load())
+	assert.Equal(int32(4), write.calls.Load())
+
+	assert.NoError((&ReadWriteLimiter{}).Wait(context.Background(), http.MethodGet))
+}
+
+func TestHTTPClient_request_rate_limiter_shared(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+	}))
+	defer srv.Close()
+
+	read := &countingLimiter{}
+	write := &countingLimiter{}
+	limiter := &ReadWriteLimiter{
+		Read:  read,
+		Write: write,
+	}
+
+	client1 := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+	client1.SetRateLimiter(limiter)
+
+	client2 := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+	client2.SetRateLimiter(limiter)
+
+	_, err := client1.request(context.Background(), http.MethodGet, "/foo", nil, nil, nil)
+	assert.NoError(err)
+
+	_, err = client2.request(context.Background(), http.MethodGet, "/foo", nil, nil, nil)
+	assert.NoError(err)
+
+	_, err = client2.request(context.Background(), http.MethodPost, "/foo", nil, nil, nil)
+	assert.NoError(err)
+
+	assert.Equal(int32(2), read.calls.Load())
+	assert.Equal(int32(1), write.calls.Load())
+}
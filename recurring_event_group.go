@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"cloud.google.com/go/civil"
+
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
@@ -45,6 +48,118 @@ type RecurringEventGroupSchedule struct {
 	CreatedDate  time.Time `json:"created_date"`
 }
 
+// Repeats values of recurring event group schedules.
+const (
+	RecurringEventRepeatsOnce    = "Once"
+	RecurringEventRepeatsDaily   = "Daily"
+	RecurringEventRepeatsWeekly  = "Weekly"
+	RecurringEventRepeatsMonthly = "Monthly"
+	RecurringEventRepeatsYearly  = "Yearly"
+)
+
+// Occurrences returns the start times of the occurrences of s in [from, to), in order, with the event time in loc.
+//
+// Daily and weekly schedules repeat on the days of the week that are set, or every day if none are. Monthly and yearly
+// schedules repeat on the day of the month, and the month, of the series start, skipping months and years without that
+// day. Schedules that do not repeat occur once, on the series start. Occurrences end on the series stop, if any.
+//
+// Event times that do not exist in loc because of a DST transition are moved forward by the length of the transition,
+// and those that happen twice are the first of the two.
+func (s *RecurringEventGroupSchedule) Occurrences(from time.Time, to time.Time, loc *time.Location) ([]time.Time, error) {
+	seriesStart, err := civil.ParseDate(s.SeriesStart)
+	if err != nil {
+		return nil, fmt.Errorf("parsing series start: %w", err)
+	}
+
+	last := civil.DateOf(to.In(loc))
+	if s.SeriesStop != "" {
+		seriesStop, err := civil.ParseDate(s.SeriesStop)
+		if err != nil {
+			return nil, fmt.Errorf("parsing series stop: %w", err)
+		}
+
+		if seriesStop.Before(last) {
+			last = seriesStop
+		}
+	}
+
+	eventTime, err := civil.ParseTime(s.EventTime)
+	if err != nil {
+		return nil, fmt.Errorf("parsing event time: %w", err)
+	}
+
+	var occurs func(date civil.Date) bool
+
+	switch {
+	case s.Repeats == "" || strings.EqualFold(s.Repeats, RecurringEventRepeatsOnce):
+		occurs = func(date civil.Date) bool {
+			return date == seriesStart
+		}
+	case strings.EqualFold(s.Repeats, RecurringEventRepeatsDaily), strings.EqualFold(s.Repeats, RecurringEventRepeatsWeekly):
+		days := map[time.Weekday]bool{
+			time.Monday:    s.DOWMonday,
+			time.Tuesday:   s.DOWTuesday,
+			time.Wednesday: s.DOWWednesday,
+			time.Thursday:  s.DOWThursday,
+			time.Friday:    s.DOWFriday,
+			time.Saturday:  s.DOWSaturday,
+			time.Sunday:    s.DOWSunday,
+		}
+
+		everyDay := !s.DOWMonday && !s.DOWTuesday && !s.DOWWednesday && !s.DOWThursday && !s.DOWFriday && !s.DOWSaturday &&
+			!s.DOWSunday
+
+		occurs = func(date civil.Date) bool {
+			return everyDay || days[date.Weekday()]
+		}
+	case strings.EqualFold(s.Repeats, RecurringEventRepeatsMonthly):
+		occurs = func(date civil.Date) bool {
+			return date.Day == seriesStart.Day
+		}
+	case strings.EqualFold(s.Repeats, RecurringEventRepeatsYearly):
+		occurs = func(date civil.Date) bool {
+			return date.Month == seriesStart.Month && date.Day == seriesStart.Day
+		}
+	default:
+		return nil, fmt.Errorf("unsupported repeats %q", s.Repeats)
+	}
+
+	// Occurrences on earlier dates start before from.
+	first := seriesStart
+	if date := civil.DateOf(from.In(loc)); date.After(first) {
+		first = date
+	}
+
+	var starts []time.Time
+	for date := first; !date.After(last); date = date.AddDays(1) {
+		if !occurs(date) {
+			continue
+		}
+
+		start := eventStart(date, eventTime, loc)
+		if start.Before(from) || !start.Before(to) {
+			continue
+		}
+
+		starts = append(starts, start)
+	}
+
+	return starts, nil
+}
+
+// eventStart returns the event time on date in loc. time.Date does not define which offset a time in a DST gap gets,
+// so those are resolved with the offset before the gap, which moves them forward by its length.
+func eventStart(date civil.Date, t civil.Time, loc *time.Location) time.Time {
+	start := time.Date(date.Year, date.Month, date.Day, t.Hour, t.Minute, t.Second, 0, loc)
+	if start.Hour() == t.Hour && start.Minute() == t.Minute {
+		return start
+	}
+
+	_, offset := time.Date(date.Year, date.Month, date.Day-1, t.Hour, t.Minute, t.Second, 0, loc).Zone()
+
+	return time.Date(date.Year, date.Month, date.Day, t.Hour, t.Minute, t.Second, 0, time.UTC).Add(-time.Duration(offset) * time.Second).In(loc)
+}
+
 type RecurringEventGroup struct {
 	ID       int64 `json:"id"`
 	Practice int64 `json:"practice"`
@@ -13,6 +13,27 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func testRecurringEventGroupSchedule() *RecurringEventGroupSchedule {
+	return &RecurringEventGroupSchedule{
+		ID:           5,
+		SeriesStart:  "2024-01-01",
+		SeriesStop:   "2024-02-01",
+		EventTime:    "10:30:00",
+		Physician:    2,
+		Duration:     15,
+		Repeats:      "Weekly",
+		DOWMonday:    true,
+		DOWTuesday:   false,
+		DOWWednesday: false,
+		DOWThursday:  false,
+		DOWFriday:    false,
+		DOWSaturday:  false,
+		DOWSunday:    false,
+		Description:  "Appt follow-up",
+		CreatedDate:  time.Date(2024, 3, 27, 10, 30, 0, 0, time.UTC),
+	}
+}
+
 func TestRecurringEventGroupService_Create(t *testing.T) {
 	assert := assert.New(t)
 
@@ -20,24 +41,7 @@ func TestRecurringEventGroupService_Create(t *testing.T) {
 		Practice: 1,
 		Reason:   "Appt follow-up",
 		Schedules: []*RecurringEventGroupSchedule{
-			{
-				ID:           5,
-				SeriesStart:  "2024-01-01",
-				SeriesStop:   "2024-02-01",
-				EventTime:    "10:30:00",
-				Physician:    2,
-				Duration:     15,
-				Repeats:      "Weekly",
-				DOWMonday:    true,
-				DOWTuesday:   false,
-				DOWWednesday: false,
-				DOWThursday:  false,
-				DOWFriday:    false,
-				DOWSaturday:  false,
-				DOWSunday:    false,
-				Description:  "Appt follow-up",
-				CreatedDate:  time.Date(2024, 3, 27, 10, 30, 0, 0, time.UTC),
-			},
+			testRecurringEventGroupSchedule(),
 		},
 		TimeSlotType: AppointmentTimeSlotTypeAppointmentSlot,
 	}
@@ -339,3 +343,191 @@ func TestRecurringEventGroupService_Delete(t *testing.T) {
 	assert.NotNil(res)
 	assert.NoError(err)
 }
+
+func TestRecurringEventGroupSchedule_Occurrences(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	testCases := map[string]struct {
+		schedule       func(s *RecurringEventGroupSchedule)
+		from           time.Time
+		to             time.Time
+		expectedStarts []string
+		expectedErr    bool
+	}{
+		"weekly": {
+			schedule: func(s *RecurringEventGroupSchedule) {},
+			from:     time.Date(2024, time.January, 1, 0, 0, 0, 0, loc),
+			to:       time.Date(2024, time.March, 1, 0, 0, 0, 0, loc),
+			expectedStarts: []string{
+				"2024-01-01T10:30:00-05:00",
+				"2024-01-08T10:30:00-05:00",
+				"2024-01-15T10:30:00-05:00",
+				"2024-01-22T10:30:00-05:00",
+				"2024-01-29T10:30:00-05:00",
+			},
+		},
+		"weekly on several days": {
+			schedule: func(s *RecurringEventGroupSchedule) {
+				s.SeriesStop = "2024-03-01"
+				s.EventTime = "11:00:00"
+				s.DOWTuesday = true
+			},
+			from: time.Date(2024, time.February, 19, 0, 0, 0, 0, loc),
+			to:   time.Date(2024, time.March, 10, 0, 0, 0, 0, loc),
+			expectedStarts: []string{
+				"2024-02-19T11:00:00-05:00",
+				"2024-02-20T11:00:00-05:00",
+				"2024-02-26T11:00:00-05:00",
+				"2024-02-27T11:00:00-05:00",
+			},
+		},
+		"window": {
+			schedule: func(s *RecurringEventGroupSchedule) {},
+			from:     time.Date(2024, time.January, 8, 10, 30, 0, 0, loc),
+			to:       time.Date(2024, time.January, 22, 10, 30, 0, 0, loc),
+			expectedStarts: []string{
+				"2024-01-08T10:30:00-05:00",
+				"2024-01-15T10:30:00-05:00",
+			},
+		},
+		"daily without days": {
+			schedule: func(s *RecurringEventGroupSchedule) {
+				s.Repeats = "Daily"
+				s.DOWMonday = false
+			},
+			from: time.Date(2024, time.January, 30, 0, 0, 0, 0, loc),
+			to:   time.Date(2024, time.February, 5, 0, 0, 0, 0, loc),
+			expectedStarts: []string{
+				"2024-01-30T10:30:00-05:00",
+				"2024-01-31T10:30:00-05:00",
+				"2024-02-01T10:30:00-05:00",
+			},
+		},
+		"no series stop": {
+			schedule: func(s *RecurringEventGroupSchedule) {
+				s.SeriesStop = ""
+			},
+			from: time.Date(2025, time.June, 1, 0, 0, 0, 0, loc),
+			to:   time.Date(2025, time.June, 10, 0, 0, 0, 0, loc),
+			expectedStarts: []string{
+				"2025-06-02T10:30:00-04:00",
+				"2025-06-09T10:30:00-04:00",
+			},
+		},
+		"spring forward": {
+			schedule: func(s *RecurringEventGroupSchedule) {
+				s.SeriesStop = ""
+				s.Repeats = "Daily"
+				s.DOWMonday = false
+				s.EventTime = "02:30:00"
+			},
+			from: time.Date(2024, time.March, 9, 0, 0, 0, 0, loc),
+			to:   time.Date(2024, time.March, 12, 0, 0, 0, 0, loc),
+			expectedStarts: []string{
+				"2024-03-09T02:30:00-05:00",
+				"2024-03-10T03:30:00-04:00",
+				"2024-03-11T02:30:00-04:00",
+			},
+		},
+		"fall back": {
+			schedule: func(s *RecurringEventGroupSchedule) {
+				s.SeriesStop = ""
+				s.DOWMonday = false
+				s.DOWSunday = true
+			},
+			from: time.Date(2024, time.October, 27, 0, 0, 0, 0, loc),
+			to:   time.Date(2024, time.November, 4, 0, 0, 0, 0, loc),
+			expectedStarts: []string{
+				"2024-10-27T10:30:00-04:00",
+				"2024-11-03T10:30:00-05:00",
+			},
+		},
+		"monthly": {
+			schedule: func(s *RecurringEventGroupSchedule) {
+				s.SeriesStart = "2024-01-31"
+				s.SeriesStop = "2024-12-31"
+				s.Repeats = "Monthly"
+			},
+			from: time.Date(2024, time.January, 1, 0, 0, 0, 0, loc),
+			to:   time.Date(2024, time.June, 1, 0, 0, 0, 0, loc),
+			expectedStarts: []string{
+				"2024-01-31T10:30:00-05:00",
+				"2024-03-31T10:30:00-04:00",
+				"2024-05-31T10:30:00-04:00",
+			},
+		},
+		"yearly": {
+			schedule: func(s *RecurringEventGroupSchedule) {
+				s.SeriesStart = "2024-02-29"
+				s.SeriesStop = ""
+				s.Repeats = "Yearly"
+			},
+			from: time.Date(2024, time.January, 1, 0, 0, 0, 0, loc),
+			to:   time.Date(2029, time.January, 1, 0, 0, 0, 0, loc),
+			expectedStarts: []string{
+				"2024-02-29T10:30:00-05:00",
+				"2028-02-29T10:30:00-05:00",
+			},
+		},
+		"once": {
+			schedule: func(s *RecurringEventGroupSchedule) {
+				s.Repeats = "Once"
+			},
+			from: time.Date(2024, time.January, 1, 0, 0, 0, 0, loc),
+			to:   time.Date(2024, time.March, 1, 0, 0, 0, 0, loc),
+			expectedStarts: []string{
+				"2024-01-01T10:30:00-05:00",
+			},
+		},
+		"before series start": {
+			schedule: func(s *RecurringEventGroupSchedule) {},
+			from:     time.Date(2023, time.December, 1, 0, 0, 0, 0, loc),
+			to:       time.Date(2024, time.January, 1, 0, 0, 0, 0, loc),
+		},
+		"unsupported repeats": {
+			schedule: func(s *RecurringEventGroupSchedule) {
+				s.Repeats = "Fortnightly"
+			},
+			from:        time.Date(2024, time.January, 1, 0, 0, 0, 0, loc),
+			to:          time.Date(2024, time.March, 1, 0, 0, 0, 0, loc),
+			expectedErr: true,
+		},
+		"invalid event time": {
+			schedule: func(s *RecurringEventGroupSchedule) {
+				s.EventTime = "10:30am"
+			},
+			from:        time.Date(2024, time.January, 1, 0, 0, 0, 0, loc),
+			to:          time.Date(2024, time.March, 1, 0, 0, 0, 0, loc),
+			expectedErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			schedule := testRecurringEventGroupSchedule()
+			testCase.schedule(schedule)
+
+			starts, err := schedule.Occurrences(testCase.from, testCase.to, loc)
+			if testCase.expectedErr {
+				assert.Error(err)
+				return
+			}
+
+			if !assert.NoError(err) {
+				return
+			}
+
+			var actual []string
+			for _, start := range starts {
+				actual = append(actual, start.Format(time.RFC3339))
+			}
+
+			assert.Equal(testCase.expectedStarts, actual)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package elation
+
+import "time"
+
+// Report is a lab, imaging or other report, as sent in webhook events of ResourceReports. Only the fields common to
+// every report type are decoded.
+type Report struct {
+	ID           int64      `json:"id"`            //: 140754680299540,
+	Patient      int64      `json:"patient"`       //: 64058687489,
+	Practice     int64      `json:"practice"`      //: 65540,
+	Physician    int64      `json:"physician"`     //: 131074,
+	ReportType   string     `json:"report_type"`   //: "Lab",
+	CustomTitle  string     `json:"custom_title"`  //: "Lipid panel",
+	DocumentDate time.Time  `json:"document_date"` //: "2016-10-15T23:32:43Z",
+	ChartDate    time.Time  `json:"chart_date"`    //: "2016-10-15T23:32:43Z",
+	SignedBy     *int64     `json:"signed_by"`     //: 131074,
+	SignedDate   *time.Time `json:"signed_date"`   //: "2016-10-15T23:34:18Z",
+	CreatedDate  time.Time  `json:"created_date"`  //: "2016-10-15T23:32:43Z",
+	DeletedDate  *time.Time `json:"deleted_date"`  //: null
+}
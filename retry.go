@@ -0,0 +1,133 @@
+package elation
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryMinBackoff  = 500 * time.Millisecond
+	defaultRetryMaxBackoff  = 10 * time.Second
+)
+
+// RetryPolicy controls how failed requests are retried. Requests are retried when the API responds with a 429 or a
+// 5xx status code, or when the request fails before a response is received. A response whose Retry-After asks for a
+// longer wait than MaxBackoff is returned without retrying.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first. A value less than 2 disables retries.
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+
+	// RetryNonIdempotent enables retries for methods that are not idempotent, such as POST and PATCH.
+	RetryNonIdempotent bool
+}
+
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: defaultRetryMaxAttempts,
+		MinBackoff:  defaultRetryMinBackoff,
+		MaxBackoff:  defaultRetryMaxBackoff,
+	}
+}
+
+func (p *RetryPolicy) maxAttempts(method string) int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+
+	if !isIdempotent(method) && !p.RetryNonIdempotent {
+		return 1
+	}
+
+	return p.MaxAttempts
+}
+
+func (p *RetryPolicy) shouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	return isRetryableStatus(res.StatusCode)
+}
+
+// backoff returns the delay before the given retry attempt (starting at 1). A Retry-After header on the response takes
+// precedence over the computed exponential backoff. It returns false if Retry-After asks for a longer wait than
+// MaxBackoff, in which case the request is not retried.
+func (p *RetryPolicy) backoff(attempt int, res *http.Response) (time.Duration, bool) {
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultRetryMaxBackoff
+	}
+
+	if res != nil {
+		if d, ok := parseRetryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+			return d, d <= maxBackoff
+		}
+	}
+
+	d := p.MinBackoff
+	for i := 1; i < attempt && d < maxBackoff; i++ {
+		d *= 2
+	}
+
+	d = min(d, maxBackoff)
+	if d <= 0 {
+		return 0, true
+	}
+
+	// Full jitter.
+	return rand.N(d) + 1, true
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	seconds, err := strconv.Atoi(v)
+	if err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(t.Sub(now), 0), true
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
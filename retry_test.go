@@ -0,0 +1,272 @@
+package elation
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+	}
+}
+
+func TestHTTPClient_request_retries(t *testing.T) {
+	testCases := map[string]struct {
+		method             string
+		retryNonIdempotent bool
+		statusCodes        []int
+		expectedAttempts   int32
+		expectedStatusCode int
+		expectedErr        bool
+	}{
+		"it retries a GET after a 503": {
+			method:             http.MethodGet,
+			statusCodes:        []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedAttempts:   2,
+			expectedStatusCode: http.StatusOK,
+		},
+		"it retries a DELETE after a 429": {
+			method:             http.MethodDelete,
+			statusCodes:        []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusNoContent},
+			expectedAttempts:   3,
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"it gives up after the maximum number of attempts": {
+			method:             http.MethodGet,
+			statusCodes:        []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			expectedAttempts:   3,
+			expectedStatusCode: http.StatusBadGateway,
+			expectedErr:        true,
+		},
+		"it does not retry a 400": {
+			method:             http.MethodGet,
+			statusCodes:        []int{http.StatusBadRequest, http.StatusOK},
+			expectedAttempts:   1,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        true,
+		},
+		"it does not retry a POST by default": {
+			method:             http.MethodPost,
+			statusCodes:        []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedAttempts:   1,
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedErr:        true,
+		},
+		"it retries a POST when non-idempotent retries are enabled": {
+			method:             http.MethodPost,
+			retryNonIdempotent: true,
+			statusCodes:        []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedAttempts:   2,
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var attempts atomic.Int32
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tokenRequest(w, r) {
+					return
+				}
+
+				assert.Equal(testCase.method, r.Method)
+
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(err)
+				assert.Equal(`{"foo":"bar"}`, string(body))
+
+				attempt := attempts.Add(1)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(testCase.statusCodes[attempt-1])
+				//nolint
+				w.Write([]byte(`{}`))
+			}))
+			defer srv.Close()
+
+			client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+			policy := testRetryPolicy()
+			policy.RetryNonIdempotent = testCase.retryNonIdempotent
+			client.SetRetryPolicy(policy)
+
+			res, err := client.request(context.Background(), testCase.method, "/foo", nil, map[string]string{"foo": "bar"}, nil)
+			assert.Equal(testCase.expectedAttempts, attempts.Load())
+			assert.NotNil(res)
+			assert.Equal(testCase.expectedStatusCode, res.StatusCode)
+
+			if testCase.expectedErr {
+				clientErr := &Error{}
+				assert.True(errors.As(err, &clientErr))
+				assert.Equal(testCase.expectedStatusCode, clientErr.StatusCode)
+			} else {
+				assert.NoError(err)
+			}
+		})
+	}
+}
+
+func TestHTTPClient_request_retries_disabled(t *testing.T) {
+	assert := assert.New(t)
+
+	var attempts atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+	client.SetRetryPolicy(nil)
+
+	_, err := client.request(context.Background(), http.MethodGet, "/foo", nil, nil, nil)
+	assert.Error(err)
+	assert.Equal(int32(1), attempts.Load())
+}
+
+func TestHTTPClient_request_retries_context_canceled(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var attempts atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		attempts.Add(1)
+		cancel()
+
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+	client.SetRetryPolicy(testRetryPolicy())
+
+	_, err := client.request(ctx, http.MethodGet, "/foo", nil, nil, nil)
+	assert.ErrorIs(err, context.Canceled)
+	assert.Equal(int32(1), attempts.Load())
+}
+
+func TestHTTPClient_request_retry_after_exceeds_max_backoff(t *testing.T) {
+	assert := assert.New(t)
+
+	var attempts atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		attempts.Add(1)
+
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+	client.SetRetryPolicy(testRetryPolicy())
+
+	start := time.Now()
+
+	_, err := client.request(context.Background(), http.MethodGet, "/foo", nil, nil, nil)
+	assert.True(IsRateLimited(err))
+	assert.Equal(int32(1), attempts.Load())
+	assert.Less(time.Since(start), time.Second)
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	assert := assert.New(t)
+
+	policy := &RetryPolicy{
+		MaxAttempts: 5,
+		MinBackoff:  10 * time.Millisecond,
+		MaxBackoff:  40 * time.Millisecond,
+	}
+
+	for attempt := 1; attempt <= 5; attempt++ {
+		for range 20 {
+			d, ok := policy.backoff(attempt, nil)
+			assert.True(ok)
+			assert.Greater(d, time.Duration(0))
+			assert.LessOrEqual(d, 40*time.Millisecond)
+		}
+	}
+
+	res := &http.Response{Header: http.Header{}}
+	res.Header.Set("Retry-After", "0")
+	d, ok := policy.backoff(1, res)
+	assert.True(ok)
+	assert.Equal(time.Duration(0), d)
+
+	res.Header.Set("Retry-After", "2")
+	_, ok = policy.backoff(1, res)
+	assert.False(ok, "Retry-After is longer than MaxBackoff")
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		value      string
+		expected   time.Duration
+		expectedOK bool
+	}{
+		"empty": {
+			value: "",
+		},
+		"seconds": {
+			value:      "5",
+			expected:   5 * time.Second,
+			expectedOK: true,
+		},
+		"negative seconds": {
+			value: "-5",
+		},
+		"HTTP date": {
+			value:      now.Add(30 * time.Second).Format(http.TimeFormat),
+			expected:   30 * time.Second,
+			expectedOK: true,
+		},
+		"HTTP date in the past": {
+			value:      now.Add(-30 * time.Second).Format(http.TimeFormat),
+			expected:   0,
+			expectedOK: true,
+		},
+		"invalid": {
+			value: "foo",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			d, ok := parseRetryAfter(testCase.value, now)
+			assert.Equal(testCase.expected, d)
+			assert.Equal(testCase.expectedOK, ok)
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package elation
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultSigningKeyRefreshInterval = time.Minute
+
+var (
+	ErrSigningPubKey         = errors.New("invalid signing public key")
+	ErrSigningKeyUnavailable = errors.New("loading signing keys")
+)
+
+// WebhookVerifier verifies webhook requests. It is implemented by SigningKeyProvider.
+type WebhookVerifier interface {
+	Verify(r *http.Request) (*Event, error)
+}
+
+// ParseSigningPubKey decodes the signing_pub_key of a subscription. The key is usually the base64 encoded raw ed25519
+// key, but base64 or PEM encoded PKIX keys and hex encoded raw keys are also accepted.
+func ParseSigningPubKey(s string) (ed25519.PublicKey, error) {
+	s = strings.TrimSpace(s)
+
+	if block, _ := pem.Decode([]byte(s)); block != nil {
+		return parsePKIXSigningPubKey(block.Bytes)
+	}
+
+	if b, err := hex.DecodeString(s); err == nil && len(b) == ed25519.PublicKeySize {
+		return ed25519.PublicKey(b), nil
+	}
+
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSigningPubKey, err)
+	}
+
+	if len(b) == ed25519.PublicKeySize {
+		return ed25519.PublicKey(b), nil
+	}
+
+	return parsePKIXSigningPubKey(b)
+}
+
+func parsePKIXSigningPubKey(der []byte) (ed25519.PublicKey, error) {
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSigningPubKey, err)
+	}
+
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected key type %T", ErrSigningPubKey, key)
+	}
+
+	return publicKey, nil
+}
+
+// SigningKeyProvider verifies webhooks with the signing keys of the application's subscriptions. Keys are loaded on
+// first use and cached. An event is tried against the key that last verified an event of the same application, then the
+// keys of subscriptions to its resource, then every other key. If none of them verifies it, the keys are reloaded,
+// at most once per MinRefreshInterval, so that rotated keys and new subscriptions are picked up. Concurrent reloads
+// share one request, and failed reloads count towards the interval too.
+type SigningKeyProvider struct {
+	// MinRefreshInterval is the minimum time between reloads caused by verification failures, whether or not they
+	// succeed, which keeps forged requests from causing a request to Elation each. It defaults to one minute.
+	MinRefreshInterval time.Duration
+
+	// AllowUnknownResources accepts events whose resource is not one of the Resource constants, like
+	// WithUnknownResources.
+	AllowUnknownResources bool
+
+	subscriptions SubscriptionServicer
+	now           func() time.Time
+
+	mu            sync.Mutex
+	loaded        bool
+	attemptedAt   time.Time
+	lastErr       error
+	inflight      *signingKeyRefresh
+	keys          []*subscriptionKey
+	byApplication map[string]ed25519.PublicKey
+}
+
+// signingKeyRefresh is a reload of the keys that other callers can wait for.
+type signingKeyRefresh struct {
+	done chan struct{}
+	err  error
+}
+
+type subscriptionKey struct {
+	resource Resource
+	key      ed25519.PublicKey
+}
+
+var _ WebhookVerifier = (*SigningKeyProvider)(nil)
+
+func NewSigningKeyProvider(subscriptions SubscriptionServicer) *SigningKeyProvider {
+	return &SigningKeyProvider{
+		MinRefreshInterval: defaultSigningKeyRefreshInterval,
+		subscriptions:      subscriptions,
+		now:                time.Now,
+		byApplication:      map[string]ed25519.PublicKey{},
+	}
+}
+
+// Verify verifies a webhook request like VerifyWebhook, using the key matching the event.
+func (p *SigningKeyProvider) Verify(r *http.Request) (*Event, error) {
+	body, sig, err := readWebhook(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// The event is only used to choose which keys to try until its signature is verified.
+	unverified, _ := decodeEvent(body, true)
+	if unverified == nil {
+		unverified = &Event{}
+	}
+
+	key, err := p.verify(r.Context(), unverified, body, sig)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := decodeEvent(body, p.AllowUnknownResources)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.ApplicationID != "" {
+		p.mu.Lock()
+		p.byApplication[event.ApplicationID] = key
+		p.mu.Unlock()
+	}
+
+	return event, nil
+}
+
+// Refresh reloads the keys from the subscriptions. If a reload is already in progress, Refresh waits for it instead.
+// Subscriptions whose keys do not parse are skipped, and returned as errors that wrap ErrSigningPubKey once the other
+// keys are loaded.
+func (p *SigningKeyProvider) Refresh(ctx context.Context) error {
+	return p.refresh(ctx, true)
+}
+
+// refresh reloads the keys, or waits for the reload in progress. Unless force is set, the keys are not reloaded within
+// MinRefreshInterval of the previous attempt; the error of that attempt is then returned if no keys were ever loaded.
+func (p *SigningKeyProvider) refresh(ctx context.Context, force bool) error {
+	p.mu.Lock()
+
+	if r := p.inflight; r != nil {
+		p.mu.Unlock()
+
+		select {
+		case <-r.done:
+			return r.err
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ErrSigningKeyUnavailable, ctx.Err())
+		}
+	}
+
+	now := p.now()
+	if !force && !p.attemptedAt.IsZero() && now.Sub(p.attemptedAt) < p.MinRefreshInterval {
+		var err error
+		if !p.loaded {
+			err = p.lastErr
+		}
+
+		p.mu.Unlock()
+
+		return err
+	}
+
+	r := &signingKeyRefresh{done: make(chan struct{})}
+	p.inflight = r
+	p.attemptedAt = now
+	p.mu.Unlock()
+
+	r.err = p.load(ctx)
+
+	p.mu.Lock()
+	p.inflight = nil
+	p.lastErr = r.err
+	p.mu.Unlock()
+
+	close(r.done)
+
+	return r.err
+}
+
+func (p *SigningKeyProvider) load(ctx context.Context) error {
+	subscriptions, _, err := p.subscriptions.Find(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: finding subscriptions: %w", ErrSigningKeyUnavailable, err)
+	}
+
+	// A key that does not parse only affects its own subscription, so the others are still loaded.
+	var keys []*subscriptionKey
+	var errs []error
+	for _, subscription := range subscriptions {
+		if subscription.DeletedDate != nil || subscription.SigningPubKey == "" {
+			continue
+		}
+
+		key, err := ParseSigningPubKey(subscription.SigningPubKey)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("subscription %d: %w", subscription.ID, err))
+			continue
+		}
+
+		keys = append(keys, &subscriptionKey{resource: subscription.Resource, key: key})
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.loaded = true
+	p.keys = keys
+
+	// Keys learned from events may have been rotated away.
+	for applicationID, key := range p.byApplication {
+		if !p.hasKey(key) {
+			delete(p.byApplication, applicationID)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (p *SigningKeyProvider) verify(ctx context.Context, event *Event, body []byte, sig []byte) (ed25519.PublicKey, error) {
+	if key := p.match(event, body, sig); key != nil {
+		return key, nil
+	}
+
+	// Keys that do not parse are reported by Refresh, but the other keys can still verify the event.
+	if err := p.refresh(ctx, false); err != nil && !errors.Is(err, ErrSigningPubKey) {
+		return nil, err
+	}
+
+	if key := p.match(event, body, sig); key != nil {
+		return key, nil
+	}
+
+	return nil, ErrWebhookSignature
+}
+
+// match returns the cached key that verifies sig, trying the most likely keys for event first.
+func (p *SigningKeyProvider) match(event *Event, body []byte, sig []byte) ed25519.PublicKey {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var candidates []ed25519.PublicKey
+	if key, ok := p.byApplication[event.ApplicationID]; ok && event.ApplicationID != "" {
+		candidates = append(candidates, key)
+	}
+
+	for _, key := range p.keys {
+		if key.resource == event.Resource {
+			candidates = append(candidates, key.key)
+		}
+	}
+
+	for _, key := range p.keys {
+		if key.resource != event.Resource {
+			candidates = append(candidates, key.key)
+		}
+	}
+
+	for _, key := range candidates {
+		if ed25519.Verify(key, body, sig) {
+			return key
+		}
+	}
+
+	return nil
+}
+
+func (p *SigningKeyProvider) hasKey(key ed25519.PublicKey) bool {
+	for _, k := range p.keys {
+		if k.key.Equal(key) {
+			return true
+		}
+	}
+
+	return false
+}
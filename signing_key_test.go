@@ -0,0 +1,294 @@
+package elation
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSigningPubKey(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	assert.NoError(t, err)
+
+	testCases := map[string]struct {
+		key         string
+		expectedErr bool
+	}{
+		"base64": {
+			key: base64.StdEncoding.EncodeToString(publicKey),
+		},
+		"base64 PKIX": {
+			key: base64.StdEncoding.EncodeToString(der),
+		},
+		"PEM": {
+			key: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+		},
+		"hex": {
+			key: hex.EncodeToString(publicKey),
+		},
+		"invalid": {
+			key:         "foo",
+			expectedErr: true,
+		},
+		"wrong length": {
+			key:         base64.StdEncoding.EncodeToString([]byte("foo")),
+			expectedErr: true,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			actual, err := ParseSigningPubKey(testCase.key)
+			if testCase.expectedErr {
+				assert.ErrorIs(err, ErrSigningPubKey)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(publicKey, actual)
+		})
+	}
+}
+
+func TestSigningKeyProvider(t *testing.T) {
+	assert := assert.New(t)
+
+	patientsPublicKey, patientsPrivateKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(err)
+
+	appointmentsPublicKey, appointmentsPrivateKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(err)
+
+	rotatedPublicKey, rotatedPrivateKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(err)
+
+	var mu sync.Mutex
+	requests := 0
+	subscriptions := []*Subscription{
+		{ID: 1, Resource: ResourcePatients, SigningPubKey: base64.StdEncoding.EncodeToString(patientsPublicKey)},
+		{ID: 2, Resource: ResourceAppointments, SigningPubKey: base64.StdEncoding.EncodeToString(appointmentsPublicKey)},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		requests++
+
+		b, err := json.Marshal(Response[[]*Subscription]{Results: subscriptions})
+		assert.NoError(err)
+
+		w.Header().Set("Content-Type", "application/json")
+		//nolint
+		w.Write(b)
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	provider := NewSigningKeyProvider(client.Subscriptions())
+	provider.now = func() time.Time { return now }
+
+	verify := func(privateKey ed25519.PrivateKey, resource Resource) (*Event, error) {
+		b, err := json.Marshal(&Event{
+			Data:          []byte(`{}`),
+			Action:        WebhookEventActionSaved,
+			ApplicationID: "app",
+			Resource:      resource,
+		})
+		assert.NoError(err)
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(b))
+		req.Header.Set(WebhookSignatureHeader, base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, b)))
+
+		return provider.Verify(req)
+	}
+
+	event, err := verify(patientsPrivateKey, ResourcePatients)
+	assert.NoError(err)
+	assert.Equal(ResourcePatients, event.Resource)
+
+	_, err = verify(appointmentsPrivateKey, ResourceAppointments)
+	assert.NoError(err)
+	assert.Equal(1, requests)
+
+	// Rotate the appointments key. Failures within the refresh interval do not reload the keys.
+	mu.Lock()
+	subscriptions[1].SigningPubKey = base64.StdEncoding.EncodeToString(rotatedPublicKey)
+	mu.Unlock()
+
+	_, err = verify(rotatedPrivateKey, ResourceAppointments)
+	assert.ErrorIs(err, ErrWebhookSignature)
+	assert.Equal(1, requests)
+
+	now = now.Add(time.Minute)
+
+	_, err = verify(rotatedPrivateKey, ResourceAppointments)
+	assert.NoError(err)
+	assert.Equal(2, requests)
+
+	// The old key is no longer accepted.
+	_, err = verify(appointmentsPrivateKey, ResourceAppointments)
+	assert.ErrorIs(err, ErrWebhookSignature)
+
+	_, err = verify(patientsPrivateKey, ResourcePatients)
+	assert.NoError(err)
+	assert.Equal(2, requests)
+}
+
+func TestSigningKeyProvider_unavailable(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+	handler := NewWebhookHandlerWithVerifier(NewSigningKeyProvider(client.Subscriptions()))
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{}`)))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(http.StatusInternalServerError, rec.Code)
+}
+
+func TestSigningKeyProvider_refresh_limited(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests atomic.Int32
+	var available atomic.Bool
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		requests.Add(1)
+
+		if !available.Load() {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		//nolint
+		w.Write([]byte(`{"results":[]}`))
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	provider := NewSigningKeyProvider(client.Subscriptions())
+	provider.now = func() time.Time { return now }
+
+	_, forgedKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(err)
+
+	verify := func() error {
+		b := []byte(`{"resource":"patients","action":"saved","data":{}}`)
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(b))
+		req.Header.Set(WebhookSignatureHeader, base64.StdEncoding.EncodeToString(ed25519.Sign(forgedKey, b)))
+
+		_, err := provider.Verify(req)
+		return err
+	}
+
+	// While the API is down, failed reloads are limited like successful ones.
+	for range 3 {
+		assert.ErrorIs(verify(), ErrSigningKeyUnavailable)
+	}
+	assert.Equal(int32(1), requests.Load())
+
+	now = now.Add(time.Minute)
+	available.Store(true)
+
+	// A burst of forged webhooks shares one reload.
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			assert.ErrorIs(verify(), ErrWebhookSignature)
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(int32(2), requests.Load())
+
+	assert.NoError(provider.Refresh(context.Background()))
+	assert.Equal(int32(3), requests.Load(), "Refresh is not limited")
+}
+
+func TestSigningKeyProvider_invalid_key(t *testing.T) {
+	assert := assert.New(t)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		b, err := json.Marshal(Response[[]*Subscription]{Results: []*Subscription{
+			{ID: 1, Resource: ResourcePatients, SigningPubKey: "not a key"},
+			{ID: 2, Resource: ResourceAppointments, SigningPubKey: base64.StdEncoding.EncodeToString(publicKey)},
+		}})
+		assert.NoError(err)
+
+		w.Header().Set("Content-Type", "application/json")
+		//nolint
+		w.Write(b)
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+	provider := NewSigningKeyProvider(client.Subscriptions())
+
+	b := []byte(`{"resource":"appointments","action":"saved","data":{}}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(b))
+	req.Header.Set(WebhookSignatureHeader, base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, b)))
+
+	event, err := provider.Verify(req)
+	if assert.NoError(err, "the valid key still verifies") {
+		assert.Equal(ResourceAppointments, event.Resource)
+	}
+
+	err = provider.Refresh(context.Background())
+	assert.ErrorIs(err, ErrSigningPubKey)
+	assert.ErrorContains(err, "subscription 1")
+	assert.NotErrorIs(err, ErrSigningKeyUnavailable)
+}
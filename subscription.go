@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -37,22 +38,63 @@ type Subscription struct {
 
 type Resource string
 
+// Resources that can be subscribed to.
 const (
 	ResourceAppointments            Resource = "appointments"
 	ResourceAllergies               Resource = "allergies"
 	ResourceAllergyDocumentation    Resource = "allergy_documentation"
+	ResourceBills                   Resource = "bills"
+	ResourceClinicalDocuments       Resource = "clinical_documents"
 	ResourceDiscontinuedMedications Resource = "discontinued_medications"
+	ResourceInsuranceCompanies      Resource = "insurance_companies"
+	ResourceInsurancePlans          Resource = "insurance_plans"
+	ResourceInsurancePolicies       Resource = "insurance_policies"
 	ResourceLetters                 Resource = "letters"
 	ResourceMedications             Resource = "medications"
+	ResourceMessageThreads          Resource = "message_threads"
+	ResourceNonVisitNotes           Resource = "non_visit_notes"
 	ResourcePatients                Resource = "patients"
 	ResourcePhysicians              Resource = "physicians"
 	ResourceProblems                Resource = "problems"
+	ResourceReports                 Resource = "reports"
+	ResourceServiceLocations        Resource = "service_locations"
+	ResourceVisitNotes              Resource = "visit_notes"
 )
 
+// Resources returns the resources that can be subscribed to.
+func Resources() []Resource {
+	return []Resource{
+		ResourceAppointments,
+		ResourceAllergies,
+		ResourceAllergyDocumentation,
+		ResourceBills,
+		ResourceClinicalDocuments,
+		ResourceDiscontinuedMedications,
+		ResourceInsuranceCompanies,
+		ResourceInsurancePlans,
+		ResourceInsurancePolicies,
+		ResourceLetters,
+		ResourceMedications,
+		ResourceMessageThreads,
+		ResourceNonVisitNotes,
+		ResourcePatients,
+		ResourcePhysicians,
+		ResourceProblems,
+		ResourceReports,
+		ResourceServiceLocations,
+		ResourceVisitNotes,
+	}
+}
+
 func (r Resource) String() string {
 	return string(r)
 }
 
+// IsKnown reports whether r can be subscribed to.
+func (r Resource) IsKnown() bool {
+	return slices.Contains(Resources(), r)
+}
+
 type SubscriptionJSONDate time.Time
 
 func (s *SubscriptionJSONDate) MarshalJSON() ([]byte, error) {
@@ -93,6 +135,50 @@ type Subscribe struct {
 	Properties json.RawMessage `json:"properties"`
 }
 
+// SubscriptionProperties are the filters of a subscription, sent as the properties of POST /app/subscriptions/. The
+// filters are those of the Subscriptions section of Elation's API reference (https://docs.elationhealth.com/reference),
+// which lists the resources each of them applies to. A filter only applies to resources that have the matching field,
+// and an empty filter matches every row. Filters that are not listed here can be set with Subscribe.Properties.
+type SubscriptionProperties struct {
+	Practice        []int64 `json:"practice,omitempty"`
+	Physician       []int64 `json:"physician,omitempty"`
+	ServiceLocation []int64 `json:"service_location,omitempty"`
+
+	// SignedOnly only sends events for signed notes and reports.
+	SignedOnly bool `json:"signed_only,omitempty"`
+}
+
+// NewSubscribe returns a Subscribe for resource with the given properties, which may be nil.
+func NewSubscribe(resource Resource, target string, properties *SubscriptionProperties) *Subscribe {
+	subscribe := &Subscribe{
+		Resource: resource,
+		Target:   target,
+	}
+
+	if properties != nil {
+		// SubscriptionProperties always marshals.
+		//nolint
+		subscribe.Properties, _ = json.Marshal(properties)
+	}
+
+	return subscribe
+}
+
+// DecodeProperties returns the properties of the subscription, or nil if it has none.
+func (s *Subscribe) DecodeProperties() (*SubscriptionProperties, error) {
+	if len(s.Properties) == 0 || string(s.Properties) == "null" {
+		return nil, nil
+	}
+
+	properties := &SubscriptionProperties{}
+	err := json.Unmarshal(s.Properties, properties)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling properties: %w", err)
+	}
+
+	return properties, nil
+}
+
 func (s *SubscriptionService) Subscribe(ctx context.Context, subscribe *Subscribe) (*Subscription, *http.Response, error) {
 	ctx, span := s.client.tracer.Start(ctx, "subscribe", trace.WithSpanKind(trace.SpanKindClient))
 	defer span.End()
@@ -0,0 +1,111 @@
+package elation
+
+import (
+	"context"
+	"fmt"
+)
+
+type ReconcileSubscriptionsOptions struct {
+	// DryRun returns the plan without creating or deleting subscriptions.
+	DryRun bool
+}
+
+// SubscriptionPlan is the result of ReconcileSubscriptions.
+type SubscriptionPlan struct {
+	// Create are the desired subscriptions that do not exist.
+	Create []*Subscribe
+
+	// Delete are the existing subscriptions that are not desired, including subscriptions to a desired resource with
+	// another target.
+	Delete []*Subscription
+
+	// Keep are the existing subscriptions that match a desired subscription.
+	Keep []*Subscription
+
+	// Created are the subscriptions created when the plan was applied, in the order of Create.
+	Created []*Subscription
+}
+
+// IsEmpty reports whether the plan has nothing to create or delete.
+func (p *SubscriptionPlan) IsEmpty() bool {
+	return len(p.Create) == 0 && len(p.Delete) == 0
+}
+
+// ReconcileSubscriptions makes the active subscriptions match desired. Subscriptions are matched by resource and target,
+// and only one subscription is kept for each match. Properties are only used when creating subscriptions, since they are
+// not returned by Find.
+//
+// Missing subscriptions are created before stale ones are deleted, so that a change of target does not miss events. If
+// an error occurs, the plan is returned with the subscriptions created so far.
+func ReconcileSubscriptions(ctx context.Context, subscriptions SubscriptionServicer, desired []Subscribe, opts *ReconcileSubscriptionsOptions) (*SubscriptionPlan, error) {
+	existing, _, err := subscriptions.Find(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("finding subscriptions: %w", err)
+	}
+
+	plan := &SubscriptionPlan{}
+
+	type key struct {
+		resource Resource
+		target   string
+	}
+
+	wanted := map[key]bool{}
+	for _, subscribe := range desired {
+		k := key{subscribe.Resource, subscribe.Target}
+		if wanted[k] {
+			continue
+		}
+
+		wanted[k] = true
+
+		found := false
+		for _, subscription := range existing {
+			if subscription.DeletedDate == nil && subscription.Resource == subscribe.Resource && subscription.Target == subscribe.Target {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			plan.Create = append(plan.Create, &subscribe)
+		}
+	}
+
+	kept := map[key]bool{}
+	for _, subscription := range existing {
+		if subscription.DeletedDate != nil {
+			continue
+		}
+
+		k := key{subscription.Resource, subscription.Target}
+		if wanted[k] && !kept[k] {
+			kept[k] = true
+			plan.Keep = append(plan.Keep, subscription)
+		} else {
+			plan.Delete = append(plan.Delete, subscription)
+		}
+	}
+
+	if opts != nil && opts.DryRun {
+		return plan, nil
+	}
+
+	for _, subscribe := range plan.Create {
+		created, _, err := subscriptions.Subscribe(ctx, subscribe)
+		if err != nil {
+			return plan, fmt.Errorf("subscribing to %s: %w", subscribe.Resource, err)
+		}
+
+		plan.Created = append(plan.Created, created)
+	}
+
+	for _, subscription := range plan.Delete {
+		_, err := subscriptions.Delete(ctx, subscription.ID)
+		if err != nil {
+			return plan, fmt.Errorf("deleting subscription %d: %w", subscription.ID, err)
+		}
+	}
+
+	return plan, nil
+}
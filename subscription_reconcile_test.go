@@ -0,0 +1,166 @@
+package elation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcileSubscriptions(t *testing.T) {
+	desired := []Subscribe{
+		{Resource: ResourcePatients, Target: "https://example.com/webhooks"},
+		{Resource: ResourceAppointments, Target: "https://example.com/webhooks"},
+		{Resource: ResourceProblems, Target: "https://example.com/webhooks"},
+	}
+
+	testCases := map[string]struct {
+		existing         []*Subscription
+		dryRun           bool
+		expectedCreate   []Resource
+		expectedDelete   []int64
+		expectedKeep     []int64
+		expectedRequests []string
+	}{
+		"create all": {
+			expectedCreate:   []Resource{ResourcePatients, ResourceAppointments, ResourceProblems},
+			expectedRequests: []string{"POST patients", "POST appointments", "POST problems"},
+		},
+		"up to date": {
+			existing: []*Subscription{
+				{ID: 1, Resource: ResourcePatients, Target: "https://example.com/webhooks"},
+				{ID: 2, Resource: ResourceAppointments, Target: "https://example.com/webhooks"},
+				{ID: 3, Resource: ResourceProblems, Target: "https://example.com/webhooks"},
+			},
+			expectedKeep: []int64{1, 2, 3},
+		},
+		"wrong target, stale and duplicate": {
+			existing: []*Subscription{
+				{ID: 1, Resource: ResourcePatients, Target: "https://example.com/webhooks"},
+				{ID: 2, Resource: ResourceAppointments, Target: "https://old.example.com/webhooks"},
+				{ID: 3, Resource: ResourceProblems, Target: "https://example.com/webhooks"},
+				{ID: 4, Resource: ResourceProblems, Target: "https://example.com/webhooks"},
+				{ID: 5, Resource: ResourceLetters, Target: "https://example.com/webhooks"},
+			},
+			expectedCreate:   []Resource{ResourceAppointments},
+			expectedDelete:   []int64{2, 4, 5},
+			expectedKeep:     []int64{1, 3},
+			expectedRequests: []string{"POST appointments", "DELETE 2", "DELETE 4", "DELETE 5"},
+		},
+		"dry run": {
+			existing: []*Subscription{
+				{ID: 1, Resource: ResourceLetters, Target: "https://example.com/webhooks"},
+			},
+			dryRun:         true,
+			expectedCreate: []Resource{ResourcePatients, ResourceAppointments, ResourceProblems},
+			expectedDelete: []int64{1},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var requests []string
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tokenRequest(w, r) {
+					return
+				}
+
+				switch r.Method {
+				case http.MethodGet:
+					b, err := json.Marshal(Response[[]*Subscription]{Results: testCase.existing})
+					assert.NoError(err)
+
+					w.Header().Set("Content-Type", "application/json")
+					//nolint
+					w.Write(b)
+
+				case http.MethodPost:
+					subscribe := &Subscribe{}
+					assert.NoError(json.NewDecoder(r.Body).Decode(subscribe))
+					requests = append(requests, "POST "+subscribe.Resource.String())
+
+					b, err := json.Marshal(&Subscription{ID: 100, Resource: subscribe.Resource, Target: subscribe.Target})
+					assert.NoError(err)
+
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusCreated)
+					//nolint
+					w.Write(b)
+
+				case http.MethodDelete:
+					id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/app/subscriptions/"), "/")
+					requests = append(requests, "DELETE "+id)
+
+					w.WriteHeader(http.StatusNoContent)
+				}
+			}))
+			defer srv.Close()
+
+			client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+			plan, err := ReconcileSubscriptions(context.Background(), client.Subscriptions(), desired, &ReconcileSubscriptionsOptions{DryRun: testCase.dryRun})
+			assert.NoError(err)
+
+			var create []Resource
+			for _, subscribe := range plan.Create {
+				create = append(create, subscribe.Resource)
+			}
+			assert.Equal(testCase.expectedCreate, create)
+
+			var deleted []int64
+			for _, subscription := range plan.Delete {
+				deleted = append(deleted, subscription.ID)
+			}
+			assert.Equal(testCase.expectedDelete, deleted)
+
+			var keep []int64
+			for _, subscription := range plan.Keep {
+				keep = append(keep, subscription.ID)
+			}
+			assert.Equal(testCase.expectedKeep, keep)
+
+			assert.Equal(testCase.expectedRequests, requests)
+			assert.Equal(len(testCase.expectedCreate) == 0 && len(testCase.expectedDelete) == 0, plan.IsEmpty())
+
+			if !testCase.dryRun {
+				assert.Len(plan.Created, len(testCase.expectedCreate))
+			}
+		})
+	}
+}
+
+func TestReconcileSubscriptions_error(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequest(w, r) {
+			return
+		}
+
+		if r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "application/json")
+			//nolint
+			w.Write([]byte(`{"results":[{"id":1,"resource":"letters","target":"https://example.com"}]}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusBadRequest)
+		//nolint
+		w.Write([]byte(`{"target":["Enter a valid URL."]}`))
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.Client(), srv.URL+"/token", "", "", srv.URL)
+
+	plan, err := ReconcileSubscriptions(context.Background(), client.Subscriptions(), []Subscribe{{Resource: ResourcePatients, Target: "foo"}}, nil)
+	assert.True(IsBadRequest(err))
+	assert.Len(plan.Create, 1)
+	assert.Empty(plan.Created)
+	assert.Equal(int64(1), plan.Delete[0].ID)
+}
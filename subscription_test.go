@@ -121,3 +121,32 @@ func TestSubscriptionService_Delete(t *testing.T) {
 	assert.NotNil(res)
 	assert.NoError(err)
 }
+
+func TestNewSubscribe(t *testing.T) {
+	assert := assert.New(t)
+
+	subscribe := NewSubscribe(ResourceVisitNotes, "https://example.com", &SubscriptionProperties{
+		Practice:   []int64{1},
+		SignedOnly: true,
+	})
+	assert.JSONEq(`{"practice":[1],"signed_only":true}`, string(subscribe.Properties))
+
+	properties, err := subscribe.DecodeProperties()
+	assert.NoError(err)
+	assert.Equal(&SubscriptionProperties{Practice: []int64{1}, SignedOnly: true}, properties)
+
+	subscribe = NewSubscribe(ResourcePatients, "https://example.com", nil)
+	assert.Nil(subscribe.Properties)
+
+	properties, err = subscribe.DecodeProperties()
+	assert.NoError(err)
+	assert.Nil(properties)
+}
+
+func TestResource_IsKnown(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(ResourceReports.IsKnown())
+	assert.False(ResourcePrescriptionFills.IsKnown())
+	assert.False(Resource("foo").IsKnown())
+}
@@ -15,7 +15,12 @@ const (
 	WebhookSignatureHeader = "El8-Ed25519-Signature"
 )
 
-var ErrPublicKeyLength = errors.New("incorrect length of public key")
+var (
+	ErrPublicKeyLength  = errors.New("incorrect length of public key")
+	ErrPrivateKeyLength = errors.New("incorrect length of private key")
+	ErrWebhookSignature = errors.New("verifying signature")
+	ErrUnknownResource  = errors.New("unknown resource")
+)
 
 type WebhookEventAction string
 
@@ -36,33 +41,86 @@ type Event struct {
 	Resource      Resource           `json:"resource"`
 }
 
-func VerifyWebhook(r *http.Request, publicKey []byte) (*Event, error) {
+type verifyOptions struct {
+	allowUnknownResources bool
+}
+
+type VerifyOption func(*verifyOptions)
+
+// WithUnknownResources accepts events whose resource is not one of the Resource constants, instead of returning
+// ErrUnknownResource.
+func WithUnknownResources() VerifyOption {
+	return func(o *verifyOptions) {
+		o.allowUnknownResources = true
+	}
+}
+
+func VerifyWebhook(r *http.Request, publicKey []byte, opts ...VerifyOption) (*Event, error) {
+	o := &verifyOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	if len(publicKey) != ed25519.PublicKeySize {
 		return nil, ErrPublicKeyLength
 	}
 
+	body, sig, err := readWebhook(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ed25519.Verify(publicKey, body, sig) {
+		return nil, ErrWebhookSignature
+	}
+
+	return decodeEvent(body, o.allowUnknownResources)
+}
+
+// SignWebhook marshals event and signs it with privateKey. It returns the request body and the value of the
+// WebhookSignatureHeader, as Elation would send them.
+func SignWebhook(privateKey ed25519.PrivateKey, event *Event) ([]byte, string, error) {
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return nil, "", ErrPrivateKeyLength
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return nil, "", fmt.Errorf("marshaling event: %w", err)
+	}
+
+	return body, base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, body)), nil
+}
+
+// readWebhook returns the body and decoded signature of a webhook request. The request body is replaced so that it can
+// be read again.
+func readWebhook(r *http.Request) ([]byte, []byte, error) {
 	sig, err := base64.StdEncoding.DecodeString(r.Header.Get(WebhookSignatureHeader))
 	if err != nil {
-		return nil, fmt.Errorf("decoding Ed25519 signature: %w", err)
+		return nil, nil, fmt.Errorf("decoding Ed25519 signature: %w", err)
 	}
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		return nil, fmt.Errorf("reading request body: %w", err)
+		return nil, nil, fmt.Errorf("reading request body: %w", err)
 	}
 	//nolint
 	_ = r.Body.Close()
 	r.Body = io.NopCloser(bytes.NewBuffer(body))
 
-	if !ed25519.Verify(publicKey, body, sig) {
-		return nil, errors.New("verifying signature")
-	}
+	return body, sig, nil
+}
 
+func decodeEvent(body []byte, allowUnknownResources bool) (*Event, error) {
 	event := &Event{}
-	err = json.Unmarshal(body, event)
+	err := json.Unmarshal(body, event)
 	if err != nil {
 		return nil, fmt.Errorf("unmarshaling body: %w", err)
 	}
 
+	if !allowUnknownResources && !event.Resource.IsKnown() {
+		return nil, fmt.Errorf("%w %q", ErrUnknownResource, event.Resource)
+	}
+
 	return event, nil
 }
@@ -0,0 +1,372 @@
+package elation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// WebhookHandler is an http.Handler that verifies webhooks and dispatches them to the callback registered for their
+// resource and action, with Data decoded into the resource's type.
+//
+// It responds with 200 once the callback succeeds, or when no callback is registered for the event, and with a 4xx
+// status for requests that are not valid webhooks. Callback errors result in a 500 so that Elation retries the event.
+//
+// With an EventStore, redelivered events are acknowledged without calling the callback again, and out-of-order events
+// are passed to the OnOutOfOrder callback instead of their own.
+type WebhookHandler struct {
+	verifier WebhookVerifier
+
+	mu           sync.RWMutex
+	callbacks    map[webhookRoute]func(context.Context, *Event) error
+	onError      func(*http.Request, error)
+	onOutOfOrder func(context.Context, *Event) error
+	store        EventStore
+}
+
+type webhookRoute struct {
+	resource Resource
+	action   WebhookEventAction
+}
+
+type webhookPublicKey struct {
+	key  []byte
+	opts []VerifyOption
+}
+
+func (k *webhookPublicKey) Verify(r *http.Request) (*Event, error) {
+	return VerifyWebhook(r, k.key, k.opts...)
+}
+
+// NewWebhookHandler returns a handler that verifies webhooks with publicKey.
+func NewWebhookHandler(publicKey []byte, opts ...VerifyOption) *WebhookHandler {
+	return NewWebhookHandlerWithVerifier(&webhookPublicKey{publicKey, opts})
+}
+
+// NewWebhookHandlerWithVerifier returns a handler that verifies webhooks with verifier, such as a SigningKeyProvider.
+func NewWebhookHandlerWithVerifier(verifier WebhookVerifier) *WebhookHandler {
+	return &WebhookHandler{
+		verifier:  verifier,
+		callbacks: map[webhookRoute]func(context.Context, *Event) error{},
+	}
+}
+
+// OnEvent registers fn for events of resource with action. It replaces any callback registered for the same events,
+// including typed ones such as OnPatientSaved.
+func (h *WebhookHandler) OnEvent(resource Resource, action WebhookEventAction, fn func(ctx context.Context, event *Event) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.callbacks[webhookRoute{resource, action}] = fn
+}
+
+// OnError registers fn to be called with the request and error whenever the handler responds with an error status.
+func (h *WebhookHandler) OnError(fn func(r *http.Request, err error)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.onError = fn
+}
+
+// SetEventStore sets the store used to detect duplicate and out-of-order events. Events are claimed before their
+// callback is called, and recorded once it succeeds.
+func (h *WebhookHandler) SetEventStore(store EventStore) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.store = store
+}
+
+// OnOutOfOrder registers fn for events that are older than an event already processed for the same resource ID. Without
+// it, out-of-order events are acknowledged and dropped.
+func (h *WebhookHandler) OnOutOfOrder(fn func(ctx context.Context, event *Event) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.onOutOfOrder = fn
+}
+
+func (h *WebhookHandler) OnAllergySaved(fn func(ctx context.Context, allergy *Allergy) error) {
+	onWebhook(h, ResourceAllergies, WebhookEventActionSaved, fn)
+}
+
+func (h *WebhookHandler) OnAllergyDeleted(fn func(ctx context.Context, allergy *Allergy) error) {
+	onWebhook(h, ResourceAllergies, WebhookEventActionDeleted, fn)
+}
+
+func (h *WebhookHandler) OnAllergyDocumentationSaved(fn func(ctx context.Context, allergyDocumentation *AllergyDocumentation) error) {
+	onWebhook(h, ResourceAllergyDocumentation, WebhookEventActionSaved, fn)
+}
+
+func (h *WebhookHandler) OnAllergyDocumentationDeleted(fn func(ctx context.Context, allergyDocumentation *AllergyDocumentation) error) {
+	onWebhook(h, ResourceAllergyDocumentation, WebhookEventActionDeleted, fn)
+}
+
+func (h *WebhookHandler) OnAppointmentSaved(fn func(ctx context.Context, appointment *Appointment) error) {
+	onWebhook(h, ResourceAppointments, WebhookEventActionSaved, fn)
+}
+
+func (h *WebhookHandler) OnAppointmentDeleted(fn func(ctx context.Context, appointment *Appointment) error) {
+	onWebhook(h, ResourceAppointments, WebhookEventActionDeleted, fn)
+}
+
+func (h *WebhookHandler) OnBillSaved(fn func(ctx context.Context, bill *Bill) error) {
+	onWebhook(h, ResourceBills, WebhookEventActionSaved, fn)
+}
+
+func (h *WebhookHandler) OnBillDeleted(fn func(ctx context.Context, bill *Bill) error) {
+	onWebhook(h, ResourceBills, WebhookEventActionDeleted, fn)
+}
+
+func (h *WebhookHandler) OnClinicalDocumentSaved(fn func(ctx context.Context, clinicalDocument *ClinicalDocument) error) {
+	onWebhook(h, ResourceClinicalDocuments, WebhookEventActionSaved, fn)
+}
+
+func (h *WebhookHandler) OnClinicalDocumentDeleted(fn func(ctx context.Context, clinicalDocument *ClinicalDocument) error) {
+	onWebhook(h, ResourceClinicalDocuments, WebhookEventActionDeleted, fn)
+}
+
+func (h *WebhookHandler) OnDiscontinuedMedicationSaved(fn func(ctx context.Context, discontinuedMedication *DiscontinuedMedication) error) {
+	onWebhook(h, ResourceDiscontinuedMedications, WebhookEventActionSaved, fn)
+}
+
+func (h *WebhookHandler) OnDiscontinuedMedicationDeleted(fn func(ctx context.Context, discontinuedMedication *DiscontinuedMedication) error) {
+	onWebhook(h, ResourceDiscontinuedMedications, WebhookEventActionDeleted, fn)
+}
+
+func (h *WebhookHandler) OnInsuranceCompanySaved(fn func(ctx context.Context, insuranceCompany *InsuranceCompany) error) {
+	onWebhook(h, ResourceInsuranceCompanies, WebhookEventActionSaved, fn)
+}
+
+func (h *WebhookHandler) OnInsuranceCompanyDeleted(fn func(ctx context.Context, insuranceCompany *InsuranceCompany) error) {
+	onWebhook(h, ResourceInsuranceCompanies, WebhookEventActionDeleted, fn)
+}
+
+func (h *WebhookHandler) OnInsurancePlanSaved(fn func(ctx context.Context, insurancePlan *InsurancePlan) error) {
+	onWebhook(h, ResourceInsurancePlans, WebhookEventActionSaved, fn)
+}
+
+func (h *WebhookHandler) OnInsurancePlanDeleted(fn func(ctx context.Context, insurancePlan *InsurancePlan) error) {
+	onWebhook(h, ResourceInsurancePlans, WebhookEventActionDeleted, fn)
+}
+
+func (h *WebhookHandler) OnInsurancePolicySaved(fn func(ctx context.Context, insurancePolicy *InsurancePolicy) error) {
+	onWebhook(h, ResourceInsurancePolicies, WebhookEventActionSaved, fn)
+}
+
+func (h *WebhookHandler) OnInsurancePolicyDeleted(fn func(ctx context.Context, insurancePolicy *InsurancePolicy) error) {
+	onWebhook(h, ResourceInsurancePolicies, WebhookEventActionDeleted, fn)
+}
+
+func (h *WebhookHandler) OnLetterSaved(fn func(ctx context.Context, letter *Letter) error) {
+	onWebhook(h, ResourceLetters, WebhookEventActionSaved, fn)
+}
+
+func (h *WebhookHandler) OnLetterDeleted(fn func(ctx context.Context, letter *Letter) error) {
+	onWebhook(h, ResourceLetters, WebhookEventActionDeleted, fn)
+}
+
+func (h *WebhookHandler) OnMedicationSaved(fn func(ctx context.Context, medication *PatientMedication) error) {
+	onWebhook(h, ResourceMedications, WebhookEventActionSaved, fn)
+}
+
+func (h *WebhookHandler) OnMedicationDeleted(fn func(ctx context.Context, medication *PatientMedication) error) {
+	onWebhook(h, ResourceMedications, WebhookEventActionDeleted, fn)
+}
+
+func (h *WebhookHandler) OnMessageThreadSaved(fn func(ctx context.Context, messageThread *MessageThread) error) {
+	onWebhook(h, ResourceMessageThreads, WebhookEventActionSaved, fn)
+}
+
+func (h *WebhookHandler) OnMessageThreadDeleted(fn func(ctx context.Context, messageThread *MessageThread) error) {
+	onWebhook(h, ResourceMessageThreads, WebhookEventActionDeleted, fn)
+}
+
+func (h *WebhookHandler) OnNonVisitNoteSaved(fn func(ctx context.Context, nonVisitNote *NonVisitNote) error) {
+	onWebhook(h, ResourceNonVisitNotes, WebhookEventActionSaved, fn)
+}
+
+func (h *WebhookHandler) OnNonVisitNoteDeleted(fn func(ctx context.Context, nonVisitNote *NonVisitNote) error) {
+	onWebhook(h, ResourceNonVisitNotes, WebhookEventActionDeleted, fn)
+}
+
+func (h *WebhookHandler) OnPatientSaved(fn func(ctx context.Context, patient *Patient) error) {
+	onWebhook(h, ResourcePatients, WebhookEventActionSaved, fn)
+}
+
+func (h *WebhookHandler) OnPatientDeleted(fn func(ctx context.Context, patient *Patient) error) {
+	onWebhook(h, ResourcePatients, WebhookEventActionDeleted, fn)
+}
+
+func (h *WebhookHandler) OnPhysicianSaved(fn func(ctx context.Context, physician *Physician) error) {
+	onWebhook(h, ResourcePhysicians, WebhookEventActionSaved, fn)
+}
+
+func (h *WebhookHandler) OnPhysicianDeleted(fn func(ctx context.Context, physician *Physician) error) {
+	onWebhook(h, ResourcePhysicians, WebhookEventActionDeleted, fn)
+}
+
+func (h *WebhookHandler) OnProblemSaved(fn func(ctx context.Context, problem *PatientProblem) error) {
+	onWebhook(h, ResourceProblems, WebhookEventActionSaved, fn)
+}
+
+func (h *WebhookHandler) OnProblemDeleted(fn func(ctx context.Context, problem *PatientProblem) error) {
+	onWebhook(h, ResourceProblems, WebhookEventActionDeleted, fn)
+}
+
+func (h *WebhookHandler) OnReportSaved(fn func(ctx context.Context, report *Report) error) {
+	onWebhook(h, ResourceReports, WebhookEventActionSaved, fn)
+}
+
+func (h *WebhookHandler) OnReportDeleted(fn func(ctx context.Context, report *Report) error) {
+	onWebhook(h, ResourceReports, WebhookEventActionDeleted, fn)
+}
+
+func (h *WebhookHandler) OnServiceLocationSaved(fn func(ctx context.Context, serviceLocation *ServiceLocation) error) {
+	onWebhook(h, ResourceServiceLocations, WebhookEventActionSaved, fn)
+}
+
+func (h *WebhookHandler) OnServiceLocationDeleted(fn func(ctx context.Context, serviceLocation *ServiceLocation) error) {
+	onWebhook(h, ResourceServiceLocations, WebhookEventActionDeleted, fn)
+}
+
+func (h *WebhookHandler) OnVisitNoteSaved(fn func(ctx context.Context, visitNote *VisitNote) error) {
+	onWebhook(h, ResourceVisitNotes, WebhookEventActionSaved, fn)
+}
+
+func (h *WebhookHandler) OnVisitNoteDeleted(fn func(ctx context.Context, visitNote *VisitNote) error) {
+	onWebhook(h, ResourceVisitNotes, WebhookEventActionDeleted, fn)
+}
+
+// onWebhook registers fn with Data decoded into a T. Deleted events are decoded the same way, although Elation may
+// only send the ID of the deleted row.
+func onWebhook[T any](h *WebhookHandler, resource Resource, action WebhookEventAction, fn func(context.Context, *T) error) {
+	h.OnEvent(resource, action, func(ctx context.Context, event *Event) error {
+		data := new(T)
+		if err := json.Unmarshal(event.Data, data); err != nil {
+			return &webhookDecodeError{err}
+		}
+
+		return fn(ctx, data)
+	})
+}
+
+// webhookDecodeError marks events whose data does not match their resource, which retrying would not fix.
+type webhookDecodeError struct {
+	err error
+}
+
+func (e *webhookDecodeError) Error() string {
+	return fmt.Sprintf("unmarshaling event data: %v", e.err)
+}
+
+func (e *webhookDecodeError) Unwrap() error {
+	return e.err
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		h.fail(w, r, http.StatusMethodNotAllowed, fmt.Errorf("unexpected method %s", r.Method))
+		return
+	}
+
+	event, err := h.verifier.Verify(r)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPublicKeyLength), errors.Is(err, ErrSigningKeyUnavailable):
+			h.fail(w, r, http.StatusInternalServerError, err)
+		case errors.Is(err, ErrWebhookSignature):
+			h.fail(w, r, http.StatusUnauthorized, err)
+		default:
+			h.fail(w, r, http.StatusBadRequest, err)
+		}
+
+		return
+	}
+
+	err = h.Handle(r.Context(), event)
+
+	var decodeErr *webhookDecodeError
+	switch {
+	case errors.As(err, &decodeErr):
+		h.fail(w, r, http.StatusBadRequest, err)
+		return
+	case err != nil:
+		h.fail(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// Handle dispatches an event that has already been verified, such as one emitted by a ChangeFeed, the same way
+// ServeHTTP does. With an EventStore, the event is claimed before its callback is called, and an event that another
+// call is processing returns an error that wraps ErrEventInProgress.
+func (h *WebhookHandler) Handle(ctx context.Context, event *Event) (err error) {
+	h.mu.RLock()
+	fn := h.callbacks[webhookRoute{event.Resource, event.Action}]
+	onOutOfOrder := h.onOutOfOrder
+	store := h.store
+	h.mu.RUnlock()
+
+	// recorded is set once Record is called, which releases the claim on the event even if it fails.
+	recorded := false
+
+	if store != nil {
+		status, err := store.Claim(ctx, event)
+		if err != nil {
+			return fmt.Errorf("claiming event %d: %w", event.EventID, err)
+		}
+
+		switch status {
+		case EventStatusDuplicate:
+			return nil
+		case EventStatusInProgress:
+			return fmt.Errorf("%w: %d", ErrEventInProgress, event.EventID)
+		case EventStatusOutOfOrder:
+			fn = onOutOfOrder
+		}
+
+		// Release the claim when fn fails or panics, so that the event is processed again when it is retried.
+		defer func() {
+			if recorded {
+				return
+			}
+
+			if releaseErr := store.Release(context.WithoutCancel(ctx), event); releaseErr != nil {
+				err = errors.Join(err, fmt.Errorf("releasing event %d: %w", event.EventID, releaseErr))
+			}
+		}()
+	}
+
+	if fn != nil {
+		if err := fn(ctx, event); err != nil {
+			return fmt.Errorf("handling %s %s event %d: %w", event.Resource, event.Action, event.EventID, err)
+		}
+	}
+
+	if store != nil {
+		recorded = true
+		if err := store.Record(ctx, event); err != nil {
+			return fmt.Errorf("recording event %d: %w", event.EventID, err)
+		}
+	}
+
+	return nil
+}
+
+func (h *WebhookHandler) fail(w http.ResponseWriter, r *http.Request, statusCode int, err error) {
+	h.mu.RLock()
+	onError := h.onError
+	h.mu.RUnlock()
+
+	if onError != nil {
+		onError(r, err)
+	}
+
+	http.Error(w, http.StatusText(statusCode), statusCode)
+}
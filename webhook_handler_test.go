@@ -0,0 +1,344 @@
+package elation
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookHandler(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	_, otherPrivateKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	testCases := map[string]struct {
+		method             string
+		event              *Event
+		privateKey         ed25519.PrivateKey
+		expectedStatusCode int
+		expectedPatient    *Patient
+		expectedErr        bool
+	}{
+		"patient saved": {
+			event: &Event{
+				Data:     []byte(`{"id":1,"first_name":"Jane"}`),
+				Action:   WebhookEventActionSaved,
+				EventID:  1,
+				Resource: ResourcePatients,
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedPatient:    &Patient{ID: 1, FirstName: "Jane"},
+		},
+		"callback error": {
+			event: &Event{
+				Data:     []byte(`{"id":2}`),
+				Action:   WebhookEventActionSaved,
+				Resource: ResourcePatients,
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedPatient:    &Patient{ID: 2},
+			expectedErr:        true,
+		},
+		"invalid data": {
+			event: &Event{
+				Data:     []byte(`{"id":"foo"}`),
+				Action:   WebhookEventActionSaved,
+				Resource: ResourcePatients,
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        true,
+		},
+		"no callback": {
+			event: &Event{
+				Data:     []byte(`{"id":1}`),
+				Action:   WebhookEventActionDeleted,
+				Resource: ResourcePatients,
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		"invalid signature": {
+			event: &Event{
+				Data:     []byte(`{"id":1}`),
+				Action:   WebhookEventActionSaved,
+				Resource: ResourcePatients,
+			},
+			privateKey:         otherPrivateKey,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErr:        true,
+		},
+		"method not allowed": {
+			method: http.MethodGet,
+			event: &Event{
+				Data: []byte(`{}`),
+			},
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedErr:        true,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var actualPatient *Patient
+			var actualErr error
+
+			handler := NewWebhookHandler(publicKey)
+			handler.OnPatientSaved(func(ctx context.Context, patient *Patient) error {
+				actualPatient = patient
+				if patient.ID == 2 {
+					return errors.New("foo")
+				}
+
+				return nil
+			})
+			handler.OnError(func(r *http.Request, err error) {
+				actualErr = err
+			})
+
+			key := privateKey
+			if testCase.privateKey != nil {
+				key = testCase.privateKey
+			}
+
+			method := http.MethodPost
+			if testCase.method != "" {
+				method = testCase.method
+			}
+
+			b, err := json.Marshal(testCase.event)
+			assert.NoError(err)
+
+			req := httptest.NewRequest(method, "/", bytes.NewReader(b))
+			req.Header.Set(WebhookSignatureHeader, base64.StdEncoding.EncodeToString(ed25519.Sign(key, b)))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(testCase.expectedStatusCode, rec.Code)
+			assert.Equal(testCase.expectedPatient, actualPatient)
+			assert.Equal(testCase.expectedErr, actualErr != nil)
+		})
+	}
+}
+
+func TestWebhookHandler_OnEvent(t *testing.T) {
+	assert := assert.New(t)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(err)
+
+	var actual *Event
+
+	handler := NewWebhookHandler(publicKey)
+	handler.OnAppointmentSaved(func(ctx context.Context, appointment *Appointment) error {
+		return errors.New("replaced")
+	})
+	handler.OnEvent(ResourceAppointments, WebhookEventActionSaved, func(ctx context.Context, event *Event) error {
+		actual = event
+		return nil
+	})
+
+	event := &Event{
+		Data:     []byte(`{"id":1}`),
+		Action:   WebhookEventActionSaved,
+		EventID:  1,
+		Resource: ResourceAppointments,
+	}
+	b, err := json.Marshal(event)
+	assert.NoError(err)
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(b))
+	req.Header.Set(WebhookSignatureHeader, base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, b)))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Equal(event, actual)
+}
+
+func TestWebhookHandler_SetEventStore(t *testing.T) {
+	assert := assert.New(t)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(err)
+
+	var saved []int64
+	var outOfOrder []int64
+	fail := true
+
+	handler := NewWebhookHandler(publicKey)
+	handler.SetEventStore(NewMemoryEventStore(100))
+	handler.OnPatientSaved(func(ctx context.Context, patient *Patient) error {
+		if fail {
+			fail = false
+			return errors.New("foo")
+		}
+
+		saved = append(saved, patient.ID)
+
+		return nil
+	})
+	handler.OnOutOfOrder(func(ctx context.Context, event *Event) error {
+		outOfOrder = append(outOfOrder, event.EventID)
+		return nil
+	})
+
+	send := func(event *Event) int {
+		b, err := json.Marshal(event)
+		assert.NoError(err)
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(b))
+		req.Header.Set(WebhookSignatureHeader, base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, b)))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	// A failed event is not recorded, so its retry is processed.
+	assert.Equal(http.StatusInternalServerError, send(testEvent(2, ResourcePatients, 1)))
+	assert.Equal(http.StatusOK, send(testEvent(2, ResourcePatients, 1)))
+	assert.Equal(http.StatusOK, send(testEvent(2, ResourcePatients, 1)))
+	assert.Equal(http.StatusOK, send(testEvent(1, ResourcePatients, 1)))
+
+	assert.Equal([]int64{1}, saved)
+	assert.Equal([]int64{1}, outOfOrder)
+}
+
+func TestWebhookHandler_Handle_concurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	calls := 0
+
+	handler := NewWebhookHandler(nil)
+	handler.SetEventStore(NewMemoryEventStore(100))
+	handler.OnEvent(ResourcePatients, WebhookEventActionSaved, func(ctx context.Context, event *Event) error {
+		calls++
+		close(started)
+		<-release
+
+		return nil
+	})
+
+	ctx := context.Background()
+	event := testEvent(1, ResourcePatients, 1)
+
+	done := make(chan error)
+	go func() {
+		done <- handler.Handle(ctx, event)
+	}()
+
+	<-started
+
+	// A redelivery while the event is being processed is not processed again.
+	assert.ErrorIs(handler.Handle(ctx, event), ErrEventInProgress)
+
+	close(release)
+	assert.NoError(<-done)
+
+	assert.NoError(handler.Handle(ctx, event))
+	assert.Equal(1, calls)
+}
+
+func TestWebhookHandler_resources(t *testing.T) {
+	testCases := map[Resource]struct {
+		value    any
+		register func(h *WebhookHandler, called *bool)
+	}{
+		ResourceAllergies: {&Allergy{ID: 1}, func(h *WebhookHandler, called *bool) {
+			h.OnAllergySaved(func(ctx context.Context, v *Allergy) error { *called = v.ID == 1; return nil })
+		}},
+		ResourceAllergyDocumentation: {&AllergyDocumentation{ID: 1}, func(h *WebhookHandler, called *bool) {
+			h.OnAllergyDocumentationSaved(func(ctx context.Context, v *AllergyDocumentation) error { *called = v.ID == 1; return nil })
+		}},
+		ResourceAppointments: {&Appointment{ID: 1}, func(h *WebhookHandler, called *bool) {
+			h.OnAppointmentSaved(func(ctx context.Context, v *Appointment) error { *called = v.ID == 1; return nil })
+		}},
+		ResourceBills: {&Bill{ID: 1}, func(h *WebhookHandler, called *bool) {
+			h.OnBillSaved(func(ctx context.Context, v *Bill) error { *called = v.ID == 1; return nil })
+		}},
+		ResourceClinicalDocuments: {&ClinicalDocument{ID: 1}, func(h *WebhookHandler, called *bool) {
+			h.OnClinicalDocumentSaved(func(ctx context.Context, v *ClinicalDocument) error { *called = v.ID == 1; return nil })
+		}},
+		ResourceDiscontinuedMedications: {&DiscontinuedMedication{ID: 1}, func(h *WebhookHandler, called *bool) {
+			h.OnDiscontinuedMedicationSaved(func(ctx context.Context, v *DiscontinuedMedication) error { *called = v.ID == 1; return nil })
+		}},
+		ResourceInsuranceCompanies: {&InsuranceCompany{ID: 1}, func(h *WebhookHandler, called *bool) {
+			h.OnInsuranceCompanySaved(func(ctx context.Context, v *InsuranceCompany) error { *called = v.ID == 1; return nil })
+		}},
+		ResourceInsurancePlans: {&InsurancePlan{ID: 1}, func(h *WebhookHandler, called *bool) {
+			h.OnInsurancePlanSaved(func(ctx context.Context, v *InsurancePlan) error { *called = v.ID == 1; return nil })
+		}},
+		ResourceInsurancePolicies: {&InsurancePolicy{ID: 1}, func(h *WebhookHandler, called *bool) {
+			h.OnInsurancePolicySaved(func(ctx context.Context, v *InsurancePolicy) error { *called = v.ID == 1; return nil })
+		}},
+		ResourceLetters: {&Letter{ID: 1}, func(h *WebhookHandler, called *bool) {
+			h.OnLetterSaved(func(ctx context.Context, v *Letter) error { *called = v.ID == 1; return nil })
+		}},
+		ResourceMedications: {&PatientMedication{ID: 1}, func(h *WebhookHandler, called *bool) {
+			h.OnMedicationSaved(func(ctx context.Context, v *PatientMedication) error { *called = v.ID == 1; return nil })
+		}},
+		ResourceMessageThreads: {&MessageThread{ID: 1}, func(h *WebhookHandler, called *bool) {
+			h.OnMessageThreadSaved(func(ctx context.Context, v *MessageThread) error { *called = v.ID == 1; return nil })
+		}},
+		ResourceNonVisitNotes: {&NonVisitNote{ID: 1}, func(h *WebhookHandler, called *bool) {
+			h.OnNonVisitNoteSaved(func(ctx context.Context, v *NonVisitNote) error { *called = v.ID == 1; return nil })
+		}},
+		ResourcePatients: {&Patient{ID: 1}, func(h *WebhookHandler, called *bool) {
+			h.OnPatientSaved(func(ctx context.Context, v *Patient) error { *called = v.ID == 1; return nil })
+		}},
+		ResourcePhysicians: {&Physician{ID: 1}, func(h *WebhookHandler, called *bool) {
+			h.OnPhysicianSaved(func(ctx context.Context, v *Physician) error { *called = v.ID == 1; return nil })
+		}},
+		ResourceProblems: {&PatientProblem{ID: 1}, func(h *WebhookHandler, called *bool) {
+			h.OnProblemSaved(func(ctx context.Context, v *PatientProblem) error { *called = v.ID == 1; return nil })
+		}},
+		ResourceReports: {&Report{ID: 1}, func(h *WebhookHandler, called *bool) {
+			h.OnReportSaved(func(ctx context.Context, v *Report) error { *called = v.ID == 1; return nil })
+		}},
+		ResourceServiceLocations: {&ServiceLocation{ID: 1}, func(h *WebhookHandler, called *bool) {
+			h.OnServiceLocationSaved(func(ctx context.Context, v *ServiceLocation) error { *called = v.ID == 1; return nil })
+		}},
+		ResourceVisitNotes: {&VisitNote{ID: 1}, func(h *WebhookHandler, called *bool) {
+			h.OnVisitNoteSaved(func(ctx context.Context, v *VisitNote) error { *called = v.ID == 1; return nil })
+		}},
+	}
+
+	for _, resource := range Resources() {
+		assert.Contains(t, testCases, resource)
+	}
+
+	for resource, testCase := range testCases {
+		t.Run(string(resource), func(t *testing.T) {
+			assert := assert.New(t)
+
+			event, err := NewEvent(WebhookEventActionSaved, testCase.value)
+			if !assert.NoError(err) {
+				return
+			}
+
+			assert.Equal(resource, event.Resource)
+
+			var called bool
+			handler := NewWebhookHandler(nil)
+			testCase.register(handler, &called)
+
+			assert.NoError(handler.Handle(context.Background(), event))
+			assert.True(called)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package elation
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// EventSender POSTs signed events to a webhook receiver, to test it without Elation.
+type EventSender struct {
+	// HTTPClient sends the webhooks. It defaults to a client with a 10 second timeout.
+	HTTPClient *http.Client
+
+	// ApplicationID is set on events that do not have one.
+	ApplicationID string
+
+	target     string
+	privateKey ed25519.PrivateKey
+	eventID    atomic.Int64
+}
+
+// NewEventSender returns a sender that signs events with privateKey and POSTs them to target. Events without an
+// EventID are numbered from the current Unix time in milliseconds, so that they are newer than the events of previous
+// senders.
+func NewEventSender(target string, privateKey ed25519.PrivateKey) *EventSender {
+	s := &EventSender{
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+		target:     target,
+		privateKey: privateKey,
+	}
+
+	s.eventID.Store(time.Now().UnixMilli())
+
+	return s
+}
+
+// Send signs and POSTs event. A response with a status code of 400 or greater is returned along with an *Error.
+func (s *EventSender) Send(ctx context.Context, event *Event) (*http.Response, error) {
+	e := *event
+	if e.EventID == 0 {
+		e.EventID = s.eventID.Add(1)
+	}
+
+	if e.ApplicationID == "" {
+		e.ApplicationID = s.ApplicationID
+	}
+
+	body, signature, err := SignWebhook(s.privateKey, &e)
+	if err != nil {
+		return nil, fmt.Errorf("signing event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.target, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("making new HTTP request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookSignatureHeader, signature)
+
+	res, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("doing HTTP request: %w", err)
+	}
+	defer res.Body.Close() //nolint
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return res, fmt.Errorf("reading response body: %w", err)
+	}
+
+	// The body has been read and closed, so give callers a copy they can still read.
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return res, newError(res, resBody)
+	}
+
+	return res, nil
+}
+
+// SendSaved sends a saved event for v, which must be one of the webhook-enabled types, such as *Patient.
+func (s *EventSender) SendSaved(ctx context.Context, v any) (*http.Response, error) {
+	event, err := NewEvent(WebhookEventActionSaved, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Send(ctx, event)
+}
+
+// SendDeleted sends a deleted event for v, which must be one of the webhook-enabled types, such as *Patient.
+func (s *EventSender) SendDeleted(ctx context.Context, v any) (*http.Response, error) {
+	event, err := NewEvent(WebhookEventActionDeleted, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Send(ctx, event)
+}
+
+// NewEvent builds an event for v, whose resource is inferred from its type. The data of deleted events is only the ID,
+// as Elation sends it.
+func NewEvent(action WebhookEventAction, v any) (*Event, error) {
+	resource, err := eventResource(v)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling event data: %w", err)
+	}
+
+	event := &Event{
+		Data:     data,
+		Action:   action,
+		Resource: resource,
+	}
+
+	if action == WebhookEventActionDeleted {
+		event.Data = fmt.Appendf(nil, `{"id":%d}`, event.ResourceID())
+	}
+
+	return event, nil
+}
+
+func eventResource(v any) (Resource, error) {
+	switch v.(type) {
+	case *Allergy, Allergy:
+		return ResourceAllergies, nil
+	case *AllergyDocumentation, AllergyDocumentation:
+		return ResourceAllergyDocumentation, nil
+	case *Appointment, Appointment:
+		return ResourceAppointments, nil
+	case *Bill, Bill:
+		return ResourceBills, nil
+	case *ClinicalDocument, ClinicalDocument:
+		return ResourceClinicalDocuments, nil
+	case *DiscontinuedMedication, DiscontinuedMedication:
+		return ResourceDiscontinuedMedications, nil
+	case *InsuranceCompany, InsuranceCompany:
+		return ResourceInsuranceCompanies, nil
+	case *InsurancePlan, InsurancePlan:
+		return ResourceInsurancePlans, nil
+	case *InsurancePolicy, InsurancePolicy:
+		return ResourceInsurancePolicies, nil
+	case *Letter, Letter:
+		return ResourceLetters, nil
+	case *PatientMedication, PatientMedication:
+		return ResourceMedications, nil
+	case *MessageThread, MessageThread:
+		return ResourceMessageThreads, nil
+	case *NonVisitNote, NonVisitNote:
+		return ResourceNonVisitNotes, nil
+	case *Patient, Patient:
+		return ResourcePatients, nil
+	case *Physician, Physician:
+		return ResourcePhysicians, nil
+	case *PatientProblem, PatientProblem:
+		return ResourceProblems, nil
+	case *Report, Report:
+		return ResourceReports, nil
+	case *ServiceLocation, ServiceLocation:
+		return ResourceServiceLocations, nil
+	case *VisitNote, VisitNote:
+		return ResourceVisitNotes, nil
+	default:
+		return "", fmt.Errorf("no webhook resource for %T", v)
+	}
+}
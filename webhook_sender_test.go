@@ -0,0 +1,76 @@
+package elation
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventSender(t *testing.T) {
+	assert := assert.New(t)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(err)
+
+	var saved *Patient
+	var deleted *Appointment
+	var events []*Event
+
+	handler := NewWebhookHandler(publicKey)
+	handler.SetEventStore(NewMemoryEventStore(100))
+	handler.OnPatientSaved(func(ctx context.Context, patient *Patient) error {
+		saved = patient
+		return nil
+	})
+	handler.OnAppointmentDeleted(func(ctx context.Context, appointment *Appointment) error {
+		deleted = appointment
+		return nil
+	})
+	handler.OnProblemSaved(func(ctx context.Context, problem *PatientProblem) error {
+		return errors.New("foo")
+	})
+	handler.OnOutOfOrder(func(ctx context.Context, event *Event) error {
+		events = append(events, event)
+		return nil
+	})
+
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	sender := NewEventSender(srv.URL, privateKey)
+	sender.ApplicationID = "application-id"
+	ctx := context.Background()
+
+	res, err := sender.SendSaved(ctx, &Patient{ID: 1, FirstName: "Jane"})
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, res.StatusCode)
+	assert.Equal(&Patient{ID: 1, FirstName: "Jane"}, saved)
+
+	_, err = sender.SendDeleted(ctx, Appointment{ID: 2, Reason: "Follow-up"})
+	assert.NoError(err)
+	assert.Equal(&Appointment{ID: 2}, deleted)
+
+	res, err = sender.SendSaved(ctx, &PatientProblem{ID: 3})
+	assert.ErrorIs(err, ErrServer)
+	assert.Equal(http.StatusInternalServerError, res.StatusCode)
+
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(err)
+	assert.Equal("Internal Server Error\n", string(body))
+
+	_, err = sender.SendSaved(ctx, &Practice{})
+	assert.EqualError(err, "no webhook resource for *elation.Practice")
+
+	// Explicit event IDs are kept, so older events can be sent.
+	_, err = sender.Send(ctx, &Event{Data: []byte(`{"id":1}`), Action: WebhookEventActionSaved, EventID: 1, Resource: ResourcePatients})
+	assert.NoError(err)
+	if assert.Len(events, 1) {
+		assert.Equal("application-id", events[0].ApplicationID)
+	}
+}
@@ -23,7 +23,7 @@ func TestWebhook(t *testing.T) {
 		Action:        "action",
 		EventID:       1,
 		ApplicationID: "application-id",
-		Resource:      "resource",
+		Resource:      ResourcePatients,
 	}
 	b, err := json.Marshal(event)
 	assert.NoError(err)
@@ -47,3 +47,74 @@ func TestWebhook_incorrect_public_key_len(t *testing.T) {
 	assert.Nil(event)
 	assert.ErrorIs(err, ErrPublicKeyLength)
 }
+
+func TestSignWebhook(t *testing.T) {
+	assert := assert.New(t)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(err)
+
+	event := &Event{
+		Data:          []byte(`{"id":1}`),
+		Action:        WebhookEventActionSaved,
+		EventID:       1,
+		ApplicationID: "application-id",
+		Resource:      ResourcePatients,
+	}
+
+	body, sig, err := SignWebhook(privateKey, event)
+	assert.NoError(err)
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set(WebhookSignatureHeader, sig)
+
+	actualEvent, err := VerifyWebhook(req, publicKey)
+	assert.NoError(err)
+	assert.Equal(event, actualEvent)
+
+	_, _, err = SignWebhook([]byte("foo"), event)
+	assert.ErrorIs(err, ErrPrivateKeyLength)
+}
+
+func TestWebhook_unknown_resource(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	testCases := map[string]struct {
+		resource    Resource
+		opts        []VerifyOption
+		expectedErr error
+	}{
+		"known": {
+			resource: ResourceVisitNotes,
+		},
+		"unknown": {
+			resource:    "foo",
+			expectedErr: ErrUnknownResource,
+		},
+		"unknown allowed": {
+			resource: "foo",
+			opts:     []VerifyOption{WithUnknownResources()},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			body, sig, err := SignWebhook(privateKey, &Event{Data: []byte(`{}`), Resource: testCase.resource})
+			assert.NoError(err)
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+			req.Header.Set(WebhookSignatureHeader, sig)
+
+			event, err := VerifyWebhook(req, publicKey, testCase.opts...)
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(err, testCase.expectedErr)
+				assert.Nil(event)
+			} else {
+				assert.NoError(err)
+				assert.Equal(testCase.resource, event.Resource)
+			}
+		})
+	}
+}